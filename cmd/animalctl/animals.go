@@ -0,0 +1,365 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/one-byte-data/go-api-sample/pkg/client"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// animal has the fields of both client.Cat and client.Dog, so either
+// converts to and from it.
+type animal struct {
+	ID        uuid.UUID `json:"id,omitempty"`
+	Name      string    `json:"name"`
+	Breed     string    `json:"breed"`
+	Color     string    `json:"color"`
+	Birthdate time.Time `json:"birthdate"`
+	Weight    int       `json:"weight"`
+	// BreedStatus and Status are set by the server.
+	BreedStatus string `json:"breed_status,omitempty"`
+	Status      string `json:"status,omitempty"`
+}
+
+type species[T any] struct {
+	name     string
+	singular string
+	resource func(*client.Client) *client.Resource[T]
+	from     func(animal) T
+	to       func(T) animal
+}
+
+// animalFlags set fields on create and update. Only flags that were given
+// change a field.
+type animalFlags struct {
+	file      string
+	name      string
+	breed     string
+	color     string
+	birthdate string
+	weight    int
+}
+
+func (f *animalFlags) register(cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.StringVarP(&f.file, "file", "f", "", "read the "+cmd.Parent().Name()+" from a JSON or YAML file, - for stdin")
+	flags.StringVar(&f.name, "name", "", "name")
+	flags.StringVar(&f.breed, "breed", "", "breed")
+	flags.StringVar(&f.color, "color", "", "color")
+	flags.StringVar(&f.birthdate, "birthdate", "", "birthdate as 2006-01-02 or RFC 3339")
+	flags.IntVar(&f.weight, "weight", 0, "weight")
+}
+
+func (f *animalFlags) apply(cmd *cobra.Command, c *cli, a *animal) error {
+	if f.file != "" {
+		if err := readFile(c, f.file, a); err != nil {
+			return err
+		}
+	}
+
+	flags := cmd.Flags()
+	if flags.Changed("name") {
+		a.Name = f.name
+	}
+	if flags.Changed("breed") {
+		a.Breed = f.breed
+	}
+	if flags.Changed("color") {
+		a.Color = f.color
+	}
+	if flags.Changed("weight") {
+		a.Weight = f.weight
+	}
+	if flags.Changed("birthdate") {
+		birthdate, err := parseDate(f.birthdate)
+		if err != nil {
+			return err
+		}
+		a.Birthdate = birthdate
+	}
+	return nil
+}
+
+func parseDate(value string) (time.Time, error) {
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid birthdate %q, use 2006-01-02 or RFC 3339", value)
+	}
+	return t, nil
+}
+
+// readFile decodes JSON or YAML into v. YAML is decoded generically and
+// re-encoded as JSON so both formats use the API's field names.
+func readFile(c *cli, path string, v interface{}) error {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(c.in)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return err
+	}
+
+	var generic interface{}
+	if err := yaml.Unmarshal(data, &generic); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	data, err = json.Marshal(generic)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+func parseIDs(args []string) ([]uuid.UUID, error) {
+	ids := make([]uuid.UUID, 0, len(args))
+	for _, arg := range args {
+		id, err := uuid.Parse(arg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid id %q", arg)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func newAnimalCommand[T any](c *cli, s species[T]) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   s.name,
+		Short: "Manage " + s.name,
+	}
+
+	// completeIDs offers the IDs and names of the animals on the server.
+	completeIDs := func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		api, err := c.client()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		items, err := s.resource(api).List(cmd.Context())
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		ids := make([]string, 0, len(items))
+		for _, item := range items {
+			a := s.to(item)
+			ids = append(ids, a.ID.String()+"\t"+a.Name)
+		}
+		return ids, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	printAnimals := func(items []T) error {
+		animals := make([]animal, 0, len(items))
+		for _, item := range items {
+			animals = append(animals, s.to(item))
+		}
+		return write(c.out, c.output, animals, animalTable(animals))
+	}
+	printAnimal := func(item *T) error {
+		a := s.to(*item)
+		return write(c.out, c.output, a, animalTable([]animal{a}))
+	}
+
+	list := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List all " + s.name,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			api, err := c.client()
+			if err != nil {
+				return err
+			}
+			items, err := s.resource(api).List(cmd.Context())
+			if err != nil {
+				return err
+			}
+			return printAnimals(items)
+		},
+	}
+
+	get := &cobra.Command{
+		Use:               "get ID",
+		Short:             "Show a " + s.singular,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeIDs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ids, err := parseIDs(args)
+			if err != nil {
+				return err
+			}
+			api, err := c.client()
+			if err != nil {
+				return err
+			}
+			item, err := s.resource(api).Get(cmd.Context(), ids[0])
+			if err != nil {
+				return err
+			}
+			return printAnimal(item)
+		},
+	}
+
+	createFlags := &animalFlags{}
+	create := &cobra.Command{
+		Use:   "create",
+		Short: "Add a " + s.singular + " from flags or a file",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			a := animal{}
+			if err := createFlags.apply(cmd, c, &a); err != nil {
+				return err
+			}
+			api, err := c.client()
+			if err != nil {
+				return err
+			}
+			item := s.from(a)
+			created, err := s.resource(api).Create(cmd.Context(), &item)
+			if err != nil {
+				return err
+			}
+			return printAnimal(created)
+		},
+	}
+
+	updateFlags := &animalFlags{}
+	update := &cobra.Command{
+		Use:               "update ID",
+		Short:             "Change a " + s.singular + "; fields without a flag keep their value",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeIDs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ids, err := parseIDs(args)
+			if err != nil {
+				return err
+			}
+			api, err := c.client()
+			if err != nil {
+				return err
+			}
+			current, err := s.resource(api).Get(cmd.Context(), ids[0])
+			if err != nil {
+				return err
+			}
+			a := s.to(*current)
+			if err := updateFlags.apply(cmd, c, &a); err != nil {
+				return err
+			}
+			item := s.from(a)
+			updated, err := s.resource(api).Update(cmd.Context(), ids[0], &item)
+			if err != nil {
+				return err
+			}
+			return printAnimal(updated)
+		},
+	}
+
+	del := &cobra.Command{
+		Use:               "delete ID...",
+		Aliases:           []string{"rm"},
+		Short:             "Delete " + s.name,
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: completeIDs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ids, err := parseIDs(args)
+			if err != nil {
+				return err
+			}
+			api, err := c.client()
+			if err != nil {
+				return err
+			}
+			for _, id := range ids {
+				if err := s.resource(api).Delete(cmd.Context(), id); err != nil {
+					return fmt.Errorf("deleting %s: %w", id, err)
+				}
+				fmt.Fprintf(c.err, "deleted %s %s\n", s.singular, id)
+			}
+			return nil
+		},
+	}
+
+	var importFile string
+	importCmd := &cobra.Command{
+		Use:   "import -f FILE",
+		Short: "Add every " + s.singular + " in a JSON or YAML list",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			animals := make([]animal, 0)
+			if err := readFile(c, importFile, &animals); err != nil {
+				return err
+			}
+			api, err := c.client()
+			if err != nil {
+				return err
+			}
+
+			var failed error
+			for i, a := range animals {
+				item := s.from(a)
+				created, err := s.resource(api).Create(cmd.Context(), &item)
+				if err != nil {
+					failed = errors.Join(failed, fmt.Errorf("%s %d (%s): %w", s.singular, i+1, a.Name, err))
+					continue
+				}
+				fmt.Fprintf(c.err, "created %s %s\n", s.singular, s.to(*created).ID)
+			}
+			return failed
+		},
+	}
+	importCmd.Flags().StringVarP(&importFile, "file", "f", "", "JSON or YAML file, - for stdin")
+	importCmd.MarkFlagRequired("file")
+
+	var exportFile string
+	export := &cobra.Command{
+		Use:   "export",
+		Short: "Write all " + s.name + " as JSON or YAML that import reads",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format := c.output
+			if format == outputTable {
+				format = outputJSON
+			}
+			api, err := c.client()
+			if err != nil {
+				return err
+			}
+			items, err := s.resource(api).List(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			out := c.out
+			if exportFile != "" && exportFile != "-" {
+				f, err := os.Create(exportFile)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				out = f
+			}
+			return write(out, format, items, table{})
+		},
+	}
+	export.Flags().StringVarP(&exportFile, "file", "f", "", "file to write, stdout by default")
+
+	cmd.AddCommand(list, get, create, update, del, importCmd, export)
+	createFlags.register(create)
+	updateFlags.register(update)
+	return cmd
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Profile is where to find a server and how to authenticate with it.
+type Profile struct {
+	Server string `yaml:"server"`
+	APIKey string `yaml:"api_key,omitempty"`
+	Actor  string `yaml:"actor,omitempty"`
+	// Tenant is the tenant the requests are made for, the default one when
+	// empty. The API key must belong to it, or be the admin key.
+	Tenant string `yaml:"tenant,omitempty"`
+}
+
+// Config is stored as YAML in $XDG_CONFIG_HOME/animalctl/config.yaml, or
+// the file named by ANIMALCTL_CONFIG.
+type Config struct {
+	CurrentProfile string             `yaml:"current_profile"`
+	Profiles       map[string]Profile `yaml:"profiles"`
+}
+
+const defaultProfile = "default"
+
+func defaultConfigPath() (string, error) {
+	if path := os.Getenv("ANIMALCTL_CONFIG"); path != "" {
+		return path, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "animalctl", "config.yaml"), nil
+}
+
+// loadConfig returns an empty config when the file doesn't exist yet.
+func loadConfig(path string) (*Config, error) {
+	config := &Config{Profiles: make(map[string]Profile)}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return config, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if config.Profiles == nil {
+		config.Profiles = make(map[string]Profile)
+	}
+	return config, nil
+}
+
+// save writes the config readable only by the user, as it holds API keys.
+func (config *Config) save(path string) error {
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+// resolve picks the named profile, the current one or the default one, and
+// applies the ANIMALCTL_SERVER, ANIMALCTL_API_KEY, ANIMALCTL_ACTOR and
+// ANIMALCTL_TENANT overrides. Without any configuration it points at localhost.
+func (config *Config) resolve(name string) (Profile, error) {
+	if name == "" {
+		name = config.CurrentProfile
+	}
+	if name == "" {
+		name = defaultProfile
+	}
+
+	profile, ok := config.Profiles[name]
+	if !ok && name != defaultProfile {
+		return Profile{}, fmt.Errorf("profile %q does not exist, known profiles are %v", name, config.profileNames())
+	}
+	if profile.Server == "" {
+		profile.Server = "http://localhost:8080"
+	}
+
+	if value := os.Getenv("ANIMALCTL_SERVER"); value != "" {
+		profile.Server = value
+	}
+	if value := os.Getenv("ANIMALCTL_API_KEY"); value != "" {
+		profile.APIKey = value
+	}
+	if value := os.Getenv("ANIMALCTL_ACTOR"); value != "" {
+		profile.Actor = value
+	}
+	if value := os.Getenv("ANIMALCTL_TENANT"); value != "" {
+		profile.Tenant = value
+	}
+	return profile, nil
+}
+
+func (config *Config) profileNames() []string {
+	names := make([]string, 0, len(config.Profiles))
+	for name := range config.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
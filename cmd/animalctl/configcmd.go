@@ -0,0 +1,124 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+func newConfigCommand(c *cli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Manage the profiles in the config file",
+	}
+
+	completeProfiles := func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return c.completeProfiles(), cobra.ShellCompDirectiveNoFileComp
+	}
+
+	view := &cobra.Command{
+		Use:   "view",
+		Short: "Show the config with API keys redacted",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config, err := loadConfig(c.configPath)
+			if err != nil {
+				return err
+			}
+			rows := table{header: []string{"CURRENT", "NAME", "SERVER", "API KEY", "ACTOR", "TENANT"}}
+			for _, name := range config.profileNames() {
+				profile := config.Profiles[name]
+				if profile.APIKey != "" {
+					profile.APIKey = "REDACTED"
+				}
+				config.Profiles[name] = profile
+
+				current := ""
+				if name == config.CurrentProfile {
+					current = "*"
+				}
+				rows.rows = append(rows.rows, []string{current, name, profile.Server, profile.APIKey, profile.Actor, profile.Tenant})
+			}
+			return write(c.out, c.output, config, rows)
+		},
+	}
+
+	var profile Profile
+	setProfile := &cobra.Command{
+		Use:   "set-profile NAME",
+		Short: "Create or change a profile; fields without a flag keep their value",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config, err := loadConfig(c.configPath)
+			if err != nil {
+				return err
+			}
+			name := args[0]
+			existing := config.Profiles[name]
+			flags := cmd.Flags()
+			if flags.Changed("server") {
+				existing.Server = strings.TrimSuffix(profile.Server, "/")
+			}
+			if flags.Changed("api-key") {
+				existing.APIKey = profile.APIKey
+			}
+			if flags.Changed("actor") {
+				existing.Actor = profile.Actor
+			}
+			if flags.Changed("tenant") {
+				existing.Tenant = profile.Tenant
+			}
+			config.Profiles[name] = existing
+			if config.CurrentProfile == "" {
+				config.CurrentProfile = name
+			}
+			return config.save(c.configPath)
+		},
+	}
+	// The local flags shadow the global --server, --api-key and --tenant
+	// overrides.
+	setProfile.Flags().StringVar(&profile.Server, "server", "", "server URL")
+	setProfile.Flags().StringVar(&profile.APIKey, "api-key", "", "API key")
+	setProfile.Flags().StringVar(&profile.Actor, "actor", "", "name recorded in the audit log")
+	setProfile.Flags().StringVar(&profile.Tenant, "tenant", "", "tenant the requests are made for")
+
+	useProfile := &cobra.Command{
+		Use:               "use-profile NAME",
+		Short:             "Make a profile the current one",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeProfiles,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config, err := loadConfig(c.configPath)
+			if err != nil {
+				return err
+			}
+			if _, ok := config.Profiles[args[0]]; !ok {
+				return fmt.Errorf("profile %q does not exist, known profiles are %v", args[0], config.profileNames())
+			}
+			config.CurrentProfile = args[0]
+			return config.save(c.configPath)
+		},
+	}
+
+	deleteProfile := &cobra.Command{
+		Use:               "delete-profile NAME",
+		Short:             "Remove a profile",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeProfiles,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config, err := loadConfig(c.configPath)
+			if err != nil {
+				return err
+			}
+			delete(config.Profiles, args[0])
+			if config.CurrentProfile == args[0] {
+				config.CurrentProfile = ""
+			}
+			return config.save(c.configPath)
+		},
+	}
+
+	cmd.AddCommand(view, setProfile, useProfile, deleteProfile)
+	return cmd
+}
@@ -0,0 +1,143 @@
+// animalctl administers the cats and dogs of a go-api-sample server.
+//
+//	animalctl config set-profile prod --server https://api.example.com --api-key ...
+//	animalctl --profile prod cats list -o yaml
+//	animalctl dogs import -f dogs.yaml
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+
+	"github.com/one-byte-data/go-api-sample/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+var version string = "development"
+
+type cli struct {
+	configPath string
+	profile    string
+	output     string
+	server     string
+	apiKey     string
+	tenant     string
+
+	in  io.Reader
+	out io.Writer
+	err io.Writer
+}
+
+func main() {
+	if err := newRootCommand(os.Stdin, os.Stdout, os.Stderr).Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+func newRootCommand(in io.Reader, out io.Writer, errOut io.Writer) *cobra.Command {
+	c := &cli{in: in, out: out, err: errOut}
+
+	root := &cobra.Command{
+		Use:          "animalctl",
+		Short:        "Manage the cats and dogs of a go-api-sample server",
+		Version:      version,
+		SilenceUsage: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if !slices.Contains(outputFormats, c.output) {
+				return fmt.Errorf("unknown output format %q, use one of %v", c.output, outputFormats)
+			}
+			if c.configPath == "" {
+				path, err := defaultConfigPath()
+				if err != nil {
+					return err
+				}
+				c.configPath = path
+			}
+			return nil
+		},
+	}
+	root.SetIn(in)
+	root.SetOut(out)
+	root.SetErr(errOut)
+
+	flags := root.PersistentFlags()
+	flags.StringVar(&c.configPath, "config", "", "config file (default $XDG_CONFIG_HOME/animalctl/config.yaml or $ANIMALCTL_CONFIG)")
+	flags.StringVarP(&c.profile, "profile", "p", "", "profile to use (default the current profile)")
+	flags.StringVarP(&c.output, "output", "o", outputTable, "output format: table, json or yaml")
+	flags.StringVar(&c.server, "server", "", "server URL, overrides the profile")
+	flags.StringVar(&c.apiKey, "api-key", "", "API key, overrides the profile")
+	flags.StringVar(&c.tenant, "tenant", "", "tenant, overrides the profile")
+
+	root.RegisterFlagCompletionFunc("output", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return outputFormats, cobra.ShellCompDirectiveNoFileComp
+	})
+	root.RegisterFlagCompletionFunc("profile", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return c.completeProfiles(), cobra.ShellCompDirectiveNoFileComp
+	})
+
+	root.AddCommand(
+		newAnimalCommand(c, species[client.Cat]{
+			name:     "cats",
+			singular: "cat",
+			resource: func(api *client.Client) *client.Resource[client.Cat] { return api.Cats },
+			from:     func(a animal) client.Cat { return client.Cat(a) },
+			to:       func(cat client.Cat) animal { return animal(cat) },
+		}),
+		newAnimalCommand(c, species[client.Dog]{
+			name:     "dogs",
+			singular: "dog",
+			resource: func(api *client.Client) *client.Resource[client.Dog] { return api.Dogs },
+			from:     func(a animal) client.Dog { return client.Dog(a) },
+			to:       func(dog client.Dog) animal { return animal(dog) },
+		}),
+		newConfigCommand(c),
+	)
+	return root
+}
+
+// client connects to the server of the selected profile.
+func (c *cli) client() (*client.Client, error) {
+	config, err := loadConfig(c.configPath)
+	if err != nil {
+		return nil, err
+	}
+	profile, err := config.resolve(c.profile)
+	if err != nil {
+		return nil, err
+	}
+	if c.server != "" {
+		profile.Server = c.server
+	}
+	if c.apiKey != "" {
+		profile.APIKey = c.apiKey
+	}
+	if c.tenant != "" {
+		profile.Tenant = c.tenant
+	}
+
+	options := []client.Option{client.WithUserAgent("animalctl/" + version)}
+	if profile.APIKey != "" {
+		options = append(options, client.WithAPIKey(profile.APIKey))
+	}
+	if profile.Actor != "" {
+		options = append(options, client.WithActor(profile.Actor))
+	}
+	if profile.Tenant != "" {
+		options = append(options, client.WithTenant(profile.Tenant))
+	}
+	return client.New(profile.Server, options...)
+}
+
+func (c *cli) completeProfiles() []string {
+	path := c.configPath
+	if path == "" {
+		path, _ = defaultConfigPath()
+	}
+	config, err := loadConfig(path)
+	if err != nil {
+		return nil
+	}
+	return config.profileNames()
+}
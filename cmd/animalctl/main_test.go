@@ -0,0 +1,230 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fakeServer answers like the API and records the bodies of the requests
+// it gets.
+func fakeServer(t *testing.T, bodies *[]string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		*bodies = append(*bodies, r.Method+" "+r.URL.Path+" "+string(body))
+
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/cats":
+			w.Write([]byte(`[{"id":"0bd2dcd1-d5f1-4b64-9e47-0ddcde8d3ab3","name":"Nacho","breed":"Tabby","color":"Orange","birthdate":"2020-02-10T00:00:00Z","weight":17}]`))
+		case r.Method == "POST" && r.URL.Path == "/dogs":
+			var dog map[string]interface{}
+			json.Unmarshal(body, &dog)
+			dog["id"] = "7c9d0a2e-3a8b-4f57-9df0-4cf6a0f0c7a1"
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(dog)
+		default:
+			w.Header().Set("Content-Type", "application/problem+json")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"status":404,"code":"not_found","detail":"the requested resource does not exist"}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func run(t *testing.T, args ...string) (string, error) {
+	t.Setenv("ANIMALCTL_CONFIG", filepath.Join(t.TempDir(), "config.yaml"))
+	out := new(bytes.Buffer)
+	cmd := newRootCommand(strings.NewReader(""), out, io.Discard)
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func TestAnimalCommands(t *testing.T) {
+	bodies := make([]string, 0)
+	server := fakeServer(t, &bodies)
+
+	importFile := filepath.Join(t.TempDir(), "dogs.yaml")
+	os.WriteFile(importFile, []byte("- name: Spike\n  breed: Boxer\n  color: Brown\n  birthdate: 2019-05-01\n  weight: 30\n"), 0o600)
+
+	tests := []struct {
+		name        string
+		args        []string
+		wantOutput  []string
+		wantRequest string
+		wantErr     bool
+	}{
+		{
+			name:       "Should list cats as a table",
+			args:       []string{"cats", "list"},
+			wantOutput: []string{"ID", "NAME", "Nacho", "2020-02-10", "17"},
+		},
+		{
+			name:       "Should list cats as YAML",
+			args:       []string{"cats", "ls", "-o", "yaml"},
+			wantOutput: []string{"- birthdate: \"2020-02-10T00:00:00Z\"", "  name: Nacho"},
+		},
+		{
+			name:       "Should export cats as JSON",
+			args:       []string{"cats", "export"},
+			wantOutput: []string{`"name": "Nacho"`},
+		},
+		{
+			name:        "Should create a dog from flags",
+			args:        []string{"dogs", "create", "--name", "Rex", "--breed", "Boxer", "--color", "Brown", "--birthdate", "2019-05-01", "--weight", "30", "-o", "json"},
+			wantOutput:  []string{`"id": "7c9d0a2e-3a8b-4f57-9df0-4cf6a0f0c7a1"`},
+			wantRequest: `POST /dogs {"id":"00000000-0000-0000-0000-000000000000","name":"Rex","breed":"Boxer","color":"Brown","birthdate":"2019-05-01T00:00:00Z","weight":30}`,
+		},
+		{
+			name:        "Should import dogs from YAML",
+			args:        []string{"dogs", "import", "-f", importFile},
+			wantRequest: `POST /dogs {"id":"00000000-0000-0000-0000-000000000000","name":"Spike","breed":"Boxer","color":"Brown","birthdate":"2019-05-01T00:00:00Z","weight":30}`,
+		},
+		{
+			name:    "Should report a missing dog",
+			args:    []string{"dogs", "get", "7c9d0a2e-3a8b-4f57-9df0-4cf6a0f0c7a1"},
+			wantErr: true,
+		},
+		{
+			name:    "Should reject an invalid id",
+			args:    []string{"dogs", "delete", "not-an-id"},
+			wantErr: true,
+		},
+		{
+			name:    "Should reject an unknown output format",
+			args:    []string{"cats", "list", "-o", "xml"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bodies = bodies[:0]
+			got, err := run(t, append(tt.args, "--server", server.URL)...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("animalctl %v error = %v, wantErr %v", tt.args, err, tt.wantErr)
+				return
+			}
+			for _, want := range tt.wantOutput {
+				if !strings.Contains(got, want) {
+					t.Errorf("animalctl %v = %v, want it to contain %v", tt.args, got, want)
+				}
+			}
+			if tt.wantRequest != "" && (len(bodies) != 1 || bodies[0] != tt.wantRequest) {
+				t.Errorf("animalctl %v requests = %v, want %v", tt.args, bodies, tt.wantRequest)
+			}
+		})
+	}
+}
+
+func TestTenant(t *testing.T) {
+	tenants := make([]string, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tenants = append(tenants, r.Header.Get("X-Tenant-ID"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[]`))
+	}))
+	t.Cleanup(server.Close)
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	t.Setenv("ANIMALCTL_CONFIG", path)
+	t.Setenv("ANIMALCTL_TENANT", "")
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{name: "Should use the default tenant without one", args: []string{"cats", "list"}, want: ""},
+		{name: "Should use the tenant of the profile", args: []string{"--profile", "shelter", "cats", "list"}, want: "shelter-a"},
+		{name: "Should let the flag override the profile", args: []string{"--profile", "shelter", "--tenant", "shelter-b", "cats", "list"}, want: "shelter-b"},
+	}
+	for _, args := range [][]string{
+		{"config", "set-profile", "default", "--server", server.URL},
+		{"config", "set-profile", "shelter", "--server", server.URL, "--tenant", "shelter-a"},
+	} {
+		cmd := newRootCommand(strings.NewReader(""), io.Discard, io.Discard)
+		cmd.SetArgs(args)
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("animalctl %v error = %v", args, err)
+		}
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tenants = tenants[:0]
+			cmd := newRootCommand(strings.NewReader(""), io.Discard, io.Discard)
+			cmd.SetArgs(tt.args)
+			if err := cmd.Execute(); err != nil {
+				t.Fatalf("animalctl %v error = %v", tt.args, err)
+			}
+			if len(tenants) != 1 || tenants[0] != tt.want {
+				t.Errorf("animalctl %v tenants = %q, want %q", tt.args, tenants, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfigCommands(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	t.Setenv("ANIMALCTL_CONFIG", path)
+	t.Setenv("ANIMALCTL_SERVER", "")
+
+	for _, args := range [][]string{
+		{"config", "set-profile", "prod", "--server", "https://api.example.com/", "--api-key", "secret"},
+		{"config", "set-profile", "staging", "--server", "https://staging.example.com"},
+		{"config", "use-profile", "staging"},
+	} {
+		cmd := newRootCommand(strings.NewReader(""), io.Discard, io.Discard)
+		cmd.SetArgs(args)
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("animalctl %v error = %v", args, err)
+		}
+	}
+
+	config, err := loadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		profile string
+		env     string
+		want    Profile
+		wantErr bool
+	}{
+		{name: "Should use the current profile", want: Profile{Server: "https://staging.example.com"}},
+		{name: "Should use a named profile", profile: "prod", want: Profile{Server: "https://api.example.com", APIKey: "secret"}},
+		{name: "Should let the environment override the server", profile: "prod", env: "http://localhost:9000", want: Profile{Server: "http://localhost:9000", APIKey: "secret"}},
+		{name: "Should reject an unknown profile", profile: "dev", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("ANIMALCTL_SERVER", tt.env)
+			got, err := config.resolve(tt.profile)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Config.resolve() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Config.resolve() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	out := new(bytes.Buffer)
+	cmd := newRootCommand(strings.NewReader(""), out, io.Discard)
+	cmd.SetArgs([]string{"config", "view"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(out.String(), "secret") || !strings.Contains(out.String(), "REDACTED") {
+		t.Errorf("animalctl config view = %v, want the API key redacted", out.String())
+	}
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+	outputYAML  = "yaml"
+)
+
+var outputFormats = []string{outputTable, outputJSON, outputYAML}
+
+// table is how a value prints as a table.
+type table struct {
+	header []string
+	rows   [][]string
+}
+
+// write prints v in the given format. Tables use rows, JSON and YAML
+// print v itself.
+func write(w io.Writer, format string, v interface{}, rows table) error {
+	switch format {
+	case outputTable:
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, strings.Join(rows.header, "\t"))
+		for _, row := range rows.rows {
+			fmt.Fprintln(tw, strings.Join(row, "\t"))
+		}
+		return tw.Flush()
+	case outputJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(v)
+	case outputYAML:
+		// Going through JSON keeps the field names and time format the API
+		// uses instead of the yaml package's own.
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		var generic interface{}
+		if err := yaml.Unmarshal(data, &generic); err != nil {
+			return err
+		}
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(generic); err != nil {
+			return err
+		}
+		return encoder.Close()
+	}
+	return fmt.Errorf("unknown output format %q, use one of %v", format, outputFormats)
+}
+
+func animalTable(animals []animal) table {
+	rows := table{header: []string{"ID", "NAME", "BREED", "COLOR", "BIRTHDATE", "WEIGHT", "STATUS"}}
+	for _, a := range animals {
+		rows.rows = append(rows.rows, []string{
+			a.ID.String(),
+			a.Name,
+			a.Breed,
+			a.Color,
+			a.Birthdate.Format("2006-01-02"),
+			strconv.Itoa(a.Weight),
+			a.Status,
+		})
+	}
+	return rows
+}
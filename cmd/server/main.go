@@ -1,12 +1,21 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"log/slog"
+	"net"
 	"os"
 
+	"github.com/glebarez/sqlite"
 	_ "github.com/one-byte-data/go-api-sample/docs"
 	"github.com/one-byte-data/go-api-sample/internal/controllers"
-	"github.com/one-byte-data/go-api-sample/internal/models"
+	"github.com/one-byte-data/go-api-sample/internal/grpcserver"
+	"github.com/one-byte-data/go-api-sample/internal/logging"
+	"github.com/one-byte-data/go-api-sample/internal/metrics"
+	"github.com/one-byte-data/go-api-sample/internal/middlewares"
+	"github.com/one-byte-data/go-api-sample/internal/repositories"
+	"github.com/one-byte-data/go-api-sample/internal/tracing"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 
@@ -30,23 +39,75 @@ var version string = "development"
 
 // @host localhost:8080
 // @BasePath /
+
+// @securityDefinitions.apikey ApiKey
+// @in header
+// @name X-Api-Key
+// @description Rate limits are keyed by the authenticated identity of the caller, or else by the real client IP address. The key scopes requests to the tenant it belongs to, and the tenant routes and changes to the breed catalogue require the admin API key.
 func main() {
+	setupLogging()
 	printVersion()
+	metrics.SetBuildInfo(version)
+
+	shutdownTracing := setupTracing()
+	defer shutdownTracing()
 
-	db := setupDatabase(getConnectionString())
+	repo := setupRepository()
 
-	router, err := controllers.SetupRouter(db)
+	router, err := controllers.SetupRouter(repo)
 	if err != nil {
 		panic(err)
 	}
 
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
+	serveGRPC(repo)
+
 	if err := router.Run(); err != nil {
 		panic(err)
 	}
 }
 
+// serveGRPC starts the gRPC API on GRPC_PORT in the background, sharing the
+// services the router was set up with. Its health service follows the
+// database of repo.
+func serveGRPC(repo repositories.Repository) {
+	port, err := grpcserver.PortFromEnv()
+	if err != nil {
+		panic(err)
+	}
+	if port == 0 {
+		return
+	}
+
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		panic(fmt.Sprintf("unable to listen for gRPC: %v", err))
+	}
+
+	credentials, err := middlewares.TenantCredentialsFromEnv()
+	if err != nil {
+		panic(err)
+	}
+
+	config, err := repositories.ConfigFromEnv()
+	if err != nil {
+		panic(err)
+	}
+	health := grpcserver.NewHealth(repo)
+	health.Check(context.Background())
+	go health.Watch(context.Background(), config.HealthCheckInterval)
+
+	cats, dogs, tenants := controllers.Services()
+	server := grpcserver.New(cats, dogs, tenants, credentials, health)
+	go func() {
+		slog.Info("serving gRPC", "port", port)
+		if err := server.Serve(listener); err != nil {
+			panic(err)
+		}
+	}()
+}
+
 func getConnectionString() string {
 	connectionString := os.Getenv("CONNECTION_STRING")
 	if connectionString == "" {
@@ -56,18 +117,124 @@ func getConnectionString() string {
 }
 
 func printVersion() {
-	fmt.Printf("Starting go-api-sample %s\n\n", version)
+	slog.Info("starting go-api-sample", "version", version)
 }
 
+func setupLogging() {
+	level, err := logging.LevelFromEnv()
+	if err != nil {
+		panic(err)
+	}
+	logging.Setup(os.Stdout, level)
+}
+
+// setupRepository opens the storage backend selected by STORAGE_BACKEND.
+func setupRepository() repositories.Repository {
+	config, err := repositories.ConfigFromEnv()
+	if err != nil {
+		panic(err)
+	}
+
+	slog.Info("opening storage", "backend", config.Backend, "replicas", len(config.Replicas))
+	switch config.Backend {
+	case repositories.BackendMemory:
+		return repositories.NewMemory()
+	case repositories.BackendSQLite:
+		db := openDatabase(sqlite.Open(config.SQLitePath), "go-api-sample")
+		configurePool(db, config)
+		return monitorDatabase(repositories.NewGorm(db), db, config)
+	}
+
+	primary := setupDatabase(getConnectionString())
+	configurePool(primary, config)
+	if len(config.Replicas) == 0 {
+		return monitorDatabase(repositories.NewGorm(primary), primary, config)
+	}
+
+	replicas := make([]*gorm.DB, 0, len(config.Replicas))
+	for i, connectionString := range config.Replicas {
+		replica := openDatabase(postgres.Open(connectionString), fmt.Sprintf("go-api-sample-replica-%d", i+1))
+		configurePool(replica, config)
+		replicas = append(replicas, replica)
+	}
+
+	// A replica that is down at startup is skipped until it recovers.
+	routing := repositories.NewReplicas(primary, replicas...)
+	routing.Check(context.Background())
+	go routing.Watch(context.Background(), config.ReplicaCheckInterval)
+
+	return monitorDatabase(repositories.NewReplicatedGorm(routing), primary, config)
+}
+
+// setupDatabase opens the primary database without connecting to it, so
+// the server can start before the database does.
 func setupDatabase(connectionString string) *gorm.DB {
-	db, err := gorm.Open(postgres.Open(connectionString))
+	return openDatabase(postgres.Open(connectionString), "go-api-sample")
+}
+
+// monitorDatabase waits up to DB_STARTUP_TIMEOUT for the database to accept
+// connections and migrates it. When it does not, the server starts anyway
+// and answers 503 until a background check gets through.
+func monitorDatabase(repo repositories.Repository, db *gorm.DB, config repositories.Config) repositories.Repository {
+	monitor := repositories.NewMonitor(repo, func(ctx context.Context) error {
+		if err := repo.Ping(ctx); err != nil {
+			return err
+		}
+		return repositories.Migrate(db.WithContext(ctx))
+	})
+
+	if config.StartupTimeout > 0 {
+		if err := monitor.Connect(context.Background(), config.StartupTimeout); err != nil {
+			slog.Error("starting without the database, requests will fail until it is available", "error", err)
+		}
+	}
+	go monitor.Watch(context.Background(), config.HealthCheckInterval)
+
+	return monitor
+}
+
+// openDatabase instruments a database without connecting to it.
+func openDatabase(dialector gorm.Dialector, name string) *gorm.DB {
+	db, err := gorm.Open(dialector, &gorm.Config{
+		Logger:               logging.NewGormLogger(),
+		DisableAutomaticPing: true,
+	})
 	if err != nil {
 		panic(fmt.Sprintf("unable to connect to database: %v", err))
 	}
 
-	if err := db.AutoMigrate(&models.Cat{}, &models.Dog{}); err != nil {
+	if err := metrics.InstrumentDatabase(db, name); err != nil {
+		panic(err)
+	}
+
+	if err := db.Use(&tracing.GormPlugin{}); err != nil {
 		panic(err)
 	}
 
 	return db
 }
+
+func configurePool(db *gorm.DB, config repositories.Config) {
+	if err := config.ConfigurePool(db); err != nil {
+		panic(err)
+	}
+}
+
+func setupTracing() func() {
+	config, err := tracing.ConfigFromEnv()
+	if err != nil {
+		panic(err)
+	}
+	config.ServiceVersion = version
+
+	shutdown, err := tracing.Setup(context.Background(), config)
+	if err != nil {
+		panic(fmt.Sprintf("unable to set up tracing: %v", err))
+	}
+
+	return func() {
+		if err := shutdown(context.Background()); err != nil {
+			slog.Error("unable to shut down tracing", "error", err)
+		}
+	}
+}
@@ -68,16 +68,16 @@ func Test_setupDatabase(t *testing.T) {
 		wantPanic bool
 	}{
 		{
-			name: "Should be able to connect to database",
+			name: "Should open the database without connecting to it",
 			args: args{
 				connectionString: "postgresql://root@cockroachdb:26257/defaultdb?sslmode=disable",
 			},
 			wantPanic: false,
 		},
 		{
-			name: "Should panic",
+			name: "Should panic on an invalid connection string",
 			args: args{
-				connectionString: "",
+				connectionString: "postgresql://root@cockroachdb:port/defaultdb",
 			},
 			wantPanic: true,
 		},
@@ -25,7 +25,7 @@ func SetupTests(t testing.TB, dialector gorm.Dialector) func(t testing.TB) {
 		panic(err)
 	}
 
-	if err := DB.AutoMigrate(&models.Cat{}, &models.Dog{}); err != nil {
+	if err := DB.AutoMigrate(&models.Cat{}, &models.Dog{}, &models.AuditEntry{}, &models.Tenant{}); err != nil {
 		panic(err)
 	}
 
@@ -40,7 +40,7 @@ func SetupTests(t testing.TB, dialector gorm.Dialector) func(t testing.TB) {
 	}
 
 	return func(t testing.TB) {
-		DB.Migrator().DropTable(&models.Cat{}, &models.Dog{})
+		DB.Migrator().DropTable(&models.Cat{}, &models.Dog{}, &models.AuditEntry{}, &models.Tenant{})
 	}
 }
 
@@ -24,7 +24,2853 @@ const docTemplate = `{
     },
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
-    "paths": {}
+    "paths": {
+        "/applications": {
+            "get": {
+                "description": "get adoption applications, oldest first, optionally filtered by species, animal and status",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Gets the adoption applications",
+                "parameters": [
+                    {
+                        "enum": [
+                            "cats",
+                            "dogs"
+                        ],
+                        "type": "string",
+                        "description": "Species",
+                        "name": "species",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cat or dog ID",
+                        "name": "animal_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "submitted",
+                            "under_review",
+                            "approved",
+                            "rejected"
+                        ],
+                        "type": "string",
+                        "description": "Status",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Application"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "submits an application to adopt a cat or dog. The cat or dog must exist and not be adopted yet",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Submits an adoption application",
+                "parameters": [
+                    {
+                        "description": "Application",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Application"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Application"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of the new application"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/applications/{id}": {
+            "get": {
+                "description": "get an adoption application",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Gets an adoption application by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Application ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/models.Application"
+                        }
+                    },
+                    "400": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "replaces the applicant details of an application that is still open. The cat or dog, status and reviewer are kept",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Updates an adoption application by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Application ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Application",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Application"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "updated",
+                        "schema": {
+                            "$ref": "#/definitions/models.Application"
+                        }
+                    },
+                    "400": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "deletes an adoption application",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Deletes an adoption application by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Application ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Deleted"
+                        }
+                    },
+                    "400": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/applications/{id}/review": {
+            "post": {
+                "description": "moves an application to under_review, approved or rejected. Approving it marks the cat or dog adopted and rejects the other open applications for it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Reviews an adoption application",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Application ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Status",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.ReviewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "reviewed",
+                        "schema": {
+                            "$ref": "#/definitions/models.Application"
+                        }
+                    },
+                    "400": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/audit": {
+            "get": {
+                "description": "get audit entries, optionally filtered by resource, resource ID, actor and time",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Gets the audit log",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Resource name (cats, dogs)",
+                        "name": "resource",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Resource ID",
+                        "name": "resource_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Actor",
+                        "name": "actor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only entries at or after this RFC 3339 time",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only entries before this RFC 3339 time",
+                        "name": "until",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.AuditEntry"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/breeds/{species}": {
+            "get": {
+                "description": "get the breed catalogue of cats or dogs, ordered by name",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Gets the breeds of a species",
+                "parameters": [
+                    {
+                        "enum": [
+                            "cats",
+                            "dogs"
+                        ],
+                        "type": "string",
+                        "description": "Species",
+                        "name": "species",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Breed"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "adds a breed to the catalogue of cats or dogs, requires the admin API key. The name and aliases must not name another breed of the species",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Adds a breed",
+                "parameters": [
+                    {
+                        "enum": [
+                            "cats",
+                            "dogs"
+                        ],
+                        "type": "string",
+                        "description": "Species",
+                        "name": "species",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Breed",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Breed"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Admin API key",
+                        "name": "X-Api-Key",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Breed"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of the new breed"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/breeds/{species}/{id}": {
+            "get": {
+                "description": "get a breed of cats or dogs",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Gets a breed by ID",
+                "parameters": [
+                    {
+                        "enum": [
+                            "cats",
+                            "dogs"
+                        ],
+                        "type": "string",
+                        "description": "Species",
+                        "name": "species",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Breed ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/models.Breed"
+                        }
+                    },
+                    "400": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "replaces the name, aliases and weight range of a breed, requires the admin API key. Cats and dogs keep the breed name they were stored with",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Updates a breed by ID",
+                "parameters": [
+                    {
+                        "enum": [
+                            "cats",
+                            "dogs"
+                        ],
+                        "type": "string",
+                        "description": "Species",
+                        "name": "species",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Breed ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Breed",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Breed"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Admin API key",
+                        "name": "X-Api-Key",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "updated",
+                        "schema": {
+                            "$ref": "#/definitions/models.Breed"
+                        }
+                    },
+                    "400": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "deletes a breed from the catalogue, requires the admin API key. Cats and dogs keep the breed name they were stored with",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Deletes a breed by ID",
+                "parameters": [
+                    {
+                        "enum": [
+                            "cats",
+                            "dogs"
+                        ],
+                        "type": "string",
+                        "description": "Species",
+                        "name": "species",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Breed ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Admin API key",
+                        "name": "X-Api-Key",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Deleted"
+                        }
+                    },
+                    "400": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/cache/stats": {
+            "get": {
+                "description": "get the hits, misses, evictions and size of every cache",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Gets the cache statistics",
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/cache.Stats"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/cats": {
+            "get": {
+                "description": "get a list of cats, optionally filtered by status",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Gets all the cats in the database",
+                "parameters": [
+                    {
+                        "enum": [
+                            "intake",
+                            "available",
+                            "on_hold",
+                            "adopted",
+                            "returned"
+                        ],
+                        "type": "string",
+                        "description": "Status",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Cat"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "adds a cat",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Adds a cat",
+                "parameters": [
+                    {
+                        "description": "Cat",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Cat"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "return=minimal for an empty response",
+                        "name": "Prefer",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "replays the first response to requests with the same key",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Cat"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of the new cat"
+                            }
+                        }
+                    },
+                    "204": {
+                        "description": "created, Prefer: return=minimal",
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of the new cat"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/cats/count": {
+            "post": {
+                "description": "count cats",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Counts the cats in the database",
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Count"
+                        }
+                    },
+                    "500": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/cats/{id}": {
+            "get": {
+                "description": "get a cat",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Gets a cat by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cat ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/models.Cat"
+                        }
+                    },
+                    "400": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "updates a cat",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Updates a cat by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cat ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Cat",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Cat"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "return=minimal for an empty response",
+                        "name": "Prefer",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "updated",
+                        "schema": {
+                            "$ref": "#/definitions/models.Cat"
+                        }
+                    },
+                    "204": {
+                        "description": "updated, Prefer: return=minimal"
+                    },
+                    "400": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "deletes a cat",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Deletes a cat by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cat ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Deleted"
+                        }
+                    },
+                    "400": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/cats/{id}/history": {
+            "get": {
+                "description": "get the audit entries for a cat",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Gets the change history of a cat",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cat ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.AuditEntry"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/cats/{id}/transitions": {
+            "get": {
+                "description": "get the status changes of a cat, oldest first",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Gets the status transitions of a cat",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cat ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Transition"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "moves a cat to another shelter status, following the allowed transitions",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Changes the status of a cat",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cat ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Status",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.TransitionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Transition"
+                        }
+                    },
+                    "400": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/dogs": {
+            "get": {
+                "description": "get a list of dogs, optionally filtered by status",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Gets all the dogs in the database",
+                "parameters": [
+                    {
+                        "enum": [
+                            "intake",
+                            "available",
+                            "on_hold",
+                            "adopted",
+                            "returned"
+                        ],
+                        "type": "string",
+                        "description": "Status",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Dog"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "adds a dog",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Adds a dog",
+                "parameters": [
+                    {
+                        "description": "Dog",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Dog"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "return=minimal for an empty response",
+                        "name": "Prefer",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "replays the first response to requests with the same key",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Dog"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of the new dog"
+                            }
+                        }
+                    },
+                    "204": {
+                        "description": "created, Prefer: return=minimal",
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of the new dog"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/dogs/count": {
+            "post": {
+                "description": "count dogs",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Counts the dogs in the database",
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Count"
+                        }
+                    },
+                    "500": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/dogs/{id}": {
+            "get": {
+                "description": "get a dog",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Gets a dog by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Dog ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/models.Dog"
+                        }
+                    },
+                    "400": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "updates a dog",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Updates a dog by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Dog ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Dog",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Dog"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "return=minimal for an empty response",
+                        "name": "Prefer",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "updated",
+                        "schema": {
+                            "$ref": "#/definitions/models.Dog"
+                        }
+                    },
+                    "204": {
+                        "description": "updated, Prefer: return=minimal"
+                    },
+                    "400": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "deletes a dog",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Deletes a dog by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Dog ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Deleted"
+                        }
+                    },
+                    "400": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/dogs/{id}/history": {
+            "get": {
+                "description": "get the audit entries for a dog",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Gets the change history of a dog",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Dog ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.AuditEntry"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/dogs/{id}/transitions": {
+            "get": {
+                "description": "get the status changes of a dog, oldest first",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Gets the status transitions of a dog",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Dog ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Transition"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "moves a dog to another shelter status, following the allowed transitions",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Changes the status of a dog",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Dog ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Status",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/controllers.TransitionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Transition"
+                        }
+                    },
+                    "400": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/graphql": {
+            "post": {
+                "description": "runs a GraphQL query or mutation on cats and dogs. Errors while executing the query are listed in the response.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Runs a GraphQL query or mutation",
+                "parameters": [
+                    {
+                        "description": "GraphQL request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/graphqlapi.Request"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/graphqlapi.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/health": {
+            "get": {
+                "description": "gets the status of the server",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Gets the status of the server",
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/ready": {
+            "get": {
+                "description": "answers 503 while the database is unavailable, e.g. while the server waits for it to start",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Gets whether the server is ready to serve requests",
+                "responses": {
+                    "200": {
+                        "description": "ready",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Message"
+                        }
+                    },
+                    "503": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/stats/cats": {
+            "get": {
+                "description": "get the counts by breed and color, the age buckets and the weights per breed of the cats, optionally filtered. The aggregation is done by the database",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Gets statistics about the cats",
+                "parameters": [
+                    {
+                        "enum": [
+                            "intake",
+                            "available",
+                            "on_hold",
+                            "adopted",
+                            "returned"
+                        ],
+                        "type": "string",
+                        "description": "Status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Breed, ignoring case",
+                        "name": "breed",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Color, ignoring case",
+                        "name": "color",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum weight",
+                        "name": "min_weight",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum weight",
+                        "name": "max_weight",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only cats born at or after this RFC 3339 time",
+                        "name": "born_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only cats born before this RFC 3339 time",
+                        "name": "born_before",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/models.AnimalStats"
+                        }
+                    },
+                    "400": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/stats/dogs": {
+            "get": {
+                "description": "get the counts by breed and color, the age buckets and the weights per breed of the dogs, optionally filtered. The aggregation is done by the database",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Gets statistics about the dogs",
+                "parameters": [
+                    {
+                        "enum": [
+                            "intake",
+                            "available",
+                            "on_hold",
+                            "adopted",
+                            "returned"
+                        ],
+                        "type": "string",
+                        "description": "Status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Breed, ignoring case",
+                        "name": "breed",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Color, ignoring case",
+                        "name": "color",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum weight",
+                        "name": "min_weight",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum weight",
+                        "name": "max_weight",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only dogs born at or after this RFC 3339 time",
+                        "name": "born_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only dogs born before this RFC 3339 time",
+                        "name": "born_before",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/models.AnimalStats"
+                        }
+                    },
+                    "400": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/tenants": {
+            "get": {
+                "description": "get a list of tenants, requires the admin API key",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Gets all the tenants",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Admin API key",
+                        "name": "X-Api-Key",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Tenant"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "adds a tenant, requires the admin API key. IDs are 1 to 63 lowercase letters, digits and hyphens",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Adds a tenant",
+                "parameters": [
+                    {
+                        "description": "Tenant",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Tenant"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Admin API key",
+                        "name": "X-Api-Key",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Tenant"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of the new tenant"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/tenants/{id}": {
+            "get": {
+                "description": "get a tenant, requires the admin API key",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Gets a tenant by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Admin API key",
+                        "name": "X-Api-Key",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/models.Tenant"
+                        }
+                    },
+                    "403": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "deletes a tenant without cats or dogs, requires the admin API key. The default tenant cannot be deleted",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Deletes a tenant by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Admin API key",
+                        "name": "X-Api-Key",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Deleted"
+                        }
+                    },
+                    "403": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "problem",
+                        "schema": {
+                            "$ref": "#/definitions/problems.Problem"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "cache.Stats": {
+            "type": "object",
+            "properties": {
+                "capacity": {
+                    "type": "integer"
+                },
+                "evictions": {
+                    "type": "integer"
+                },
+                "hits": {
+                    "type": "integer"
+                },
+                "misses": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controllers.Count": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "controllers.Deleted": {
+            "type": "object",
+            "properties": {
+                "deleted": {
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.Message": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.ReviewRequest": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "under_review",
+                        "approved",
+                        "rejected"
+                    ]
+                }
+            }
+        },
+        "controllers.TransitionRequest": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "intake",
+                        "available",
+                        "on_hold",
+                        "adopted",
+                        "returned"
+                    ]
+                }
+            }
+        },
+        "graphqlapi.Location": {
+            "type": "object",
+            "properties": {
+                "column": {
+                    "type": "integer"
+                },
+                "line": {
+                    "type": "integer"
+                }
+            }
+        },
+        "graphqlapi.Request": {
+            "type": "object",
+            "required": [
+                "query"
+            ],
+            "properties": {
+                "operationName": {
+                    "type": "string"
+                },
+                "query": {
+                    "type": "string"
+                },
+                "variables": {
+                    "type": "object",
+                    "additionalProperties": true
+                }
+            }
+        },
+        "graphqlapi.Response": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/graphqlapi.ResponseError"
+                    }
+                }
+            }
+        },
+        "graphqlapi.ResponseError": {
+            "type": "object",
+            "properties": {
+                "extensions": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "locations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/graphqlapi.Location"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "array",
+                    "items": {}
+                }
+            }
+        },
+        "models.AgeBucket": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "max_years": {
+                    "type": "integer"
+                },
+                "min_years": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.AnimalStats": {
+            "type": "object",
+            "properties": {
+                "ages": {
+                    "description": "Ages lists every age bucket, youngest first, including empty ones.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AgeBucket"
+                    }
+                },
+                "breeds": {
+                    "description": "Breeds and Colors are ordered by count, largest first, then by value.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.GroupCount"
+                    }
+                },
+                "colors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.GroupCount"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "weights": {
+                    "description": "Weights is ordered by breed.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BreedWeight"
+                    }
+                }
+            }
+        },
+        "models.Application": {
+            "type": "object",
+            "required": [
+                "animal_id",
+                "applicant_email",
+                "applicant_name",
+                "species"
+            ],
+            "properties": {
+                "animal_id": {
+                    "type": "string"
+                },
+                "applicant_email": {
+                    "type": "string",
+                    "maxLength": 254
+                },
+                "applicant_name": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "minLength": 2
+                },
+                "applicant_phone": {
+                    "type": "string",
+                    "maxLength": 32
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string",
+                    "maxLength": 1000
+                },
+                "reviewer": {
+                    "type": "string"
+                },
+                "species": {
+                    "type": "string",
+                    "enum": [
+                        "cats",
+                        "dogs"
+                    ]
+                },
+                "status": {
+                    "description": "Status, Reviewer and the timestamps are set by the services. The\nreviewer is the actor of the last review.",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AuditEntry": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "actor": {
+                    "type": "string"
+                },
+                "changes": {
+                    "type": "object"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "request_id": {
+                    "type": "string"
+                },
+                "resource": {
+                    "type": "string"
+                },
+                "resource_id": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Breed": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "aliases": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "max_weight": {
+                    "type": "integer"
+                },
+                "min_weight": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 24,
+                    "minLength": 2
+                },
+                "species": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.BreedWeight": {
+            "type": "object",
+            "properties": {
+                "breed": {
+                    "type": "string"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "mean": {
+                    "type": "number"
+                },
+                "median": {
+                    "type": "number"
+                },
+                "p25": {
+                    "type": "number"
+                },
+                "p75": {
+                    "type": "number"
+                },
+                "p90": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.Cat": {
+            "type": "object",
+            "required": [
+                "birthdate",
+                "breed",
+                "color",
+                "name",
+                "weight"
+            ],
+            "properties": {
+                "birthdate": {
+                    "type": "string"
+                },
+                "breed": {
+                    "type": "string",
+                    "maxLength": 24,
+                    "minLength": 2
+                },
+                "breed_status": {
+                    "description": "BreedStatus tells whether the breed is in the breed catalogue, see\nBreedKnown and BreedUnknown. It is set by the services.",
+                    "type": "string"
+                },
+                "color": {
+                    "type": "string",
+                    "maxLength": 24,
+                    "minLength": 2
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 24,
+                    "minLength": 2
+                },
+                "status": {
+                    "description": "Status is the shelter status, see StatusIntake. It is set by the\nservices and changed through transitions.",
+                    "type": "string"
+                },
+                "weight": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "models.Dog": {
+            "type": "object",
+            "required": [
+                "birthdate",
+                "breed",
+                "color",
+                "name",
+                "weight"
+            ],
+            "properties": {
+                "birthdate": {
+                    "type": "string"
+                },
+                "breed": {
+                    "type": "string",
+                    "maxLength": 24,
+                    "minLength": 2
+                },
+                "breed_status": {
+                    "description": "BreedStatus tells whether the breed is in the breed catalogue, see\nBreedKnown and BreedUnknown. It is set by the services.",
+                    "type": "string"
+                },
+                "color": {
+                    "type": "string",
+                    "maxLength": 24,
+                    "minLength": 2
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 24,
+                    "minLength": 2
+                },
+                "status": {
+                    "description": "Status is the shelter status, see StatusIntake. It is set by the\nservices and changed through transitions.",
+                    "type": "string"
+                },
+                "weight": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "models.GroupCount": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Tenant": {
+            "type": "object",
+            "required": [
+                "id",
+                "name"
+            ],
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "maxLength": 63
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "minLength": 2
+                }
+            }
+        },
+        "models.Transition": {
+            "type": "object",
+            "properties": {
+                "actor": {
+                    "type": "string"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "request_id": {
+                    "type": "string"
+                },
+                "resource": {
+                    "type": "string"
+                },
+                "resource_id": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "problems.FieldError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "rule": {
+                    "type": "string"
+                }
+            }
+        },
+        "problems.Problem": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "detail": {
+                    "type": "string"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/problems.FieldError"
+                    }
+                },
+                "instance": {
+                    "type": "string"
+                },
+                "request_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        }
+    },
+    "securityDefinitions": {
+        "ApiKey": {
+            "description": "Rate limits are keyed by the authenticated identity of the caller, or else by the real client IP address. The key scopes requests to the tenant it belongs to, and the tenant routes and changes to the breed catalogue require the admin API key.",
+            "type": "apiKey",
+            "name": "X-Api-Key",
+            "in": "header"
+        }
+    }
 }`
 
 // SwaggerInfo holds exported Swagger Info so clients can modify it
@@ -0,0 +1,179 @@
+package cache
+
+import (
+	"container/list"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Config sizes every cache. A zero TTL disables caching.
+type Config struct {
+	Size int
+	TTL  time.Duration
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Size: 1000,
+		TTL:  30 * time.Second,
+	}
+}
+
+// ConfigFromEnv reads CACHE_SIZE as a number of entries and CACHE_TTL as a
+// duration.
+func ConfigFromEnv() (Config, error) {
+	config := DefaultConfig()
+
+	if value := os.Getenv("CACHE_SIZE"); value != "" {
+		size, err := strconv.Atoi(value)
+		if err != nil || size < 1 {
+			return config, fmt.Errorf("CACHE_SIZE must be a positive number, got %q", value)
+		}
+		config.Size = size
+	}
+
+	if value := os.Getenv("CACHE_TTL"); value != "" {
+		ttl, err := time.ParseDuration(value)
+		if err != nil || ttl < 0 {
+			return config, fmt.Errorf("CACHE_TTL must be a duration, got %q", value)
+		}
+		config.TTL = ttl
+	}
+
+	return config, nil
+}
+
+func (config Config) Enabled() bool {
+	return config.TTL > 0
+}
+
+type Stats struct {
+	Name      string `json:"name"`
+	Hits      uint64 `json:"hits"`
+	Misses    uint64 `json:"misses"`
+	Evictions uint64 `json:"evictions"`
+	Size      int    `json:"size"`
+	Capacity  int    `json:"capacity"`
+}
+
+// Reporter is implemented by every cache so operators can inspect them
+// without knowing their key and value types.
+type Reporter interface {
+	Stats() Stats
+}
+
+type entry[K comparable, V any] struct {
+	key     K
+	value   V
+	expires time.Time
+}
+
+// LRU is a thread-safe cache that evicts the least recently used entry once
+// it is full and treats entries older than its TTL as missing.
+type LRU[K comparable, V any] struct {
+	mu        sync.Mutex
+	name      string
+	capacity  int
+	ttl       time.Duration
+	items     map[K]*list.Element
+	order     *list.List
+	now       func() time.Time
+	hits      uint64
+	misses    uint64
+	evictions uint64
+}
+
+func New[K comparable, V any](name string, config Config) *LRU[K, V] {
+	return &LRU[K, V]{
+		name:     name,
+		capacity: config.Size,
+		ttl:      config.TTL,
+		items:    make(map[K]*list.Element),
+		order:    list.New(),
+		now:      time.Now,
+	}
+}
+
+func (c *LRU[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.items[key]
+	if !ok {
+		c.misses++
+		var zero V
+		return zero, false
+	}
+
+	e := element.Value.(*entry[K, V])
+	if c.now().After(e.expires) {
+		c.remove(element)
+		c.misses++
+		var zero V
+		return zero, false
+	}
+
+	c.order.MoveToFront(element)
+	c.hits++
+	return e.value, true
+}
+
+func (c *LRU[K, V]) Set(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expires := c.now().Add(c.ttl)
+	if element, ok := c.items[key]; ok {
+		e := element.Value.(*entry[K, V])
+		e.value = value
+		e.expires = expires
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.items[key] = c.order.PushFront(&entry[K, V]{key: key, value: value, expires: expires})
+	for c.order.Len() > c.capacity {
+		c.remove(c.order.Back())
+		c.evictions++
+	}
+}
+
+func (c *LRU[K, V]) Delete(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.items[key]; ok {
+		c.remove(element)
+	}
+}
+
+// Purge removes every entry.
+func (c *LRU[K, V]) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.items = make(map[K]*list.Element)
+	c.order.Init()
+}
+
+func (c *LRU[K, V]) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return Stats{
+		Name:      c.name,
+		Hits:      c.hits,
+		Misses:    c.misses,
+		Evictions: c.evictions,
+		Size:      c.order.Len(),
+		Capacity:  c.capacity,
+	}
+}
+
+func (c *LRU[K, V]) remove(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.items, element.Value.(*entry[K, V]).key)
+}
@@ -0,0 +1,95 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConfigFromEnv(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		want    Config
+		wantErr bool
+	}{
+		{
+			name: "Should use the defaults",
+			env:  map[string]string{"CACHE_SIZE": "", "CACHE_TTL": ""},
+			want: DefaultConfig(),
+		},
+		{
+			name: "Should read the size and TTL",
+			env:  map[string]string{"CACHE_SIZE": "10", "CACHE_TTL": "1m"},
+			want: Config{Size: 10, TTL: time.Minute},
+		},
+		{
+			name:    "Should not accept a size of zero",
+			env:     map[string]string{"CACHE_SIZE": "0", "CACHE_TTL": ""},
+			wantErr: true,
+		},
+		{
+			name:    "Should not accept a TTL that is not a duration",
+			env:     map[string]string{"CACHE_SIZE": "", "CACHE_TTL": "soon"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			got, err := ConfigFromEnv()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ConfigFromEnv() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("ConfigFromEnv() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLRU(t *testing.T) {
+	now := time.Date(2022, 2, 10, 0, 0, 0, 0, time.UTC)
+	c := New[string, int]("test", Config{Size: 2, TTL: time.Minute})
+	c.now = func() time.Time { return now }
+
+	c.Set("a", 1)
+	c.Set("b", 2)
+	if got, ok := c.Get("a"); !ok || got != 1 {
+		t.Errorf("LRU.Get(a) = %v, %v, want 1, true", got, ok)
+	}
+
+	// b is now the least recently used entry.
+	c.Set("c", 3)
+	if _, ok := c.Get("b"); ok {
+		t.Errorf("LRU.Get(b) found an evicted entry")
+	}
+	if got, ok := c.Get("c"); !ok || got != 3 {
+		t.Errorf("LRU.Get(c) = %v, %v, want 3, true", got, ok)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if _, ok := c.Get("a"); ok {
+		t.Errorf("LRU.Get(a) found an expired entry")
+	}
+
+	c.Set("d", 4)
+	c.Delete("d")
+	if _, ok := c.Get("d"); ok {
+		t.Errorf("LRU.Get(d) found a deleted entry")
+	}
+
+	c.Set("e", 5)
+	c.Purge()
+	if _, ok := c.Get("e"); ok {
+		t.Errorf("LRU.Get(e) found a purged entry")
+	}
+
+	want := Stats{Name: "test", Hits: 2, Misses: 4, Evictions: 1, Size: 0, Capacity: 2}
+	if got := c.Stats(); got != want {
+		t.Errorf("LRU.Stats() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,63 @@
+package contexts
+
+import "context"
+
+type contextKey string
+
+const (
+	actorKey          contextKey = "actor"
+	requestIDKey      contextKey = "request_id"
+	readYourWritesKey contextKey = "read_your_writes"
+	tenantKey         contextKey = "tenant"
+)
+
+// AnonymousActor is recorded when a request does not identify who made it.
+const AnonymousActor = "anonymous"
+
+// DefaultTenant owns the rows of requests that do not name a tenant.
+const DefaultTenant = "default"
+
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey, actor)
+}
+
+func Actor(ctx context.Context) string {
+	if actor, ok := ctx.Value(actorKey).(string); ok && actor != "" {
+		return actor
+	}
+	return AnonymousActor
+}
+
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey, requestID)
+}
+
+func RequestID(ctx context.Context) string {
+	if requestID, ok := ctx.Value(requestIDKey).(string); ok {
+		return requestID
+	}
+	return ""
+}
+
+// WithReadYourWrites asks for reads to see every write committed before
+// them, e.g. by reading from the primary database instead of a replica.
+func WithReadYourWrites(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readYourWritesKey, true)
+}
+
+func ReadYourWrites(ctx context.Context) bool {
+	readYourWrites, _ := ctx.Value(readYourWritesKey).(bool)
+	return readYourWrites
+}
+
+// WithTenant scopes every query made with the context to a tenant.
+func WithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantKey, tenant)
+}
+
+func Tenant(ctx context.Context) string {
+	if tenant, ok := ctx.Value(tenantKey).(string); ok && tenant != "" {
+		return tenant
+	}
+	return DefaultTenant
+}
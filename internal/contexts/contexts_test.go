@@ -0,0 +1,120 @@
+package contexts
+
+import (
+	"context"
+	"testing"
+)
+
+func TestActor(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{
+			name: "Should get the actor from the context",
+			ctx:  WithActor(context.Background(), "jdoe"),
+			want: "jdoe",
+		},
+		{
+			name: "Should fall back to anonymous",
+			ctx:  context.Background(),
+			want: AnonymousActor,
+		},
+		{
+			name: "Should fall back to anonymous for an empty actor",
+			ctx:  WithActor(context.Background(), ""),
+			want: AnonymousActor,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Actor(tt.ctx); got != tt.want {
+				t.Errorf("Actor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequestID(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{
+			name: "Should get the request ID from the context",
+			ctx:  WithRequestID(context.Background(), "abc-123"),
+			want: "abc-123",
+		},
+		{
+			name: "Should be empty without a request ID",
+			ctx:  context.Background(),
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RequestID(tt.ctx); got != tt.want {
+				t.Errorf("RequestID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadYourWrites(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want bool
+	}{
+		{
+			name: "Should be set by WithReadYourWrites",
+			ctx:  WithReadYourWrites(context.Background()),
+			want: true,
+		},
+		{
+			name: "Should be unset by default",
+			ctx:  context.Background(),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ReadYourWrites(tt.ctx); got != tt.want {
+				t.Errorf("ReadYourWrites() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTenant(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{
+			name: "Should get the tenant from the context",
+			ctx:  WithTenant(context.Background(), "shelter-a"),
+			want: "shelter-a",
+		},
+		{
+			name: "Should fall back to the default tenant",
+			ctx:  context.Background(),
+			want: DefaultTenant,
+		},
+		{
+			name: "Should fall back to the default tenant for an empty tenant",
+			ctx:  WithTenant(context.Background(), ""),
+			want: DefaultTenant,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Tenant(tt.ctx); got != tt.want {
+				t.Errorf("Tenant() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
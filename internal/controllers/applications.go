@@ -0,0 +1,213 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/one-byte-data/go-api-sample/internal/models"
+	"github.com/one-byte-data/go-api-sample/internal/problems"
+	"github.com/one-byte-data/go-api-sample/internal/services"
+)
+
+// ReviewRequest is the body of the review endpoint.
+type ReviewRequest struct {
+	Status string `json:"status" binding:"required,oneof=under_review approved rejected"`
+}
+
+// @Summary Gets the adoption applications
+// @Description get adoption applications, oldest first, optionally filtered by species, animal and status
+// @Produce  json
+// @Param        species    query  string  false  "Species"  Enums(cats, dogs)
+// @Param        animal_id  query  string  false  "Cat or dog ID"
+// @Param        status     query  string  false  "Status"  Enums(submitted, under_review, approved, rejected)
+// @Success 200 {array} models.Application	"ok"
+// @Failure      400   {object}  problems.Problem  "problem"
+// @Failure      500   {object}  problems.Problem  "problem"
+// @Failure      503   {object}  problems.Problem  "problem"
+// @Router /applications [get]
+func ApplicationsGet(c *gin.Context) {
+	filter := &services.ApplicationFilter{
+		Species: c.Query("species"),
+		Status:  c.Query("status"),
+	}
+
+	switch filter.Species {
+	case "", services.CatsResource, services.DogsResource:
+	default:
+		problems.Abort(c, problems.New(http.StatusBadRequest, problems.CodeInvalidQuery, "invalid species"))
+		return
+	}
+
+	switch filter.Status {
+	case "", models.ApplicationSubmitted, models.ApplicationUnderReview, models.ApplicationApproved, models.ApplicationRejected:
+	default:
+		problems.Abort(c, problems.New(http.StatusBadRequest, problems.CodeInvalidQuery, "invalid status"))
+		return
+	}
+
+	if value := c.Query("animal_id"); value != "" {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			problems.Abort(c, problems.New(http.StatusBadRequest, problems.CodeInvalidQuery, "invalid animal_id"))
+			return
+		}
+		filter.AnimalID = &id
+	}
+
+	applications, err := applicationsService.Get(c.Request.Context(), filter)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, applications)
+}
+
+// @Summary Gets an adoption application by ID
+// @Description get an adoption application
+// @Produce  json
+// @Param        id  path  string  true  "Application ID"
+// @Success 200 {object} models.Application	"ok"
+// @Failure      400   {object}  problems.Problem  "problem"
+// @Failure      404   {object}  problems.Problem  "problem"
+// @Failure      500   {object}  problems.Problem  "problem"
+// @Failure      503   {object}  problems.Problem  "problem"
+// @Router /applications/{id} [get]
+func ApplicationsGetOne(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		abortInvalidID(c)
+		return
+	}
+
+	application, err := applicationsService.GetOne(c.Request.Context(), id)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, application)
+}
+
+// @Summary Submits an adoption application
+// @Description submits an application to adopt a cat or dog. The cat or dog must exist and not be adopted yet
+// @Accept   json
+// @Produce  json
+// @Param        message  body  models.Application  true  "Application"
+// @Success      201   {object}  models.Application  "created"
+// @Header       201   {string}  Location  "URL of the new application"
+// @Failure      400   {object}  problems.Problem  "problem"
+// @Failure      404   {object}  problems.Problem  "problem"
+// @Failure      409   {object}  problems.Problem  "problem"
+// @Failure      500   {object}  problems.Problem  "problem"
+// @Failure      503   {object}  problems.Problem  "problem"
+// @Router /applications [post]
+func ApplicationsPost(c *gin.Context) {
+	application := new(models.Application)
+	if err := bind(c, application); err != nil {
+		c.Error(err)
+		problems.Abort(c, problems.Binding(err))
+		return
+	}
+
+	if err := applicationsService.Add(c.Request.Context(), application); err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.Header("Location", fmt.Sprintf("/applications/%s", application.ID))
+	c.JSON(http.StatusCreated, application)
+}
+
+// @Summary Updates an adoption application by ID
+// @Description replaces the applicant details of an application that is still open. The cat or dog, status and reviewer are kept
+// @Accept   json
+// @Produce  json
+// @Param        id       path  string              true  "Application ID"
+// @Param        message  body  models.Application  true  "Application"
+// @Success      200   {object}  models.Application  "updated"
+// @Failure      400   {object}  problems.Problem  "problem"
+// @Failure      404   {object}  problems.Problem  "problem"
+// @Failure      409   {object}  problems.Problem  "problem"
+// @Failure      500   {object}  problems.Problem  "problem"
+// @Failure      503   {object}  problems.Problem  "problem"
+// @Router /applications/{id} [put]
+func ApplicationsPut(c *gin.Context) {
+	application := new(models.Application)
+	if err := bind(c, application); err != nil {
+		c.Error(err)
+		problems.Abort(c, problems.Binding(err))
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		abortInvalidID(c)
+		return
+	}
+
+	if err := applicationsService.Update(c.Request.Context(), id, application); err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, application)
+}
+
+// @Summary Deletes an adoption application by ID
+// @Description deletes an adoption application
+// @Produce  json
+// @Param        id  path  string  true  "Application ID"
+// @Success 200 {object} controllers.Deleted	"ok"
+// @Failure      400   {object}  problems.Problem  "problem"
+// @Failure      404   {object}  problems.Problem  "problem"
+// @Failure      500   {object}  problems.Problem  "problem"
+// @Failure      503   {object}  problems.Problem  "problem"
+// @Router /applications/{id} [delete]
+func ApplicationsDelete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		abortInvalidID(c)
+		return
+	}
+
+	if err := applicationsService.Delete(c.Request.Context(), id); err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, Deleted{Deleted: id.String()})
+}
+
+// @Summary Reviews an adoption application
+// @Description moves an application to under_review, approved or rejected. Approving it marks the cat or dog adopted and rejects the other open applications for it
+// @Accept   json
+// @Produce  json
+// @Param        id       path  string                     true  "Application ID"
+// @Param        message  body  controllers.ReviewRequest  true  "Status"
+// @Success      200   {object}  models.Application  "reviewed"
+// @Failure      400   {object}  problems.Problem  "problem"
+// @Failure      404   {object}  problems.Problem  "problem"
+// @Failure      409   {object}  problems.Problem  "problem"
+// @Failure      500   {object}  problems.Problem  "problem"
+// @Failure      503   {object}  problems.Problem  "problem"
+// @Router /applications/{id}/review [post]
+func ApplicationsReview(c *gin.Context) {
+	request := new(ReviewRequest)
+	if err := bind(c, request); err != nil {
+		c.Error(err)
+		problems.Abort(c, problems.Binding(err))
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		abortInvalidID(c)
+		return
+	}
+
+	application, err := applicationsService.Review(c.Request.Context(), id, request.Status)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, application)
+}
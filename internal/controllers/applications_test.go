@@ -0,0 +1,160 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/one-byte-data/go-api-sample/internal/middlewares"
+	"github.com/one-byte-data/go-api-sample/internal/repositories"
+)
+
+func TestApplications(t *testing.T) {
+	router, err := SetupRouter(repositories.NewMemory())
+	if err != nil {
+		panic(err)
+	}
+
+	const dogID = "d06d0000-0000-4000-8000-000000000011"
+	const firstID = "a9910000-0000-4000-8000-000000000001"
+	const secondID = "a9910000-0000-4000-8000-000000000002"
+	dog := `{"id": "` + dogID + `", "name": "Spot", "breed": "Samoyed", "color": "White", "birthdate": "2019-05-01T00:00:00Z", "weight": 50}`
+	application := func(id string, name string) string {
+		return `{"id": "` + id + `", "species": "dogs", "animal_id": "` + dogID + `", "applicant_name": "` + name + `", "applicant_email": "adopter@example.com"}`
+	}
+
+	type args struct {
+		method   string
+		endpoint string
+		body     string
+	}
+	tests := []struct {
+		name         string
+		args         args
+		wantCode     int
+		wantResponse string
+	}{
+		{
+			name:     "Should not apply for a dog that does not exist",
+			args:     args{method: "POST", endpoint: "/applications", body: application(firstID, "Bob")},
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "Should add the dog",
+			args:     args{method: "POST", endpoint: "/dogs", body: dog},
+			wantCode: http.StatusCreated,
+		},
+		{
+			name:         "Should submit an application",
+			args:         args{method: "POST", endpoint: "/applications", body: application(firstID, "Bob")},
+			wantCode:     http.StatusCreated,
+			wantResponse: `"status":"submitted"`,
+		},
+		{
+			name:         "Should submit a competing application",
+			args:         args{method: "POST", endpoint: "/applications", body: application(secondID, "Carol")},
+			wantCode:     http.StatusCreated,
+			wantResponse: `"applicant_name":"Carol"`,
+		},
+		{
+			name:         "Should reject an invalid email address",
+			args:         args{method: "POST", endpoint: "/applications", body: `{"species": "dogs", "animal_id": "` + dogID + `", "applicant_name": "Dave", "applicant_email": "dave"}`},
+			wantCode:     http.StatusBadRequest,
+			wantResponse: `"rule":"email","message":"must be an email address"`,
+		},
+		{
+			name:         "Should update the applicant details",
+			args:         args{method: "PUT", endpoint: "/applications/" + firstID, body: `{"species": "dogs", "animal_id": "` + dogID + `", "applicant_name": "Robert", "applicant_email": "robert@example.com"}`},
+			wantCode:     http.StatusOK,
+			wantResponse: `"applicant_name":"Robert"`,
+		},
+		{
+			name:         "Should put the application under review",
+			args:         args{method: "POST", endpoint: "/applications/" + firstID + "/review", body: `{"status": "under_review"}`},
+			wantCode:     http.StatusOK,
+			wantResponse: `"status":"under_review","reviewer":"shelter-staff"`,
+		},
+		{
+			name:         "Should not approve the application while the dog is at intake",
+			args:         args{method: "POST", endpoint: "/applications/" + firstID + "/review", body: `{"status": "approved"}`},
+			wantCode:     http.StatusConflict,
+			wantResponse: `"code":"invalid_transition"`,
+		},
+		{
+			name:         "Should make the dog available",
+			args:         args{method: "POST", endpoint: "/dogs/" + dogID + "/transitions", body: `{"status": "available"}`},
+			wantCode:     http.StatusCreated,
+			wantResponse: `"to":"available"`,
+		},
+		{
+			name:         "Should approve the application",
+			args:         args{method: "POST", endpoint: "/applications/" + firstID + "/review", body: `{"status": "approved"}`},
+			wantCode:     http.StatusOK,
+			wantResponse: `"status":"approved"`,
+		},
+		{
+			name:         "Should mark the dog adopted",
+			args:         args{method: "GET", endpoint: "/dogs/" + dogID},
+			wantCode:     http.StatusOK,
+			wantResponse: `"status":"adopted"`,
+		},
+		{
+			name:         "Should reject the competing application",
+			args:         args{method: "GET", endpoint: "/applications?status=rejected&animal_id=" + dogID},
+			wantCode:     http.StatusOK,
+			wantResponse: `"id":"` + secondID + `"`,
+		},
+		{
+			name:         "Should not submit an application for an adopted dog",
+			args:         args{method: "POST", endpoint: "/applications", body: application("a9910000-0000-4000-8000-000000000003", "Erin")},
+			wantCode:     http.StatusConflict,
+			wantResponse: `"code":"conflict"`,
+		},
+		{
+			name:         "Should not update a decided application",
+			args:         args{method: "PUT", endpoint: "/applications/" + secondID, body: application(secondID, "Caroline")},
+			wantCode:     http.StatusConflict,
+			wantResponse: `"code":"conflict"`,
+		},
+		{
+			name:         "Should reject an unknown review status",
+			args:         args{method: "POST", endpoint: "/applications/" + secondID + "/review", body: `{"status": "submitted"}`},
+			wantCode:     http.StatusBadRequest,
+			wantResponse: `"rule":"oneof"`,
+		},
+		{
+			name:         "Should not filter by an invalid animal ID",
+			args:         args{method: "GET", endpoint: "/applications?animal_id=spot"},
+			wantCode:     http.StatusBadRequest,
+			wantResponse: `"code":"invalid_query"`,
+		},
+		{
+			name:         "Should delete the application",
+			args:         args{method: "DELETE", endpoint: "/applications/" + secondID},
+			wantCode:     http.StatusOK,
+			wantResponse: secondID,
+		},
+		{
+			name:     "Should not get a deleted application",
+			args:     args{method: "GET", endpoint: "/applications/" + secondID},
+			wantCode: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(tt.args.method, tt.args.endpoint, strings.NewReader(tt.args.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set(middlewares.ActorHeader, "shelter-staff")
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("%s %s code = %v, wantCode %v: %s", tt.args.method, tt.args.endpoint, w.Code, tt.wantCode, w.Body.String())
+			}
+			if !strings.Contains(w.Body.String(), tt.wantResponse) {
+				t.Errorf("%s %s = %v, wantResponse %v", tt.args.method, tt.args.endpoint, w.Body.String(), tt.wantResponse)
+			}
+		})
+	}
+}
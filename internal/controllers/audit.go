@@ -0,0 +1,106 @@
+package controllers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/one-byte-data/go-api-sample/internal/problems"
+	"github.com/one-byte-data/go-api-sample/internal/services"
+)
+
+// @Summary Gets the audit log
+// @Description get audit entries, optionally filtered by resource, resource ID, actor and time
+// @Produce  json
+// @Param        resource     query     string  false  "Resource name (cats, dogs)"
+// @Param        resource_id  query     string  false  "Resource ID"
+// @Param        actor        query     string  false  "Actor"
+// @Param        since        query     string  false  "Only entries at or after this RFC 3339 time"
+// @Param        until        query     string  false  "Only entries before this RFC 3339 time"
+// @Success 200 {array} models.AuditEntry	"ok"
+// @Failure      400   {object}  problems.Problem  "problem"
+// @Failure      500   {object}  problems.Problem  "problem"
+// @Failure      503   {object}  problems.Problem  "problem"
+// @Router /audit [get]
+func AuditGet(c *gin.Context) {
+	filter := &services.AuditFilter{
+		Resource: c.Query("resource"),
+		Actor:    c.Query("actor"),
+	}
+
+	if value := c.Query("resource_id"); value != "" {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			problems.Abort(c, problems.New(http.StatusBadRequest, problems.CodeInvalidQuery, "invalid resource_id"))
+			return
+		}
+		filter.ResourceID = &id
+	}
+
+	if value := c.Query("since"); value != "" {
+		since, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			problems.Abort(c, problems.New(http.StatusBadRequest, problems.CodeInvalidQuery, "invalid since"))
+			return
+		}
+		filter.Since = &since
+	}
+
+	if value := c.Query("until"); value != "" {
+		until, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			problems.Abort(c, problems.New(http.StatusBadRequest, problems.CodeInvalidQuery, "invalid until"))
+			return
+		}
+		filter.Until = &until
+	}
+
+	entries, err := auditService.Get(c.Request.Context(), filter)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, entries)
+}
+
+// @Summary Gets the change history of a cat
+// @Description get the audit entries for a cat
+// @Produce  json
+// @Param        id        path      string     true  "Cat ID"
+// @Success 200 {array} models.AuditEntry	"ok"
+// @Failure      400   {object}  problems.Problem  "problem"
+// @Failure      500   {object}  problems.Problem  "problem"
+// @Failure      503   {object}  problems.Problem  "problem"
+// @Router /cats/{id}/history [get]
+func CatsHistory(c *gin.Context) {
+	history(c, services.CatsResource)
+}
+
+// @Summary Gets the change history of a dog
+// @Description get the audit entries for a dog
+// @Produce  json
+// @Param        id        path      string     true  "Dog ID"
+// @Success 200 {array} models.AuditEntry	"ok"
+// @Failure      400   {object}  problems.Problem  "problem"
+// @Failure      500   {object}  problems.Problem  "problem"
+// @Failure      503   {object}  problems.Problem  "problem"
+// @Router /dogs/{id}/history [get]
+func DogsHistory(c *gin.Context) {
+	history(c, services.DogsResource)
+}
+
+func history(c *gin.Context, resource string) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		abortInvalidID(c)
+		return
+	}
+
+	entries, err := auditService.History(c.Request.Context(), resource, id)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, entries)
+}
@@ -0,0 +1,165 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/one-byte-data/go-api-sample/cmd/tests"
+	"github.com/one-byte-data/go-api-sample/internal/middlewares"
+	"github.com/one-byte-data/go-api-sample/internal/models"
+	"github.com/one-byte-data/go-api-sample/internal/repositories"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestAuditGet(t *testing.T) {
+	db, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	gdb, err := gorm.Open(postgres.Dialector{
+		Config: &postgres.Config{Conn: db},
+	})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	router, err := SetupRouter(repositories.NewGorm(gdb))
+	if err != nil {
+		panic(err)
+	}
+
+	type args struct {
+		method   string
+		endpoint string
+	}
+	tests := []struct {
+		name         string
+		args         args
+		wantResponse string
+		wantCode     int
+	}{
+		{
+			name:         "Should not get audit entries for an invalid resource ID",
+			args:         args{method: "GET", endpoint: "/audit?resource_id=not_a_valid_id"},
+			wantResponse: "\"detail\":\"invalid resource_id\"",
+			wantCode:     http.StatusBadRequest,
+		},
+		{
+			name:         "Should not get audit entries for an invalid time",
+			args:         args{method: "GET", endpoint: "/audit?since=yesterday"},
+			wantResponse: "\"detail\":\"invalid since\"",
+			wantCode:     http.StatusBadRequest,
+		},
+		{
+			name:         "Should not get the history of an invalid ID",
+			args:         args{method: "GET", endpoint: "/cats/not_a_valid_id/history"},
+			wantResponse: "\"code\":\"invalid_id\"",
+			wantCode:     http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(tt.args.method, tt.args.endpoint, nil)
+			router.ServeHTTP(w, req)
+
+			if tt.wantCode != w.Code {
+				t.Errorf("AuditGet() error = %v, wantCode %v", w.Code, tt.wantCode)
+				return
+			}
+
+			if !strings.Contains(w.Body.String(), tt.wantResponse) {
+				t.Errorf("AuditGet() error = %v, wantResponse %v", w.Body.String(), tt.wantResponse)
+			}
+		})
+	}
+}
+
+func TestIntegrationAuditGet(t *testing.T) {
+	if m := flag.Lookup("test.run").Value.String(); m == "" || !regexp.MustCompile(m).MatchString(t.Name()) {
+		t.Skip("skipping as execution was not requested explicitly using go test -run")
+	}
+
+	teardownTests := tests.SetupTests(t, postgres.Open(tests.ConnectionString))
+	defer teardownTests(t)
+
+	router, err := SetupRouter(repositories.NewGorm(tests.DB))
+	if err != nil {
+		panic(err)
+	}
+
+	cat := tests.Cats[0]
+	cat.Color = "Black"
+	data, _ := json.Marshal(cat)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("PUT", fmt.Sprintf("/cats/%s", cat.ID.String()), bytes.NewReader(data))
+	req.Header.Add("Content-type", "application/json")
+	req.Header.Add(middlewares.ActorHeader, "jdoe")
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("CatsPut() error = %v, wantCode %v", w.Code, http.StatusAccepted)
+	}
+
+	type args struct {
+		method   string
+		endpoint string
+	}
+	tests := []struct {
+		name      string
+		args      args
+		wantCount int
+		wantCode  int
+	}{
+		{
+			name:      "Should get the audit entries of an actor",
+			args:      args{method: "GET", endpoint: "/audit?actor=jdoe"},
+			wantCount: 1,
+			wantCode:  http.StatusOK,
+		},
+		{
+			name:      "Should get the history of a cat",
+			args:      args{method: "GET", endpoint: fmt.Sprintf("/cats/%s/history", cat.ID.String())},
+			wantCount: 1,
+			wantCode:  http.StatusOK,
+		},
+		{
+			name:      "Should get no entries for another actor",
+			args:      args{method: "GET", endpoint: "/audit?actor=someone_else"},
+			wantCount: 0,
+			wantCode:  http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(tt.args.method, tt.args.endpoint, nil)
+		router.ServeHTTP(w, req)
+
+		if tt.wantCode != w.Code {
+			t.Errorf("AuditGet() error = %v, wantCode %v", w.Code, tt.wantCode)
+			return
+		}
+
+		entries := make([]models.AuditEntry, 0)
+		if err := json.Unmarshal(w.Body.Bytes(), &entries); err != nil {
+			t.Errorf("AuditGet() error = %v, wantCount %v", err, tt.wantCount)
+			return
+		}
+
+		if tt.wantCount != len(entries) {
+			t.Errorf("AuditGet() error = %v, wantCount %v", len(entries), tt.wantCount)
+		}
+	}
+}
@@ -0,0 +1,21 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/one-byte-data/go-api-sample/internal/tracing"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// bind decodes and validates the request body in its own span so the time
+// spent binding shows up separately from the service and database spans.
+func bind(c *gin.Context, obj interface{}) error {
+	_, span := tracing.Tracer().Start(c.Request.Context(), "bind")
+	defer span.End()
+
+	err := c.ShouldBind(obj)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
@@ -0,0 +1,152 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/one-byte-data/go-api-sample/internal/models"
+	"github.com/one-byte-data/go-api-sample/internal/problems"
+)
+
+// @Summary Gets the breeds of a species
+// @Description get the breed catalogue of cats or dogs, ordered by name
+// @Produce  json
+// @Param        species  path  string  true  "Species"  Enums(cats, dogs)
+// @Success 200 {array} models.Breed	"ok"
+// @Failure      404   {object}  problems.Problem  "problem"
+// @Failure      500   {object}  problems.Problem  "problem"
+// @Failure      503   {object}  problems.Problem  "problem"
+// @Router /breeds/{species} [get]
+func BreedsGet(c *gin.Context) {
+	breeds, err := breedsService.Get(c.Request.Context(), c.Param("species"))
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, breeds)
+}
+
+// @Summary Gets a breed by ID
+// @Description get a breed of cats or dogs
+// @Produce  json
+// @Param        species  path  string  true  "Species"  Enums(cats, dogs)
+// @Param        id       path  string  true  "Breed ID"
+// @Success 200 {object} models.Breed	"ok"
+// @Failure      400   {object}  problems.Problem  "problem"
+// @Failure      404   {object}  problems.Problem  "problem"
+// @Failure      500   {object}  problems.Problem  "problem"
+// @Failure      503   {object}  problems.Problem  "problem"
+// @Router /breeds/{species}/{id} [get]
+func BreedsGetOne(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		abortInvalidID(c)
+		return
+	}
+
+	breed, err := breedsService.GetOne(c.Request.Context(), c.Param("species"), id)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, breed)
+}
+
+// @Summary Adds a breed
+// @Description adds a breed to the catalogue of cats or dogs, requires the admin API key. The name and aliases must not name another breed of the species
+// @Accept   json
+// @Produce  json
+// @Param        species    path    string        true  "Species"  Enums(cats, dogs)
+// @Param        message    body    models.Breed  true  "Breed"
+// @Param        X-Api-Key  header  string        true  "Admin API key"
+// @Success      201   {object}  models.Breed  "created"
+// @Header       201   {string}  Location  "URL of the new breed"
+// @Failure      400   {object}  problems.Problem  "problem"
+// @Failure      403   {object}  problems.Problem  "problem"
+// @Failure      404   {object}  problems.Problem  "problem"
+// @Failure      409   {object}  problems.Problem  "problem"
+// @Failure      500   {object}  problems.Problem  "problem"
+// @Failure      503   {object}  problems.Problem  "problem"
+// @Router /breeds/{species} [post]
+func BreedsPost(c *gin.Context) {
+	breed := new(models.Breed)
+	if err := bind(c, breed); err != nil {
+		c.Error(err)
+		problems.Abort(c, problems.Binding(err))
+		return
+	}
+
+	breed.Species = c.Param("species")
+	if err := breedsService.Add(c.Request.Context(), breed); err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.Header("Location", fmt.Sprintf("/breeds/%s/%s", breed.Species, breed.ID))
+	c.JSON(http.StatusCreated, breed)
+}
+
+// @Summary Updates a breed by ID
+// @Description replaces the name, aliases and weight range of a breed, requires the admin API key. Cats and dogs keep the breed name they were stored with
+// @Accept   json
+// @Produce  json
+// @Param        species    path    string        true  "Species"  Enums(cats, dogs)
+// @Param        id         path    string        true  "Breed ID"
+// @Param        message    body    models.Breed  true  "Breed"
+// @Param        X-Api-Key  header  string        true  "Admin API key"
+// @Success      200   {object}  models.Breed  "updated"
+// @Failure      400   {object}  problems.Problem  "problem"
+// @Failure      403   {object}  problems.Problem  "problem"
+// @Failure      404   {object}  problems.Problem  "problem"
+// @Failure      409   {object}  problems.Problem  "problem"
+// @Failure      500   {object}  problems.Problem  "problem"
+// @Failure      503   {object}  problems.Problem  "problem"
+// @Router /breeds/{species}/{id} [put]
+func BreedsPut(c *gin.Context) {
+	breed := new(models.Breed)
+	if err := bind(c, breed); err != nil {
+		c.Error(err)
+		problems.Abort(c, problems.Binding(err))
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		abortInvalidID(c)
+		return
+	}
+
+	if err := breedsService.Update(c.Request.Context(), c.Param("species"), id, breed); err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, breed)
+}
+
+// @Summary Deletes a breed by ID
+// @Description deletes a breed from the catalogue, requires the admin API key. Cats and dogs keep the breed name they were stored with
+// @Produce  json
+// @Param        species    path    string  true  "Species"  Enums(cats, dogs)
+// @Param        id         path    string  true  "Breed ID"
+// @Param        X-Api-Key  header  string  true  "Admin API key"
+// @Success 200 {object} controllers.Deleted	"ok"
+// @Failure      400   {object}  problems.Problem  "problem"
+// @Failure      403   {object}  problems.Problem  "problem"
+// @Failure      404   {object}  problems.Problem  "problem"
+// @Failure      500   {object}  problems.Problem  "problem"
+// @Failure      503   {object}  problems.Problem  "problem"
+// @Router /breeds/{species}/{id} [delete]
+func BreedsDelete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		abortInvalidID(c)
+		return
+	}
+
+	if err := breedsService.Delete(c.Request.Context(), c.Param("species"), id); err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, Deleted{Deleted: id.String()})
+}
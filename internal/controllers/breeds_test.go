@@ -0,0 +1,147 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/one-byte-data/go-api-sample/internal/middlewares"
+	"github.com/one-byte-data/go-api-sample/internal/repositories"
+)
+
+// expectBreeds expects the services to look up the breed catalogue, which
+// holds the named cat breeds without a weight range.
+func expectBreeds(mock sqlmock.Sqlmock, names ...string) {
+	rows := sqlmock.NewRows([]string{"id", "species", "name", "aliases", "min_weight", "max_weight"})
+	for _, name := range names {
+		rows.AddRow(uuid.New(), "cats", name, "[]", 0, 0)
+	}
+	mock.ExpectQuery(`SELECT \* FROM "breeds"`).WillReturnRows(rows)
+}
+
+func TestBreeds(t *testing.T) {
+	t.Setenv("ADMIN_API_KEY", "secret")
+	t.Setenv("BREEDS_STRICT", "true")
+	router, err := SetupRouter(repositories.NewMemory())
+	if err != nil {
+		panic(err)
+	}
+
+	const breedID = "b4eed000-0000-4000-8000-000000000001"
+	const catID = "1e1a5b7c-0000-4000-8000-000000000002"
+	breed := `{"id": "` + breedID + `", "name": "Savannah", "aliases": ["Savanna"], "min_weight": 12, "max_weight": 25}`
+
+	type args struct {
+		method   string
+		endpoint string
+		apiKey   string
+		body     string
+	}
+	tests := []struct {
+		name         string
+		args         args
+		wantCode     int
+		wantResponse string
+	}{
+		{
+			name:         "Should list the bundled breeds",
+			args:         args{method: "GET", endpoint: "/breeds/dogs"},
+			wantCode:     http.StatusOK,
+			wantResponse: `"name":"Shiba Inu"`,
+		},
+		{
+			name:     "Should not list the breeds of another species",
+			args:     args{method: "GET", endpoint: "/breeds/birds"},
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "Should not add a breed without the admin key",
+			args:     args{method: "POST", endpoint: "/breeds/cats", body: breed},
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:         "Should add a breed",
+			args:         args{method: "POST", endpoint: "/breeds/cats", apiKey: "secret", body: breed},
+			wantCode:     http.StatusCreated,
+			wantResponse: `"species":"cats"`,
+		},
+		{
+			name:         "Should not add a breed twice",
+			args:         args{method: "POST", endpoint: "/breeds/cats", apiKey: "secret", body: breed},
+			wantCode:     http.StatusConflict,
+			wantResponse: `"code":"conflict"`,
+		},
+		{
+			name:         "Should not add a breed with an inverted weight range",
+			args:         args{method: "POST", endpoint: "/breeds/cats", apiKey: "secret", body: `{"name": "Toyger", "min_weight": 15, "max_weight": 7}`},
+			wantCode:     http.StatusBadRequest,
+			wantResponse: `"field":"max_weight"`,
+		},
+		{
+			name:         "Should store a cat by the canonical name of its breed",
+			args:         args{method: "POST", endpoint: "/cats", body: `{"id": "` + catID + `", "name": "Nacho", "breed": "savanna", "color": "Orange", "birthdate": "2019-05-01T00:00:00Z", "weight": 15}`},
+			wantCode:     http.StatusCreated,
+			wantResponse: `"breed":"Savannah","color":"Orange","birthdate":"2019-05-01T00:00:00Z","weight":15,"breed_status":"known"`,
+		},
+		{
+			name:         "Should not store a cat too light for its breed",
+			args:         args{method: "PUT", endpoint: "/cats/" + catID, body: `{"name": "Nacho", "breed": "Savannah", "color": "Orange", "birthdate": "2019-05-01T00:00:00Z", "weight": 6}`},
+			wantCode:     http.StatusBadRequest,
+			wantResponse: `{"field":"weight","rule":"breedweight","message":"must be between 12 and 25 for the breed"}`,
+		},
+		{
+			name:         "Should update a breed",
+			args:         args{method: "PUT", endpoint: "/breeds/cats/" + breedID, apiKey: "secret", body: `{"name": "Savannah", "aliases": [], "min_weight": 5, "max_weight": 25}`},
+			wantCode:     http.StatusOK,
+			wantResponse: `"aliases":[]`,
+		},
+		{
+			name:         "Should use the updated weight range",
+			args:         args{method: "PUT", endpoint: "/cats/" + catID, body: `{"name": "Nacho", "breed": "Savannah", "color": "Orange", "birthdate": "2019-05-01T00:00:00Z", "weight": 6}`},
+			wantCode:     http.StatusOK,
+			wantResponse: `"weight":6`,
+		},
+		{
+			name:     "Should not get the breed as another species",
+			args:     args{method: "GET", endpoint: "/breeds/dogs/" + breedID},
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "Should delete a breed",
+			args:     args{method: "DELETE", endpoint: "/breeds/cats/" + breedID, apiKey: "secret"},
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "Should not get a deleted breed",
+			args:     args{method: "GET", endpoint: "/breeds/cats/" + breedID},
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:         "Should reject a breed missing from the catalogue",
+			args:         args{method: "PUT", endpoint: "/cats/" + catID, body: `{"name": "Nacho", "breed": "Savannah", "color": "Orange", "birthdate": "2019-05-01T00:00:00Z", "weight": 6}`},
+			wantCode:     http.StatusBadRequest,
+			wantResponse: `"rule":"breed"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(tt.args.method, tt.args.endpoint, strings.NewReader(tt.args.body))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.args.apiKey != "" {
+				req.Header.Set(middlewares.APIKeyHeader, tt.args.apiKey)
+			}
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("%s %s code = %v, wantCode %v: %s", tt.args.method, tt.args.endpoint, w.Code, tt.wantCode, w.Body.String())
+			}
+			if !strings.Contains(w.Body.String(), tt.wantResponse) {
+				t.Errorf("%s %s = %v, wantResponse %v", tt.args.method, tt.args.endpoint, w.Body.String(), tt.wantResponse)
+			}
+		})
+	}
+}
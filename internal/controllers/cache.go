@@ -0,0 +1,32 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/one-byte-data/go-api-sample/internal/cache"
+)
+
+// @Summary Gets the cache statistics
+// @Description get the hits, misses, evictions and size of every cache
+// @Produce  json
+// @Success 200 {array} cache.Stats	"ok"
+// @Router /cache/stats [get]
+func CacheStatsGet(c *gin.Context) {
+	stats := make([]cache.Stats, 0, len(caches))
+	for _, reporter := range caches {
+		stats = append(stats, reporter.Stats())
+	}
+	c.JSON(http.StatusOK, stats)
+}
+
+// setCacheControl lets clients reuse a read response for as long as the
+// server caches it. Responses are private because they depend on the caller.
+func setCacheControl(c *gin.Context) {
+	if !cacheConfig.Enabled() {
+		c.Header("Cache-Control", "no-cache")
+		return
+	}
+	c.Header("Cache-Control", fmt.Sprintf("private, max-age=%d", int(cacheConfig.TTL.Seconds())))
+}
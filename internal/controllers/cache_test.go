@@ -0,0 +1,76 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/one-byte-data/go-api-sample/internal/cache"
+	"github.com/one-byte-data/go-api-sample/internal/contexts"
+	"github.com/one-byte-data/go-api-sample/internal/repositories"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestCacheStatsGet(t *testing.T) {
+	t.Setenv("CACHE_TTL", "1m")
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	gdb, err := gorm.Open(postgres.Dialector{
+		Config: &postgres.Config{Conn: db},
+	})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	router, err := SetupRouter(repositories.NewGorm(gdb))
+	if err != nil {
+		panic(err)
+	}
+
+	testID := uuid.New()
+	mock.ExpectQuery(`SELECT \* FROM "cats" WHERE`).WillReturnRows(sqlmock.NewRows([]string{"id", "name", "tenant_id"}).AddRow(testID, "Nacho", contexts.DefaultTenant))
+
+	for i := 0; i < 2; i++ {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", fmt.Sprintf("/cats/%s", testID.String()), nil)
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("CatsGetOne() error = %v, wantCode %v", w.Code, http.StatusOK)
+		}
+		if got := w.Header().Get("Cache-Control"); got != "private, max-age=60" {
+			t.Errorf("CatsGetOne() Cache-Control = %v, want private, max-age=60", got)
+		}
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("CatsGetOne() %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/cache/stats", nil)
+	router.ServeHTTP(w, req)
+
+	stats := make([]cache.Stats, 0)
+	if err := json.Unmarshal(w.Body.Bytes(), &stats); err != nil {
+		t.Fatalf("CacheStatsGet() error = %v", err)
+	}
+	for _, s := range stats {
+		if s.Name == "cats" && (s.Hits != 1 || s.Misses != 1) {
+			t.Errorf("CacheStatsGet() cats = %+v, want 1 hit and 1 miss", s)
+		}
+	}
+	if len(stats) != 5 {
+		t.Errorf("CacheStatsGet() = %v caches, want 5", len(stats))
+	}
+}
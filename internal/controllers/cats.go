@@ -7,85 +7,97 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/one-byte-data/go-api-sample/internal/models"
+	"github.com/one-byte-data/go-api-sample/internal/problems"
 )
 
 // @Summary Deletes a cat by ID
 // @Description deletes a cat
 // @Produce  json
-// @Success 200 {object} interface{}	"ok"
-// @Failure      400   {string}   string  "ok"
-// @Failure      404   {string}   string  "ok"
-// @Failure      500   {string}   string  "ok"
-// @Router /cats/{cat_id} [delete]
+// @Param        id        path      string     true  "Cat ID"
+// @Success 200 {object} controllers.Deleted	"ok"
+// @Failure      400   {object}  problems.Problem  "problem"
+// @Failure      404   {object}  problems.Problem  "problem"
+// @Failure      500   {object}  problems.Problem  "problem"
+// @Failure      503   {object}  problems.Problem  "problem"
+// @Router /cats/{id} [delete]
 func CatsDelete(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
-			"message": "invalid id",
-		})
+		abortInvalidID(c)
 		return
 	}
 
 	if err := catsService.Delete(c.Request.Context(), id); err != nil {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
-			"message": "there was an error",
-		})
+		abortWithError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"deleted": id.String(),
-	})
+	c.JSON(http.StatusOK, Deleted{Deleted: id.String()})
 }
 
+// @Summary Counts the cats in the database
+// @Description count cats
+// @Produce  json
+// @Success 200 {object} controllers.Count	"ok"
+// @Failure      500   {object}  problems.Problem  "problem"
+// @Failure      503   {object}  problems.Problem  "problem"
+// @Router /cats/count [post]
 func CatsCount(c *gin.Context) {
-
+	count, err := catsService.Count(c.Request.Context(), nil)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, Count{Count: count})
 }
 
 // @Summary Gets all the cats in the database
-// @Description get a list of cats
+// @Description get a list of cats, optionally filtered by status
 // @Produce  json
-// @Success 200 {object} []models.Cat	"ok"
-// @Failure      400   {string}   string  "ok"
-// @Failure      404   {string}   string  "ok"
-// @Failure      500   {string}   string  "ok"
+// @Param        status  query  string  false  "Status"  Enums(intake, available, on_hold, adopted, returned)
+// @Success 200 {array} models.Cat	"ok"
+// @Failure      400   {object}  problems.Problem  "problem"
+// @Failure      500   {object}  problems.Problem  "problem"
+// @Failure      503   {object}  problems.Problem  "problem"
 // @Router /cats [get]
 func CatsGet(c *gin.Context) {
-	cats, err := catsService.Get(c.Request.Context(), nil)
+	filter, ok := statusFilter(c)
+	if !ok {
+		return
+	}
+
+	cats, err := catsService.Get(c.Request.Context(), filter)
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
-			"message": "there was an error",
-		})
+		abortWithError(c, err)
 		return
 	}
+	setCacheControl(c)
 	c.JSON(http.StatusOK, cats)
 }
 
 // @Summary Gets a cat by ID
 // @Description get a cat
 // @Produce  json
-// @Param        cat_id    path      string     true  "Cat ID"
+// @Param        id        path      string     true  "Cat ID"
 // @Success 200 {object} models.Cat	"ok"
-// @Failure      400   {string}   string  "ok"
-// @Failure      404   {string}   string  "ok"
-// @Failure      500   {string}   string  "ok"
-// @Router /cats/{cat_id} [get]
+// @Failure      400   {object}  problems.Problem  "problem"
+// @Failure      404   {object}  problems.Problem  "problem"
+// @Failure      500   {object}  problems.Problem  "problem"
+// @Failure      503   {object}  problems.Problem  "problem"
+// @Router /cats/{id} [get]
 func CatsGetOne(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
-			"message": "invalid id",
-		})
+		abortInvalidID(c)
 		return
 	}
 
 	cat, err := catsService.GetOne(c.Request.Context(), id)
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
-			"message": "there was an error",
-		})
+		abortWithError(c, err)
 		return
 	}
+	setCacheControl(c)
 	c.JSON(http.StatusOK, cat)
 }
 
@@ -94,17 +106,22 @@ func CatsGetOne(c *gin.Context) {
 // @Accept   json
 // @Produce  json
 // @Param        message  body      models.Cat  true  "Cat"
-// @Success      204   {string}  string  "answer"
-// @Failure      400   {string}   string  "ok"
-// @Failure      404   {string}   string  "ok"
-// @Failure      500   {string}   string  "ok"
+// @Param        Prefer   header    string      false  "return=minimal for an empty response"
+// @Param        Idempotency-Key  header  string  false  "replays the first response to requests with the same key"
+// @Success      201   {object}  models.Cat  "created"
+// @Success      204   "created, Prefer: return=minimal"
+// @Header       201,204  {string}  Location  "URL of the new cat"
+// @Failure      400   {object}  problems.Problem  "problem"
+// @Failure      409   {object}  problems.Problem  "problem"
+// @Failure      422   {object}  problems.Problem  "problem"
+// @Failure      500   {object}  problems.Problem  "problem"
+// @Failure      503   {object}  problems.Problem  "problem"
 // @Router /cats [post]
 func CatsPost(c *gin.Context) {
 	cat := new(models.Cat)
-	if c.ShouldBind(cat) != nil {
-		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
-			"message": "Bad request body",
-		})
+	if err := bind(c, cat); err != nil {
+		c.Error(err)
+		problems.Abort(c, problems.Binding(err))
 		return
 	}
 
@@ -114,51 +131,44 @@ func CatsPost(c *gin.Context) {
 
 	id, err := catsService.Add(c.Request.Context(), cat)
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
-			"message": "there was an error",
-		})
+		abortWithError(c, err)
 		return
 	}
-	c.JSON(http.StatusCreated, gin.H{
-		"message": fmt.Sprintf("id %s created", id.String()),
-	})
+	c.Header("Location", fmt.Sprintf("/cats/%s", id.String()))
+	respond(c, http.StatusCreated, cat)
 }
 
 // @Summary Updates a cat by ID
 // @Description updates a cat
 // @Accept   json
 // @Produce  json
-// @Param        cat_id    path      string     true  "Cat ID"
+// @Param        id        path      string     true  "Cat ID"
 // @Param        message  body      models.Cat  true  "Cat"
-// @Success      204   {string}  string  "answer"
-// @Failure      400   {string}   string  "ok"
-// @Failure      404   {string}   string  "ok"
-// @Failure      500   {string}   string  "ok"
-// @Router /cats/{cat_id} [put]
+// @Param        Prefer   header    string      false  "return=minimal for an empty response"
+// @Success      200   {object}  models.Cat  "updated"
+// @Success      204   "updated, Prefer: return=minimal"
+// @Failure      400   {object}  problems.Problem  "problem"
+// @Failure      404   {object}  problems.Problem  "problem"
+// @Failure      500   {object}  problems.Problem  "problem"
+// @Failure      503   {object}  problems.Problem  "problem"
+// @Router /cats/{id} [put]
 func CatsPut(c *gin.Context) {
 	cat := new(models.Cat)
-	if c.ShouldBind(&cat) != nil {
-		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
-			"message": "Bad request body",
-		})
+	if err := bind(c, cat); err != nil {
+		c.Error(err)
+		problems.Abort(c, problems.Binding(err))
 		return
 	}
 
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
-			"message": "invalid id",
-		})
+		abortInvalidID(c)
 		return
 	}
 
 	if err := catsService.Update(c.Request.Context(), id, cat); err != nil {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
-			"message": "there was an error",
-		})
+		abortWithError(c, err)
 		return
 	}
-	c.JSON(http.StatusAccepted, gin.H{
-		"message": fmt.Sprintf("id %s updated", id.String()),
-	})
+	respond(c, http.StatusOK, cat)
 }
@@ -17,7 +17,9 @@ import (
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/google/uuid"
 	"github.com/one-byte-data/go-api-sample/cmd/tests"
+	"github.com/one-byte-data/go-api-sample/internal/contexts"
 	"github.com/one-byte-data/go-api-sample/internal/models"
+	"github.com/one-byte-data/go-api-sample/internal/repositories"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
@@ -26,7 +28,10 @@ func BenchmarkCatInserts(b *testing.B) {
 	teardownTests := tests.SetupTests(b, postgres.Open(tests.ConnectionString))
 	defer teardownTests(b)
 
-	router, err := SetupRouter(tests.DB)
+	// A rate of zero disables the write limit so it doesn't skew the benchmark.
+	b.Setenv("RATE_LIMIT_WRITE", "0:1")
+
+	router, err := SetupRouter(repositories.NewGorm(tests.DB))
 	if err != nil {
 		panic(err)
 	}
@@ -68,7 +73,7 @@ func TestCatsDelete(t *testing.T) {
 		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
 	}
 
-	router, err := SetupRouter(gdb)
+	router, err := SetupRouter(repositories.NewGorm(gdb))
 	if err != nil {
 		panic(err)
 	}
@@ -98,7 +103,9 @@ func TestCatsDelete(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mock.ExpectBegin()
-			mock.ExpectExec(`DELETE FROM "cats" WHERE`).WithArgs(sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(1, 1))
+			mock.ExpectQuery(`SELECT \* FROM "cats" WHERE`).WithArgs(sqlmock.AnyArg(), contexts.DefaultTenant).WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(testID, "Nacho"))
+			mock.ExpectExec(`DELETE FROM "cats" WHERE`).WithArgs(sqlmock.AnyArg(), contexts.DefaultTenant).WillReturnResult(sqlmock.NewResult(1, 1))
+			mock.ExpectExec(`INSERT INTO "audit_entries"`).WillReturnResult(sqlmock.NewResult(1, 1))
 			mock.ExpectCommit()
 
 			w := httptest.NewRecorder()
@@ -117,6 +124,126 @@ func TestCatsDelete(t *testing.T) {
 	}
 }
 
+func TestCatsPost(t *testing.T) {
+	t.Setenv("BREEDS_STRICT", "true")
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	gdb, err := gorm.Open(postgres.Dialector{
+		Config: &postgres.Config{Conn: db},
+	})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	router, err := SetupRouter(repositories.NewGorm(gdb))
+	if err != nil {
+		panic(err)
+	}
+
+	testID := uuid.New()
+	cat := &models.Cat{
+		ID:        testID,
+		Name:      "Nacho",
+		Breed:     "Tabby",
+		Color:     "Orange",
+		Birthdate: time.Date(2020, 2, 10, 0, 0, 0, 0, time.UTC),
+		Weight:    17,
+	}
+
+	type args struct {
+		body   interface{}
+		prefer string
+	}
+	tests := []struct {
+		name         string
+		args         args
+		wantBreeds   bool
+		wantInsert   bool
+		wantResponse string
+		wantCode     int
+		wantLocation string
+	}{
+		{
+			name:         "Should return the created cat",
+			args:         args{body: cat},
+			wantInsert:   true,
+			wantResponse: fmt.Sprintf("{\"id\":\"%s\",\"name\":\"Nacho\",\"breed\":\"Tabby\",\"color\":\"Orange\",\"birthdate\":\"2020-02-10T00:00:00Z\",\"weight\":17,\"breed_status\":\"known\",\"status\":\"intake\"}", testID.String()),
+			wantCode:     http.StatusCreated,
+			wantLocation: fmt.Sprintf("/cats/%s", testID.String()),
+		},
+		{
+			name:         "Should return no body when the client prefers a minimal response",
+			args:         args{body: cat, prefer: "respond-async, return=minimal"},
+			wantInsert:   true,
+			wantResponse: "",
+			wantCode:     http.StatusNoContent,
+			wantLocation: fmt.Sprintf("/cats/%s", testID.String()),
+		},
+		{
+			name:         "Should list the invalid fields",
+			args:         args{body: map[string]interface{}{"name": "Nacho", "weight": 200}},
+			wantResponse: "{\"field\":\"breed\",\"rule\":\"required\",\"message\":\"is required\"}",
+			wantCode:     http.StatusBadRequest,
+		},
+		{
+			name:         "Should not accept a breed missing from the catalogue",
+			args:         args{body: map[string]interface{}{"name": "Nacho", "breed": "Tabbby", "color": "Orange", "birthdate": "2020-02-10T00:00:00Z", "weight": 9}},
+			wantBreeds:   true,
+			wantResponse: "{\"field\":\"breed\",\"rule\":\"breed\",\"message\":\"must be a known breed\"}",
+			wantCode:     http.StatusBadRequest,
+		},
+		{
+			name:         "Should not accept a birthdate in the future",
+			args:         args{body: map[string]interface{}{"name": "Nacho", "breed": "Siamese", "color": "Seal/White", "birthdate": time.Now().AddDate(1, 0, 0), "weight": 9}},
+			wantResponse: "{\"field\":\"birthdate\",\"rule\":\"notfuture\",\"message\":\"must not be in the future\"}",
+			wantCode:     http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantBreeds || tt.wantInsert {
+				expectBreeds(mock, "Tabby")
+			}
+			if tt.wantInsert {
+				mock.ExpectBegin()
+				mock.ExpectExec(`INSERT INTO "cats"`).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(`INSERT INTO "audit_entries"`).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			}
+
+			data, _ := json.Marshal(tt.args.body)
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/cats", bytes.NewReader(data))
+			req.Header.Add("Content-type", "application/json")
+			if tt.args.prefer != "" {
+				req.Header.Add("Prefer", tt.args.prefer)
+			}
+			router.ServeHTTP(w, req)
+
+			if tt.wantCode != w.Code {
+				t.Errorf("CatsPost() error = %v, wantCode %v", w.Code, tt.wantCode)
+				return
+			}
+
+			if !strings.Contains(w.Body.String(), tt.wantResponse) || (tt.wantResponse == "" && w.Body.Len() > 0) {
+				t.Errorf("CatsPost() error = %v, wantResponse %v", w.Body.String(), tt.wantResponse)
+			}
+
+			if got := w.Header().Get("Location"); got != tt.wantLocation {
+				t.Errorf("CatsPost() Location = %v, want %v", got, tt.wantLocation)
+			}
+		})
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
 func TestIntegrationCatsDelete(t *testing.T) {
 	if m := flag.Lookup("test.run").Value.String(); m == "" || !regexp.MustCompile(m).MatchString(t.Name()) {
 		t.Skip("skipping as execution was not requested explicitly using go test -run")
@@ -125,7 +252,7 @@ func TestIntegrationCatsDelete(t *testing.T) {
 	teardownTests := tests.SetupTests(t, postgres.Open(tests.ConnectionString))
 	defer teardownTests(t)
 
-	router, err := SetupRouter(tests.DB)
+	router, err := SetupRouter(repositories.NewGorm(tests.DB))
 	if err != nil {
 		panic(err)
 	}
@@ -155,7 +282,7 @@ func TestIntegrationCatsDelete(t *testing.T) {
 				method:   "DELETE",
 				endpoint: fmt.Sprintf("/cats/%s", "not_a_valid_id"),
 			},
-			wantResponse: "{\"message\":\"invalid id\"}",
+			wantResponse: "\"code\":\"invalid_id\"",
 			wantCode:     http.StatusBadRequest,
 		},
 		{
@@ -164,8 +291,8 @@ func TestIntegrationCatsDelete(t *testing.T) {
 				method:   "DELETE",
 				endpoint: fmt.Sprintf("/cats/%s", uuid.New().String()),
 			},
-			wantResponse: "{\"message\":\"there was an error\"}",
-			wantCode:     http.StatusInternalServerError,
+			wantResponse: "\"code\":\"not_found\"",
+			wantCode:     http.StatusNotFound,
 		},
 	}
 	for _, tt := range tests {
@@ -178,7 +305,7 @@ func TestIntegrationCatsDelete(t *testing.T) {
 			return
 		}
 
-		if !reflect.DeepEqual(tt.wantResponse, w.Body.String()) {
+		if !strings.Contains(w.Body.String(), tt.wantResponse) {
 			t.Errorf("CatsDelete() error = %v, wantCode %v", w.Body.String(), tt.wantResponse)
 		}
 	}
@@ -192,7 +319,7 @@ func TestIntegrationCatsGet(t *testing.T) {
 	teardownTests := tests.SetupTests(t, postgres.Open(tests.ConnectionString))
 	defer teardownTests(t)
 
-	router, err := SetupRouter(tests.DB)
+	router, err := SetupRouter(repositories.NewGorm(tests.DB))
 	if err != nil {
 		panic(err)
 	}
@@ -248,7 +375,7 @@ func TestIntegrationCatsGetOne(t *testing.T) {
 	teardownTests := tests.SetupTests(t, postgres.Open(tests.ConnectionString))
 	defer teardownTests(t)
 
-	router, err := SetupRouter(tests.DB)
+	router, err := SetupRouter(repositories.NewGorm(tests.DB))
 	if err != nil {
 		panic(err)
 	}
@@ -315,7 +442,7 @@ func TestIntegrationCatsPost(t *testing.T) {
 	teardownTests := tests.SetupTests(t, postgres.Open(tests.ConnectionString))
 	defer teardownTests(t)
 
-	router, err := SetupRouter(tests.DB)
+	router, err := SetupRouter(repositories.NewGorm(tests.DB))
 	if err != nil {
 		panic(err)
 	}
@@ -344,7 +471,7 @@ func TestIntegrationCatsPost(t *testing.T) {
 					Weight:    5,
 				},
 			},
-			wantResponse: "created",
+			wantResponse: "\"name\":\"Fluffy\"",
 			wantCode:     http.StatusCreated,
 		},
 		{
@@ -411,7 +538,7 @@ func TestIntegrationCatsPut(t *testing.T) {
 	teardownTests := tests.SetupTests(t, postgres.Open(tests.ConnectionString))
 	defer teardownTests(t)
 
-	router, err := SetupRouter(tests.DB)
+	router, err := SetupRouter(repositories.NewGorm(tests.DB))
 	if err != nil {
 		panic(err)
 	}
@@ -440,8 +567,8 @@ func TestIntegrationCatsPut(t *testing.T) {
 					Weight:    20,
 				},
 			},
-			wantResponse: "updated",
-			wantCode:     http.StatusAccepted,
+			wantResponse: "\"name\":\"Nacho\"",
+			wantCode:     http.StatusOK,
 		},
 		{
 			name: "Should not update an invalid id",
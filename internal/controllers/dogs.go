@@ -7,86 +7,97 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/one-byte-data/go-api-sample/internal/models"
+	"github.com/one-byte-data/go-api-sample/internal/problems"
 )
 
 // @Summary Deletes a dog by ID
 // @Description deletes a dog
 // @Produce  json
-// @Param        dog_id    path      string     true  "Dog ID"
-// @Success      200   {object}   interface{}	"ok"
-// @Failure      400   {string}   string  "ok"
-// @Failure      404   {string}   string  "ok"
-// @Failure      500   {string}   string  "ok"
-// @Router /dogs/{dog_id} [delete]
+// @Param        id        path      string     true  "Dog ID"
+// @Success      200   {object}   controllers.Deleted	"ok"
+// @Failure      400   {object}  problems.Problem  "problem"
+// @Failure      404   {object}  problems.Problem  "problem"
+// @Failure      500   {object}  problems.Problem  "problem"
+// @Failure      503   {object}  problems.Problem  "problem"
+// @Router /dogs/{id} [delete]
 func DogsDelete(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
-			"message": "invalid id",
-		})
+		abortInvalidID(c)
 		return
 	}
 
 	if err := dogsService.Delete(c.Request.Context(), id); err != nil {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
-			"message": "there was an error",
-		})
+		abortWithError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"deleted": id.String(),
-	})
+	c.JSON(http.StatusOK, Deleted{Deleted: id.String()})
 }
 
+// @Summary Counts the dogs in the database
+// @Description count dogs
+// @Produce  json
+// @Success 200 {object} controllers.Count	"ok"
+// @Failure      500   {object}  problems.Problem  "problem"
+// @Failure      503   {object}  problems.Problem  "problem"
+// @Router /dogs/count [post]
 func DogsCount(c *gin.Context) {
-
+	count, err := dogsService.Count(c.Request.Context(), nil)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, Count{Count: count})
 }
 
 // @Summary Gets all the dogs in the database
-// @Description get a list of dogs
+// @Description get a list of dogs, optionally filtered by status
 // @Produce  json
-// @Success 200 {object} []models.Dog	"ok"
-// @Failure      400   {string}   string  "ok"
-// @Failure      404   {string}   string  "ok"
-// @Failure      500   {string}   string  "ok"
+// @Param        status  query  string  false  "Status"  Enums(intake, available, on_hold, adopted, returned)
+// @Success 200 {array} models.Dog	"ok"
+// @Failure      400   {object}  problems.Problem  "problem"
+// @Failure      500   {object}  problems.Problem  "problem"
+// @Failure      503   {object}  problems.Problem  "problem"
 // @Router /dogs [get]
 func DogsGet(c *gin.Context) {
-	dogs, err := dogsService.Get(c.Request.Context(), nil)
+	filter, ok := statusFilter(c)
+	if !ok {
+		return
+	}
+
+	dogs, err := dogsService.Get(c.Request.Context(), filter)
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
-			"message": "there was an error",
-		})
+		abortWithError(c, err)
 		return
 	}
+	setCacheControl(c)
 	c.JSON(http.StatusOK, dogs)
 }
 
 // @Summary Gets a dog by ID
 // @Description get a dog
 // @Produce  json
-// @Param        dog_id    path      string     true  "Dog ID"
+// @Param        id        path      string     true  "Dog ID"
 // @Success 200 {object} models.Dog	"ok"
-// @Failure      400   {string}   string  "ok"
-// @Failure      404   {string}   string  "ok"
-// @Failure      500   {string}   string  "ok"
-// @Router /dogs/{dog_id} [get]
+// @Failure      400   {object}  problems.Problem  "problem"
+// @Failure      404   {object}  problems.Problem  "problem"
+// @Failure      500   {object}  problems.Problem  "problem"
+// @Failure      503   {object}  problems.Problem  "problem"
+// @Router /dogs/{id} [get]
 func DogsGetOne(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
-			"message": "invalid id",
-		})
+		abortInvalidID(c)
 		return
 	}
 
 	dog, err := dogsService.GetOne(c.Request.Context(), id)
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
-			"message": "there was an error",
-		})
+		abortWithError(c, err)
 		return
 	}
+	setCacheControl(c)
 	c.JSON(http.StatusOK, dog)
 }
 
@@ -95,17 +106,22 @@ func DogsGetOne(c *gin.Context) {
 // @Accept   json
 // @Produce  json
 // @Param        message  body      models.Dog  true  "Dog"
-// @Success      204   {string}  string  "answer"
-// @Failure      400   {string}   string  "ok"
-// @Failure      404   {string}   string  "ok"
-// @Failure      500   {string}   string  "ok"
+// @Param        Prefer   header    string      false  "return=minimal for an empty response"
+// @Param        Idempotency-Key  header  string  false  "replays the first response to requests with the same key"
+// @Success      201   {object}  models.Dog  "created"
+// @Success      204   "created, Prefer: return=minimal"
+// @Header       201,204  {string}  Location  "URL of the new dog"
+// @Failure      400   {object}  problems.Problem  "problem"
+// @Failure      409   {object}  problems.Problem  "problem"
+// @Failure      422   {object}  problems.Problem  "problem"
+// @Failure      500   {object}  problems.Problem  "problem"
+// @Failure      503   {object}  problems.Problem  "problem"
 // @Router /dogs [post]
 func DogsPost(c *gin.Context) {
 	dog := new(models.Dog)
-	if c.ShouldBind(dog) != nil {
-		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
-			"message": "Bad request body",
-		})
+	if err := bind(c, dog); err != nil {
+		c.Error(err)
+		problems.Abort(c, problems.Binding(err))
 		return
 	}
 
@@ -115,51 +131,44 @@ func DogsPost(c *gin.Context) {
 
 	id, err := dogsService.Add(c.Request.Context(), dog)
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
-			"message": "there was an error",
-		})
+		abortWithError(c, err)
 		return
 	}
-	c.JSON(http.StatusCreated, gin.H{
-		"message": fmt.Sprintf("id %s created", id.String()),
-	})
+	c.Header("Location", fmt.Sprintf("/dogs/%s", id.String()))
+	respond(c, http.StatusCreated, dog)
 }
 
 // @Summary Updates a dog by ID
 // @Description updates a dog
 // @Accept   json
 // @Produce  json
-// @Param        dog_id    path      string     true  "Dog ID"
+// @Param        id        path      string     true  "Dog ID"
 // @Param        message  body      models.Dog  true  "Dog"
-// @Success      204   {string}  string  "answer"
-// @Failure      400   {string}   string  "ok"
-// @Failure      404   {string}   string  "ok"
-// @Failure      500   {string}   string  "ok"
-// @Router /dogs/{dog_id} [put]
+// @Param        Prefer   header    string      false  "return=minimal for an empty response"
+// @Success      200   {object}  models.Dog  "updated"
+// @Success      204   "updated, Prefer: return=minimal"
+// @Failure      400   {object}  problems.Problem  "problem"
+// @Failure      404   {object}  problems.Problem  "problem"
+// @Failure      500   {object}  problems.Problem  "problem"
+// @Failure      503   {object}  problems.Problem  "problem"
+// @Router /dogs/{id} [put]
 func DogsPut(c *gin.Context) {
 	dog := new(models.Dog)
-	if c.ShouldBind(&dog) != nil {
-		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
-			"message": "Bad request body",
-		})
+	if err := bind(c, dog); err != nil {
+		c.Error(err)
+		problems.Abort(c, problems.Binding(err))
 		return
 	}
 
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
-			"message": "invalid id",
-		})
+		abortInvalidID(c)
 		return
 	}
 
 	if err := dogsService.Update(c.Request.Context(), id, dog); err != nil {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
-			"message": "there was an error",
-		})
+		abortWithError(c, err)
 		return
 	}
-	c.JSON(http.StatusAccepted, gin.H{
-		"message": fmt.Sprintf("id %s updated", id.String()),
-	})
+	respond(c, http.StatusOK, dog)
 }
@@ -2,6 +2,7 @@ package controllers
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"flag"
 	"fmt"
@@ -14,18 +15,24 @@ import (
 	"testing"
 	"time"
 
+	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/one-byte-data/go-api-sample/cmd/tests"
 	"github.com/one-byte-data/go-api-sample/internal/models"
+	"github.com/one-byte-data/go-api-sample/internal/repositories"
 	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
 )
 
 func BenchmarkDogInserts(b *testing.B) {
 	teardownTests := tests.SetupTests(b, postgres.Open(tests.ConnectionString))
 	defer teardownTests(b)
 
-	router, err := SetupRouter(tests.DB)
+	// A rate of zero disables the write limit so it doesn't skew the benchmark.
+	b.Setenv("RATE_LIMIT_WRITE", "0:1")
+
+	router, err := SetupRouter(repositories.NewGorm(tests.DB))
 	if err != nil {
 		panic(err)
 	}
@@ -71,6 +78,181 @@ func TestDogsDelete(t *testing.T) {
 }
 
 
+func TestDogsPost(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	gdb, err := gorm.Open(postgres.Dialector{
+		Config: &postgres.Config{Conn: db},
+	})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	router, err := SetupRouter(repositories.NewGorm(gdb))
+	if err != nil {
+		panic(err)
+	}
+
+	testID := uuid.New()
+	dog := &models.Dog{
+		ID:        testID,
+		Name:      "Rex",
+		Breed:     "Boxer",
+		Color:     "Brown",
+		Birthdate: time.Date(2020, 2, 10, 0, 0, 0, 0, time.UTC),
+		Weight:    60,
+	}
+
+	type args struct {
+		body   interface{}
+		prefer string
+	}
+	tests := []struct {
+		name         string
+		args         args
+		wantInsert   bool
+		wantResponse string
+		wantCode     int
+		wantLocation string
+	}{
+		{
+			name:         "Should return the created dog",
+			args:         args{body: dog},
+			wantInsert:   true,
+			wantResponse: fmt.Sprintf("{\"id\":\"%s\",\"name\":\"Rex\",\"breed\":\"Boxer\",\"color\":\"Brown\",\"birthdate\":\"2020-02-10T00:00:00Z\",\"weight\":60,\"breed_status\":\"known\",\"status\":\"intake\"}", testID.String()),
+			wantCode:     http.StatusCreated,
+			wantLocation: fmt.Sprintf("/dogs/%s", testID.String()),
+		},
+		{
+			name:         "Should return no body when the client prefers a minimal response",
+			args:         args{body: dog, prefer: "return=minimal"},
+			wantInsert:   true,
+			wantResponse: "",
+			wantCode:     http.StatusNoContent,
+			wantLocation: fmt.Sprintf("/dogs/%s", testID.String()),
+		},
+		{
+			name:         "Should list the invalid fields",
+			args:         args{body: map[string]interface{}{"name": "Rex", "weight": 60}},
+			wantResponse: "{\"field\":\"breed\",\"rule\":\"required\",\"message\":\"is required\"}",
+			wantCode:     http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantInsert {
+				expectBreeds(mock, "Boxer")
+				mock.ExpectBegin()
+				mock.ExpectExec(`INSERT INTO "dogs"`).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(`INSERT INTO "audit_entries"`).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			}
+
+			data, _ := json.Marshal(tt.args.body)
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/dogs", bytes.NewReader(data))
+			req.Header.Add("Content-type", "application/json")
+			if tt.args.prefer != "" {
+				req.Header.Add("Prefer", tt.args.prefer)
+			}
+			router.ServeHTTP(w, req)
+
+			if tt.wantCode != w.Code {
+				t.Errorf("DogsPost() error = %v, wantCode %v", w.Code, tt.wantCode)
+				return
+			}
+
+			if !strings.Contains(w.Body.String(), tt.wantResponse) || (tt.wantResponse == "" && w.Body.Len() > 0) {
+				t.Errorf("DogsPost() error = %v, wantResponse %v", w.Body.String(), tt.wantResponse)
+			}
+
+			if got := w.Header().Get("Location"); got != tt.wantLocation {
+				t.Errorf("DogsPost() Location = %v, want %v", got, tt.wantLocation)
+			}
+		})
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestDogsPut(t *testing.T) {
+	repo := repositories.NewMemory()
+	router, err := SetupRouter(repo)
+	if err != nil {
+		panic(err)
+	}
+
+	testID := uuid.New()
+	err = repo.Dogs().Create(context.Background(), &models.Dog{
+		ID:        testID,
+		Name:      "Rex",
+		Breed:     "Boxer",
+		Color:     "Brown",
+		Birthdate: time.Date(2020, 2, 10, 0, 0, 0, 0, time.UTC),
+		Weight:    60,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type args struct {
+		id     string
+		body   string
+		prefer string
+	}
+	tests := []struct {
+		name         string
+		args         args
+		wantResponse string
+		wantCode     int
+	}{
+		{
+			name:         "Should return the updated dog",
+			args:         args{id: testID.String(), body: `{"name":"Max","breed":"Boxer","color":"Brown","birthdate":"2020-02-10T00:00:00Z","weight":62}`},
+			wantResponse: fmt.Sprintf("{\"id\":\"%s\",\"name\":\"Max\",\"breed\":\"Boxer\",\"color\":\"Brown\",\"birthdate\":\"2020-02-10T00:00:00Z\",\"weight\":62,\"breed_status\":\"known\",\"status\":\"intake\"}", testID.String()),
+			wantCode:     http.StatusOK,
+		},
+		{
+			name:         "Should return no body when the client prefers a minimal response",
+			args:         args{id: testID.String(), body: `{"name":"Max","breed":"Boxer","color":"Brown","birthdate":"2020-02-10T00:00:00Z","weight":63}`, prefer: "return=minimal"},
+			wantResponse: "",
+			wantCode:     http.StatusNoContent,
+		},
+		{
+			name:         "Should not update a dog that doesn't exist",
+			args:         args{id: uuid.New().String(), body: `{"name":"Max","breed":"Boxer","color":"Brown","birthdate":"2020-02-10T00:00:00Z","weight":62}`},
+			wantResponse: "\"code\":\"not_found\"",
+			wantCode:     http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("PUT", fmt.Sprintf("/dogs/%s", tt.args.id), strings.NewReader(tt.args.body))
+			req.Header.Add("Content-type", "application/json")
+			if tt.args.prefer != "" {
+				req.Header.Add("Prefer", tt.args.prefer)
+			}
+			router.ServeHTTP(w, req)
+
+			if tt.wantCode != w.Code {
+				t.Errorf("DogsPut() error = %v, wantCode %v", w.Code, tt.wantCode)
+				return
+			}
+
+			if !strings.Contains(w.Body.String(), tt.wantResponse) || (tt.wantResponse == "" && w.Body.Len() > 0) {
+				t.Errorf("DogsPut() error = %v, wantResponse %v", w.Body.String(), tt.wantResponse)
+			}
+		})
+	}
+}
+
 func TestIntegrationDogsDelete(t *testing.T) {
 	if m := flag.Lookup("test.run").Value.String(); m == "" || !regexp.MustCompile(m).MatchString(t.Name()) {
 		t.Skip("skipping as execution was not requested explicitly using go test -run")
@@ -79,7 +261,7 @@ func TestIntegrationDogsDelete(t *testing.T) {
 	teardownTests := tests.SetupTests(t, postgres.Open(tests.ConnectionString))
 	defer teardownTests(t)
 
-	router, err := SetupRouter(tests.DB)
+	router, err := SetupRouter(repositories.NewGorm(tests.DB))
 	if err != nil {
 		panic(err)
 	}
@@ -109,7 +291,7 @@ func TestIntegrationDogsDelete(t *testing.T) {
 				method:   "DELETE",
 				endpoint: fmt.Sprintf("/dogs/%s", "not_a_valid_id"),
 			},
-			wantResponse: "{\"message\":\"invalid id\"}",
+			wantResponse: "\"code\":\"invalid_id\"",
 			wantCode:     http.StatusBadRequest,
 		},
 		{
@@ -118,8 +300,8 @@ func TestIntegrationDogsDelete(t *testing.T) {
 				method:   "DELETE",
 				endpoint: fmt.Sprintf("/dogs/%s", uuid.New().String()),
 			},
-			wantResponse: "{\"message\":\"there was an error\"}",
-			wantCode:     http.StatusInternalServerError,
+			wantResponse: "\"code\":\"not_found\"",
+			wantCode:     http.StatusNotFound,
 		},
 	}
 	for _, tt := range tests {
@@ -132,7 +314,7 @@ func TestIntegrationDogsDelete(t *testing.T) {
 			return
 		}
 
-		if !reflect.DeepEqual(tt.wantResponse, w.Body.String()) {
+		if !strings.Contains(w.Body.String(), tt.wantResponse) {
 			t.Errorf("DogsDelete() error = %v, wantCode %v", w.Body.String(), tt.wantResponse)
 		}
 	}
@@ -146,7 +328,7 @@ func TestIntegrationDogsGet(t *testing.T) {
 	teardownTests := tests.SetupTests(t, postgres.Open(tests.ConnectionString))
 	defer teardownTests(t)
 
-	router, err := SetupRouter(tests.DB)
+	router, err := SetupRouter(repositories.NewGorm(tests.DB))
 	if err != nil {
 		panic(err)
 	}
@@ -202,7 +384,7 @@ func TestIntegrationDogsGetOne(t *testing.T) {
 	teardownTests := tests.SetupTests(t, postgres.Open(tests.ConnectionString))
 	defer teardownTests(t)
 
-	router, err := SetupRouter(tests.DB)
+	router, err := SetupRouter(repositories.NewGorm(tests.DB))
 	if err != nil {
 		panic(err)
 	}
@@ -269,7 +451,7 @@ func TestIntegrationDogsPost(t *testing.T) {
 	teardownTests := tests.SetupTests(t, postgres.Open(tests.ConnectionString))
 	defer teardownTests(t)
 
-	router, err := SetupRouter(tests.DB)
+	router, err := SetupRouter(repositories.NewGorm(tests.DB))
 	if err != nil {
 		panic(err)
 	}
@@ -298,7 +480,7 @@ func TestIntegrationDogsPost(t *testing.T) {
 					Weight:    55,
 				},
 			},
-			wantResponse: "created",
+			wantResponse: "\"name\":\"Spike\"",
 			wantCode:     http.StatusCreated,
 		},
 		{
@@ -365,7 +547,7 @@ func TestIntegrationDogsPut(t *testing.T) {
 	teardownTests := tests.SetupTests(t, postgres.Open(tests.ConnectionString))
 	defer teardownTests(t)
 
-	router, err := SetupRouter(tests.DB)
+	router, err := SetupRouter(repositories.NewGorm(tests.DB))
 	if err != nil {
 		panic(err)
 	}
@@ -388,14 +570,14 @@ func TestIntegrationDogsPut(t *testing.T) {
 				endpoint: fmt.Sprintf("/dogs/%s", tests.Dogs[0].ID.String()),
 				body: &models.Dog{
 					Name:      "0111",
-					Breed:     "Pitbull Nix",
+					Breed:     "Pitbull Mix",
 					Color:     "White/Brindle",
 					Birthdate: time.Date(2020, 2, 10, 0, 0, 0, 0, time.UTC),
 					Weight:    65,
 				},
 			},
-			wantResponse: "updated",
-			wantCode:     http.StatusAccepted,
+			wantResponse: "\"name\":\"0111\"",
+			wantCode:     http.StatusOK,
 		},
 		{
 			name: "Should not update an invalid id",
@@ -404,7 +586,7 @@ func TestIntegrationDogsPut(t *testing.T) {
 				endpoint: fmt.Sprintf("/dogs/%s", "invalid_id_here"),
 				body: &models.Dog{
 					Name:      "0111",
-					Breed:     "Pitbull Nix",
+					Breed:     "Pitbull Mix",
 					Color:     "White/Brindle",
 					Birthdate: time.Date(2020, 2, 10, 0, 0, 0, 0, time.UTC),
 					Weight:    65,
@@ -420,7 +602,7 @@ func TestIntegrationDogsPut(t *testing.T) {
 				endpoint: fmt.Sprintf("/dogs/%s", tests.Dogs[0].ID.String()),
 				body: &models.Dog{
 					Name:      "0111",
-					Breed:     "Pitbull Nix",
+					Breed:     "Pitbull Mix",
 					Color:     "",
 					Birthdate: time.Date(2020, 2, 10, 0, 0, 0, 0, time.UTC),
 					Weight:    65,
@@ -436,7 +618,7 @@ func TestIntegrationDogsPut(t *testing.T) {
 				endpoint: fmt.Sprintf("/dogs/%s", tests.Dogs[0].ID.String()),
 				body: &models.Dog{
 					Name:      "0111",
-					Breed:     "Pitbull Nix",
+					Breed:     "Pitbull Mix",
 					Color:     "White/Brindle",
 					Birthdate: time.Date(2020, 2, 10, 0, 0, 0, 0, time.UTC),
 					Weight:    301,
@@ -0,0 +1,57 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/one-byte-data/go-api-sample/internal/problems"
+	"github.com/one-byte-data/go-api-sample/internal/services"
+)
+
+// unavailableRetryAfter is how many seconds clients are asked to wait
+// before retrying while the database is unavailable.
+const unavailableRetryAfter = "5"
+
+// abortWithError answers 404 for rows that don't exist, 400 for invalid
+// records and tenants, 409 for conflicting changes and status transitions
+// the state machine does not allow, 503 while the database
+// is unavailable and 500 for everything else.
+func abortWithError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrNotFound):
+		c.Error(err)
+		problems.Abort(c, problems.New(http.StatusNotFound, problems.CodeNotFound, "the requested resource does not exist"))
+	case errors.Is(err, services.ErrInvalid):
+		c.Error(err)
+		problems.Abort(c, problems.Binding(err))
+	case errors.Is(err, services.ErrInvalidTenant):
+		c.Error(err)
+		problems.Abort(c, problems.New(http.StatusBadRequest, problems.CodeInvalidTenant, "the tenant is not valid or does not exist"))
+	case errors.Is(err, services.ErrInvalidTransition):
+		c.Error(err)
+		problems.Abort(c, problems.New(http.StatusConflict, problems.CodeInvalidTransition, "the status cannot change to the requested one from the current one"))
+	case errors.Is(err, services.ErrConflict):
+		c.Error(err)
+		problems.Abort(c, problems.New(http.StatusConflict, problems.CodeConflict, "the change conflicts with the stored data"))
+	case errors.Is(err, services.ErrUnavailable):
+		abortUnavailable(c, err)
+	default:
+		problems.Internal(c, err)
+	}
+}
+
+func abortUnavailable(c *gin.Context, err error) {
+	c.Error(err)
+	c.Header("Retry-After", unavailableRetryAfter)
+	problems.Abort(c, problems.New(http.StatusServiceUnavailable, problems.CodeUnavailable, "the database is unavailable, try again later"))
+}
+
+func abortInvalidID(c *gin.Context) {
+	problems.Abort(c, problems.New(http.StatusBadRequest, problems.CodeInvalidID, "invalid id"))
+}
+
+// NoRoute answers requests that match no route.
+func NoRoute(c *gin.Context) {
+	problems.Abort(c, problems.New(http.StatusNotFound, problems.CodeNotFound, "no route matches "+c.Request.Method+" "+c.Request.URL.Path))
+}
@@ -0,0 +1,29 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/one-byte-data/go-api-sample/internal/graphqlapi"
+	"github.com/one-byte-data/go-api-sample/internal/problems"
+)
+
+// @Summary Runs a GraphQL query or mutation
+// @Description runs a GraphQL query or mutation on cats and dogs. Errors while executing the query are listed in the response.
+// @Accept   json
+// @Produce  json
+// @Param        request  body      graphqlapi.Request  true  "GraphQL request"
+// @Success 200 {object} graphqlapi.Response	"ok"
+// @Failure      400   {object}  problems.Problem  "problem"
+// @Failure      500   {object}  problems.Problem  "problem"
+// @Router /graphql [post]
+func GraphQLPost(c *gin.Context) {
+	req := new(graphqlapi.Request)
+	if err := bind(c, req); err != nil {
+		c.Error(err)
+		problems.Abort(c, problems.Binding(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, graphqlAPI.Execute(c.Request.Context(), *req))
+}
@@ -0,0 +1,89 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/one-byte-data/go-api-sample/internal/contexts"
+	"github.com/one-byte-data/go-api-sample/internal/repositories"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestGraphQLPost(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	gdb, err := gorm.Open(postgres.Dialector{
+		Config: &postgres.Config{Conn: db},
+	})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	router, err := SetupRouter(repositories.NewGorm(gdb))
+	if err != nil {
+		panic(err)
+	}
+
+	tests := []struct {
+		name         string
+		body         string
+		mock         func()
+		wantResponse string
+		wantCode     int
+	}{
+		{
+			name: "Should count filtered cats",
+			body: `{"query": "query($breed: String) { cats(filter: {breed: $breed}) { totalCount } }", "variables": {"breed": "Tabby"}}`,
+			mock: func() {
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "cats" WHERE LOWER\(breed\) = \$1 AND "cats"."tenant_id" = \$2`).
+					WithArgs("tabby", contexts.DefaultTenant).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+			},
+			wantResponse: `{"data":{"cats":{"totalCount":2}}}`,
+			wantCode:     http.StatusOK,
+		},
+		{
+			name:         "Should list errors in the response",
+			body:         `{"query": "{ cat(id: \"nacho\") { name } }"}`,
+			wantResponse: `"code":"invalid_id"`,
+			wantCode:     http.StatusOK,
+		},
+		{
+			name:         "Should not run a request without a query",
+			body:         `{"variables": {}}`,
+			wantResponse: `"code":"invalid_body"`,
+			wantCode:     http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.mock != nil {
+				tt.mock()
+			}
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/graphql", strings.NewReader(tt.body))
+			req.Header.Add("Content-type", "application/json")
+			router.ServeHTTP(w, req)
+
+			if tt.wantCode != w.Code {
+				t.Errorf("GraphQLPost() error = %v, wantCode %v", w.Code, tt.wantCode)
+				return
+			}
+			if !strings.Contains(w.Body.String(), tt.wantResponse) {
+				t.Errorf("GraphQLPost() error = %v, wantResponse %v", w.Body.String(), tt.wantResponse)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -1,21 +1,38 @@
 package controllers
 
 import (
+	"context"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
+// readinessTimeout bounds how long a readiness probe waits for the database.
+const readinessTimeout = 2 * time.Second
+
 // @Summary Gets the status of the server
 // @Description gets the status of the server
 // @Produce  json
-// @Success      204   {string}  string  "answer"
-// @Failure      400   {string}   string  "ok"
-// @Failure      404   {string}   string  "ok"
-// @Failure      500   {string}   string  "ok"
+// @Success      200   {object}  controllers.Message  "ok"
 // @Router /health [get]
 func HealthGet(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{
-		"message": "ok",
-	})
-}
\ No newline at end of file
+	c.JSON(http.StatusOK, Message{Message: "ok"})
+}
+
+// @Summary Gets whether the server is ready to serve requests
+// @Description answers 503 while the database is unavailable, e.g. while the server waits for it to start
+// @Produce  json
+// @Success      200   {object}  controllers.Message  "ready"
+// @Failure      503   {object}  problems.Problem  "problem"
+// @Router /health/ready [get]
+func HealthReadyGet(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), readinessTimeout)
+	defer cancel()
+
+	if err := repository.Ping(ctx); err != nil {
+		abortUnavailable(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, Message{Message: "ready"})
+}
@@ -1,14 +1,18 @@
 package controllers
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
 	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/one-byte-data/go-api-sample/cmd/tests"
+	"github.com/one-byte-data/go-api-sample/internal/repositories"
 	"gorm.io/driver/postgres"
 )
 
@@ -20,7 +24,7 @@ func TestIntegrationHealthGet(t *testing.T) {
 	teardownTests := tests.SetupTests(t, postgres.Open(tests.ConnectionString))
 	defer teardownTests(t)
 
-	router, err := SetupRouter(tests.DB)
+	router, err := SetupRouter(repositories.NewGorm(tests.DB))
 	if err != nil {
 		panic(err)
 	}
@@ -58,3 +62,74 @@ func TestIntegrationHealthGet(t *testing.T) {
 		}
 	}
 }
+
+func TestHealthReadyGet(t *testing.T) {
+	connect := errors.New("connection refused")
+	monitor := repositories.NewMonitor(repositories.NewMemory(), func(ctx context.Context) error {
+		return connect
+	})
+	router, err := SetupRouter(monitor)
+	if err != nil {
+		panic(err)
+	}
+
+	tests := []struct {
+		name       string
+		endpoint   string
+		connect    error
+		wantCode   int
+		wantBody   string
+		wantHeader string
+	}{
+		{
+			name:       "Should not be ready without the database",
+			endpoint:   "/health/ready",
+			connect:    connect,
+			wantCode:   http.StatusServiceUnavailable,
+			wantBody:   "\"code\":\"unavailable\"",
+			wantHeader: "5",
+		},
+		{
+			name:       "Should answer 503 without the database",
+			endpoint:   "/cats",
+			connect:    connect,
+			wantCode:   http.StatusServiceUnavailable,
+			wantBody:   "\"code\":\"unavailable\"",
+			wantHeader: "5",
+		},
+		{
+			name:     "Should be ready once the database is available",
+			endpoint: "/health/ready",
+			connect:  nil,
+			wantCode: http.StatusOK,
+			wantBody: "{\"message\":\"ready\"}",
+		},
+		{
+			name:     "Should serve requests once the database is available",
+			endpoint: "/cats",
+			connect:  nil,
+			wantCode: http.StatusOK,
+			wantBody: "[]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			connect = tt.connect
+			monitor.Check(context.Background())
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", tt.endpoint, nil)
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Errorf("HealthReadyGet() error = %v, wantCode %v", w.Code, tt.wantCode)
+			}
+			if !strings.Contains(w.Body.String(), tt.wantBody) {
+				t.Errorf("HealthReadyGet() error = %v, wantBody %v", w.Body.String(), tt.wantBody)
+			}
+			if got := w.Header().Get("Retry-After"); got != tt.wantHeader {
+				t.Errorf("HealthReadyGet() Retry-After = %v, want %v", got, tt.wantHeader)
+			}
+		})
+	}
+}
@@ -1,52 +1,206 @@
 package controllers
 
 import (
-	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/one-byte-data/go-api-sample/internal/cache"
+	"github.com/one-byte-data/go-api-sample/internal/graphqlapi"
+	"github.com/one-byte-data/go-api-sample/internal/metrics"
 	"github.com/one-byte-data/go-api-sample/internal/middlewares"
+	"github.com/one-byte-data/go-api-sample/internal/models"
+	"github.com/one-byte-data/go-api-sample/internal/repositories"
 	"github.com/one-byte-data/go-api-sample/internal/services"
-
-	"gorm.io/gorm"
+	"github.com/one-byte-data/go-api-sample/internal/validation"
 )
 
+var repository repositories.Repository
 var catsService services.CatsService
 var dogsService services.DogsService
+var auditService services.AuditService
+var tenantsService services.TenantsService
+var breedsService services.BreedsService
+var applicationsService services.ApplicationsService
+var statsService services.StatsService
+var graphqlAPI *graphqlapi.API
+
+var cacheConfig cache.Config
+var caches []cache.Reporter
+
+// Services returns the cats, dogs and tenants services the router was set
+// up with, so other transports such as the gRPC API share the same
+// decorators and caches.
+func Services() (services.CatsService, services.DogsService, services.TenantsService) {
+	return catsService, dogsService, tenantsService
+}
+
+func SetupRouter(repo repositories.Repository) (*gin.Engine, error) {
+	var err error
+	if cacheConfig, err = cache.ConfigFromEnv(); err != nil {
+		return nil, err
+	}
+	rules, err := validation.RulesFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	validation.Use(rules)
+
+	repository = repo
+	catsService = services.NewCatsService(repo)
+	dogsService = services.NewDogsService(repo)
+	applicationsService = services.NewApplicationsService(repo)
+	statsService = services.NewStatsService(repo)
+	caches = make([]cache.Reporter, 0)
+	if cacheConfig.Enabled() {
+		cats := cache.New[uuid.UUID, models.Cat]("cats", cacheConfig)
+		catLists := cache.New[string, []models.Cat]("cat_lists", cacheConfig)
+		dogs := cache.New[uuid.UUID, models.Dog]("dogs", cacheConfig)
+		dogLists := cache.New[string, []models.Dog]("dog_lists", cacheConfig)
+		stats := cache.New[string, models.AnimalStats]("stats", cacheConfig)
+		caches = append(caches, cats, catLists, dogs, dogLists, stats)
+
+		catsService = services.NewCachedCatsService(catsService, cats, catLists)
+		dogsService = services.NewCachedDogsService(dogsService, dogs, dogLists)
+		applicationsService = services.NewCachedApplicationsService(applicationsService, cats, catLists, dogs, dogLists)
+		statsService = services.NewCachedStatsService(statsService, stats)
+	}
+	for _, c := range caches {
+		metrics.TrackCache(c)
+	}
 
-func SetupRouter(db *gorm.DB) (*gin.Engine, error) {
-	catsService = services.NewCatsService(db)
-	dogsService = services.NewDogsService(db)
+	catsService = services.NewTracedCatsService(catsService)
+	dogsService = services.NewTracedDogsService(dogsService)
+	auditService = services.NewTracedAuditService(services.NewAuditService(repo))
+	tenantsService = services.NewTracedTenantsService(services.NewTenantsService(repo))
+	breedsService = services.NewTracedBreedsService(services.NewBreedsService(repo))
+	applicationsService = services.NewTracedApplicationsService(applicationsService)
+	statsService = services.NewTracedStatsService(statsService)
 
-	router := gin.Default()
-	config := cors.DefaultConfig()
-	config.AllowAllOrigins = true
-	config.AllowHeaders = []string{"Content-Type", "Authorization"}
-	config.AllowCredentials = true
-	router.Use(cors.New(config))
+	graphqlConfig, err := graphqlapi.ConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	if graphqlAPI, err = graphqlapi.New(catsService, dogsService, auditService, graphqlConfig); err != nil {
+		return nil, err
+	}
+
+	router := gin.New()
+	if err := router.SetTrustedProxies(middlewares.TrustedProxiesFromEnv()); err != nil {
+		return nil, err
+	}
+	router.Use(middlewares.RequestID())
+	router.Use(middlewares.Logger())
+	router.Use(middlewares.Recovery())
+	router.Use(middlewares.Metrics())
+	router.Use(middlewares.Tracing())
+
+	corsConfig, err := middlewares.CORSConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	corsHandler, err := middlewares.CORS(corsConfig)
+	if err != nil {
+		return nil, err
+	}
+	router.Use(corsHandler)
 	router.Use(middlewares.ValidateHeader())
 
+	tenantCredentials, err := middlewares.TenantCredentialsFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	router.Use(middlewares.TenantAuth(tenantCredentials))
+
+	rateLimitConfig, err := middlewares.RateLimitConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	router.Use(middlewares.RateLimiter(middlewares.NewMemoryRateLimitStore(), rateLimitConfig))
+	router.Use(middlewares.Actor())
+	router.Use(middlewares.ReadYourWrites())
+
+	idempotencyTTL, err := middlewares.IdempotencyTTLFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	idempotent := middlewares.Idempotency(middlewares.NewMemoryIdempotencyStore(idempotencyTTL))
+
+	router.NoRoute(NoRoute)
+
+	router.GET("/openapi.json", OpenAPIGet)
+	router.GET("/metrics", gin.WrapH(metrics.Handler()))
+
 	health := router.Group("/health")
 	{
 		health.GET("", HealthGet)
+		health.GET("/ready", HealthReadyGet)
 	}
 
-	cats := router.Group("/cats")
+	router.GET("/cache/stats", CacheStatsGet)
+	router.POST("/graphql", TenantScope, GraphQLPost)
+
+	admin := middlewares.Admin(middlewares.AdminKeyFromEnv())
+	tenants := router.Group("/tenants", admin)
+	{
+		tenants.DELETE("/:id", TenantsDelete)
+		tenants.GET("", TenantsGet)
+		tenants.GET("/:id", TenantsGetOne)
+		tenants.POST("", TenantsPost)
+	}
+
+	breeds := router.Group("/breeds/:species")
+	{
+		breeds.DELETE("/:id", admin, BreedsDelete)
+		breeds.GET("", BreedsGet)
+		breeds.GET("/:id", BreedsGetOne)
+		breeds.POST("", admin, BreedsPost)
+		breeds.PUT("/:id", admin, BreedsPut)
+	}
+
+	audit := router.Group("/audit", TenantScope)
+	{
+		audit.GET("", AuditGet)
+	}
+
+	cats := router.Group("/cats", TenantScope)
 	{
 		cats.DELETE("/:id", CatsDelete)
 		cats.POST("/count", CatsCount)
 		cats.GET("", CatsGet)
 		cats.GET("/:id", CatsGetOne)
-		cats.POST("", CatsPost)
+		cats.GET("/:id/history", CatsHistory)
+		cats.POST("", idempotent, CatsPost)
 		cats.PUT("/:id", CatsPut)
+		cats.GET("/:id/transitions", CatsTransitionsGet)
+		cats.POST("/:id/transitions", CatsTransitionsPost)
 	}
 
-	dogs := router.Group("/dogs")
+	dogs := router.Group("/dogs", TenantScope)
 	{
 		dogs.DELETE("/:id", DogsDelete)
 		dogs.POST("/count", DogsCount)
 		dogs.GET("", DogsGet)
 		dogs.GET("/:id", DogsGetOne)
-		dogs.POST("", DogsPost)
+		dogs.GET("/:id/history", DogsHistory)
+		dogs.POST("", idempotent, DogsPost)
 		dogs.PUT("/:id", DogsPut)
+		dogs.GET("/:id/transitions", DogsTransitionsGet)
+		dogs.POST("/:id/transitions", DogsTransitionsPost)
+	}
+
+	applications := router.Group("/applications", TenantScope)
+	{
+		applications.DELETE("/:id", ApplicationsDelete)
+		applications.GET("", ApplicationsGet)
+		applications.GET("/:id", ApplicationsGetOne)
+		applications.POST("", ApplicationsPost)
+		applications.PUT("/:id", ApplicationsPut)
+		applications.POST("/:id/review", ApplicationsReview)
+	}
+
+	stats := router.Group("/stats", TenantScope)
+	{
+		stats.GET("/cats", StatsCatsGet)
+		stats.GET("/dogs", StatsDogsGet)
 	}
 
 	return router, nil
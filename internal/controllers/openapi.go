@@ -0,0 +1,520 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/one-byte-data/go-api-sample/internal/cache"
+	"github.com/one-byte-data/go-api-sample/internal/graphqlapi"
+	"github.com/one-byte-data/go-api-sample/internal/middlewares"
+	"github.com/one-byte-data/go-api-sample/internal/models"
+	"github.com/one-byte-data/go-api-sample/internal/openapi"
+	"github.com/one-byte-data/go-api-sample/internal/problems"
+)
+
+var openAPIJSON = sync.OnceValues(func() ([]byte, error) {
+	return json.Marshal(OpenAPIDocument())
+})
+
+// OpenAPIGet serves the OpenAPI 3.1 document of the API.
+func OpenAPIGet(c *gin.Context) {
+	data, err := openAPIJSON()
+	if err != nil {
+		problems.Internal(c, err)
+		return
+	}
+	c.Data(http.StatusOK, "application/json", data)
+}
+
+// OpenAPIDocument describes every route registered by SetupRouter. The
+// tests in openapi_test.go fail when it disagrees with the routes or with
+// the Swagger document swag generates into the docs package.
+func OpenAPIDocument() *openapi.Document {
+	doc := openapi.New(openapi.Info{
+		Title:       "Go API Sample",
+		Version:     "1.0",
+		Description: "This is a sample API in go. Every error is an RFC 7807 application/problem+json document.",
+		License: &openapi.License{
+			Name: "Apache 2.0",
+			URL:  "http://www.apache.org/licenses/LICENSE-2.0.html",
+		},
+	})
+	doc.Servers = []openapi.Server{{URL: "http://localhost:8080"}}
+	doc.Tags = []openapi.Tag{
+		{Name: "cats"},
+		{Name: "dogs"},
+		{Name: "audit", Description: "The log of every change to cats, dogs and adoption applications"},
+		{Name: "graphql", Description: "Cats and dogs over GraphQL"},
+		{Name: "tenants", Description: "The shelters sharing the deployment, managed with the admin API key"},
+		{Name: "breeds", Description: "The breed catalogue of each species, changed with the admin API key"},
+		{Name: "applications", Description: "Applications to adopt cats and dogs and their review"},
+		{Name: "stats", Description: "Counts, ages and weights of the cats and dogs, aggregated by the database"},
+		{Name: "operations", Description: "Health, metrics and diagnostics"},
+	}
+
+	doc.Components.SecuritySchemes["ApiKey"] = &openapi.SecurityScheme{
+		Type:        "apiKey",
+		In:          "header",
+		Name:        middlewares.APIKeyHeader,
+		Description: "Rate limits are keyed by the authenticated identity of the caller, or else by the real client IP address. The key scopes requests to the tenant it belongs to, and the tenant routes and changes to the breed catalogue require the admin API key.",
+	}
+	doc.Security = []openapi.SecurityRequirement{{}, {"ApiKey": {}}}
+
+	addComponents(doc)
+
+	doc.Add("GET", "/openapi.json", operation("getOpenAPI", "Gets this OpenAPI document", "operations", nil, map[int]*openapi.Response{
+		http.StatusOK: {Description: "ok", Content: openapi.JSON("application/json", &openapi.Schema{Type: "object"})},
+	}))
+	doc.Add("GET", "/metrics", operation("getMetrics", "Gets the Prometheus metrics", "operations", nil, map[int]*openapi.Response{
+		http.StatusOK: {Description: "ok", Content: openapi.JSON("text/plain", &openapi.Schema{Type: "string"})},
+	}))
+	doc.Add("GET", "/health", operation("getHealth", "Gets the status of the server", "operations", nil, map[int]*openapi.Response{
+		http.StatusOK: jsonResponse("ok", doc.Schema("Message", Message{})),
+	}))
+	ready := withDatabase(operation("getReadiness", "Gets whether the server is ready to serve requests", "operations", nil, map[int]*openapi.Response{
+		http.StatusOK: jsonResponse("ready", doc.Schema("Message", Message{})),
+	}))
+	ready.Description = "Answers 503 while the database is unavailable, e.g. while the server waits for it to start."
+	doc.Add("GET", "/health/ready", ready)
+	doc.Add("GET", "/cache/stats", operation("getCacheStats", "Gets the cache statistics", "operations", nil, map[int]*openapi.Response{
+		http.StatusOK: jsonResponse("ok", openapi.ArrayOf(doc.Schema("CacheStats", cache.Stats{}))),
+	}))
+
+	audit := operation("getAudit", "Gets the audit log", "audit", []*openapi.Parameter{
+		query("resource", "Resource name, cats, dogs or applications", &openapi.Schema{Type: "string", Enum: []string{"cats", "dogs", "applications"}}),
+		query("resource_id", "Resource ID", &openapi.Schema{Type: "string", Format: "uuid"}),
+		query("actor", "Actor", &openapi.Schema{Type: "string"}),
+		query("since", "Only entries at or after this time", &openapi.Schema{Type: "string", Format: "date-time"}),
+		query("until", "Only entries before this time", &openapi.Schema{Type: "string", Format: "date-time"}),
+	}, map[int]*openapi.Response{
+		http.StatusOK:         jsonResponse("ok", openapi.ArrayOf(doc.Schema("AuditEntry", models.AuditEntry{}))),
+		http.StatusBadRequest: openapi.ResponseRef("BadRequest"),
+	})
+	doc.Add("GET", "/audit", withTenant(withDatabase(audit)))
+
+	graphql := operation("graphql", "Runs a GraphQL query or mutation", "graphql", nil, map[int]*openapi.Response{
+		http.StatusOK:         jsonResponse("ok, errors while executing the query are listed in the response", doc.Schema("GraphQLResponse", graphqlapi.Response{})),
+		http.StatusBadRequest: openapi.ResponseRef("BadRequest"),
+	})
+	graphql.Description = "Queries and mutates cats and dogs. Queries deeper or more complex than the configured limits are rejected with the codes query_too_deep and query_too_complex."
+	graphql.RequestBody = &openapi.RequestBody{
+		Required: true,
+		Content:  openapi.JSON("application/json", doc.Schema("GraphQLRequest", graphqlapi.Request{})),
+	}
+	doc.Add("POST", "/graphql", withTenant(graphql))
+
+	addAnimal(doc, "cats", "cat", "Cat", doc.Schema("Cat", models.Cat{}))
+	addAnimal(doc, "dogs", "dog", "Dog", doc.Schema("Dog", models.Dog{}))
+	addTenants(doc)
+	addBreeds(doc)
+	addApplications(doc)
+	addStats(doc)
+
+	return doc
+}
+
+// addTenants describes the tenant administration routes.
+func addTenants(doc *openapi.Document) {
+	schema := doc.Schema("Tenant", models.Tenant{})
+	id := openapi.ParameterRef("TenantID")
+
+	doc.Add("GET", "/tenants", admin(operation("listTenants", "Gets all the tenants", "tenants", nil, map[int]*openapi.Response{
+		http.StatusOK: jsonResponse("ok", openapi.ArrayOf(schema)),
+	})))
+	doc.Add("GET", "/tenants/:id", admin(operation("getTenant", "Gets a tenant by ID", "tenants", []*openapi.Parameter{id}, map[int]*openapi.Response{
+		http.StatusOK:       jsonResponse("ok", schema),
+		http.StatusNotFound: openapi.ResponseRef("NotFound"),
+	})))
+
+	create := admin(operation("createTenant", "Adds a tenant", "tenants", nil, map[int]*openapi.Response{
+		http.StatusCreated: withHeaders(jsonResponse("created", schema), map[string]*openapi.Header{"Location": {
+			Description: "URL of the new tenant",
+			Schema:      &openapi.Schema{Type: "string", Format: "uri-reference"},
+		}}),
+		http.StatusBadRequest: openapi.ResponseRef("BadRequest"),
+		http.StatusConflict:   openapi.ResponseRef("Conflict"),
+	}))
+	create.Description = "IDs are 1 to 63 lowercase letters, digits and hyphens, and cannot start or end with a hyphen."
+	create.RequestBody = &openapi.RequestBody{
+		Required: true,
+		Content:  openapi.JSON("application/json", schema),
+	}
+	doc.Add("POST", "/tenants", create)
+
+	remove := admin(operation("deleteTenant", "Deletes a tenant by ID", "tenants", []*openapi.Parameter{id}, map[int]*openapi.Response{
+		http.StatusOK:       jsonResponse("ok", doc.Schema("Deleted", Deleted{})),
+		http.StatusNotFound: openapi.ResponseRef("NotFound"),
+		http.StatusConflict: openapi.ResponseRef("Conflict"),
+	}))
+	remove.Description = "Only tenants without cats or dogs can be deleted, and never the default tenant. Their audit log is kept."
+	doc.Add("DELETE", "/tenants/:id", remove)
+}
+
+// addBreeds describes the breed catalogue routes.
+func addBreeds(doc *openapi.Document) {
+	schema := doc.Schema("Breed", models.Breed{})
+	species := openapi.ParameterRef("Species")
+	id := openapi.ParameterRef("ID")
+	body := &openapi.RequestBody{
+		Required: true,
+		Content:  openapi.JSON("application/json", schema),
+	}
+
+	doc.Add("GET", "/breeds/:species", withDatabase(operation("listBreeds", "Gets the breeds of a species", "breeds", []*openapi.Parameter{species}, map[int]*openapi.Response{
+		http.StatusOK:       jsonResponse("ok", openapi.ArrayOf(schema)),
+		http.StatusNotFound: openapi.ResponseRef("NotFound"),
+	})))
+	doc.Add("GET", "/breeds/:species/:id", withDatabase(operation("getBreed", "Gets a breed by ID", "breeds", []*openapi.Parameter{species, id}, map[int]*openapi.Response{
+		http.StatusOK:         jsonResponse("ok", schema),
+		http.StatusBadRequest: openapi.ResponseRef("BadRequest"),
+		http.StatusNotFound:   openapi.ResponseRef("NotFound"),
+	})))
+
+	create := admin(withDatabase(operation("createBreed", "Adds a breed", "breeds", []*openapi.Parameter{species}, map[int]*openapi.Response{
+		http.StatusCreated: withHeaders(jsonResponse("created", schema), map[string]*openapi.Header{"Location": {
+			Description: "URL of the new breed",
+			Schema:      &openapi.Schema{Type: "string", Format: "uri-reference"},
+		}}),
+		http.StatusBadRequest: openapi.ResponseRef("BadRequest"),
+		http.StatusNotFound:   openapi.ResponseRef("NotFound"),
+		http.StatusConflict:   openapi.ResponseRef("Conflict"),
+	})))
+	create.Description = "The name and aliases must not name another breed of the species, regardless of case."
+	create.RequestBody = body
+	doc.Add("POST", "/breeds/:species", create)
+
+	update := admin(withDatabase(operation("updateBreed", "Updates a breed by ID", "breeds", []*openapi.Parameter{species, id}, map[int]*openapi.Response{
+		http.StatusOK:         jsonResponse("updated", schema),
+		http.StatusBadRequest: openapi.ResponseRef("BadRequest"),
+		http.StatusNotFound:   openapi.ResponseRef("NotFound"),
+		http.StatusConflict:   openapi.ResponseRef("Conflict"),
+	})))
+	update.Description = "Replaces the name, aliases and weight range. Cats and dogs keep the breed name they were stored with."
+	update.RequestBody = body
+	doc.Add("PUT", "/breeds/:species/:id", update)
+
+	doc.Add("DELETE", "/breeds/:species/:id", admin(withDatabase(operation("deleteBreed", "Deletes a breed by ID", "breeds", []*openapi.Parameter{species, id}, map[int]*openapi.Response{
+		http.StatusOK:         jsonResponse("ok", doc.Schema("Deleted", Deleted{})),
+		http.StatusBadRequest: openapi.ResponseRef("BadRequest"),
+		http.StatusNotFound:   openapi.ResponseRef("NotFound"),
+	}))))
+}
+
+// addApplications describes the adoption application routes.
+func addApplications(doc *openapi.Document) {
+	schema := doc.Schema("Application", models.Application{})
+	id := openapi.ParameterRef("ID")
+	actor := openapi.ParameterRef("Actor")
+	body := &openapi.RequestBody{
+		Required: true,
+		Content:  openapi.JSON("application/json", schema),
+	}
+
+	status := &openapi.Schema{Type: "string", Enum: []string{models.ApplicationSubmitted, models.ApplicationUnderReview, models.ApplicationApproved, models.ApplicationRejected}}
+	doc.Add("GET", "/applications", withTenant(withDatabase(operation("listApplications", "Gets the adoption applications", "applications", []*openapi.Parameter{
+		query("species", "Only the applications for cats or dogs", &openapi.Schema{Type: "string", Enum: []string{"cats", "dogs"}}),
+		query("animal_id", "Only the applications for this cat or dog", &openapi.Schema{Type: "string", Format: "uuid"}),
+		query("status", "Only the applications with this status", status),
+	}, map[int]*openapi.Response{
+		http.StatusOK:         jsonResponse("ok", openapi.ArrayOf(schema)),
+		http.StatusBadRequest: openapi.ResponseRef("BadRequest"),
+	}))))
+	doc.Add("GET", "/applications/:id", withTenant(withDatabase(operation("getApplication", "Gets an adoption application by ID", "applications", []*openapi.Parameter{id}, map[int]*openapi.Response{
+		http.StatusOK:         jsonResponse("ok", schema),
+		http.StatusBadRequest: openapi.ResponseRef("BadRequest"),
+		http.StatusNotFound:   openapi.ResponseRef("NotFound"),
+	}))))
+
+	create := operation("createApplication", "Submits an adoption application", "applications", []*openapi.Parameter{actor}, map[int]*openapi.Response{
+		http.StatusCreated: withHeaders(jsonResponse("created", schema), map[string]*openapi.Header{"Location": {
+			Description: "URL of the new application",
+			Schema:      &openapi.Schema{Type: "string", Format: "uri-reference"},
+		}}),
+		http.StatusBadRequest: openapi.ResponseRef("BadRequest"),
+		http.StatusNotFound:   openapi.ResponseRef("NotFound"),
+		http.StatusConflict:   openapi.ResponseRef("Conflict"),
+	})
+	create.Description = "The cat or dog must exist and not be adopted yet. New applications are submitted."
+	create.RequestBody = body
+	doc.Add("POST", "/applications", withTenant(withDatabase(create)))
+
+	update := operation("updateApplication", "Updates an adoption application by ID", "applications", []*openapi.Parameter{id, actor}, map[int]*openapi.Response{
+		http.StatusOK:         jsonResponse("updated", schema),
+		http.StatusBadRequest: openapi.ResponseRef("BadRequest"),
+		http.StatusNotFound:   openapi.ResponseRef("NotFound"),
+		http.StatusConflict:   openapi.ResponseRef("Conflict"),
+	})
+	update.Description = "Replaces the applicant details. The cat or dog, status and reviewer are kept, and approved or rejected applications answer 409."
+	update.RequestBody = body
+	doc.Add("PUT", "/applications/:id", withTenant(withDatabase(update)))
+
+	doc.Add("DELETE", "/applications/:id", withTenant(withDatabase(operation("deleteApplication", "Deletes an adoption application by ID", "applications", []*openapi.Parameter{id, actor}, map[int]*openapi.Response{
+		http.StatusOK:         jsonResponse("ok", doc.Schema("Deleted", Deleted{})),
+		http.StatusBadRequest: openapi.ResponseRef("BadRequest"),
+		http.StatusNotFound:   openapi.ResponseRef("NotFound"),
+	}))))
+
+	review := operation("reviewApplication", "Reviews an adoption application", "applications", []*openapi.Parameter{id, actor}, map[int]*openapi.Response{
+		http.StatusOK:         jsonResponse("reviewed", schema),
+		http.StatusBadRequest: openapi.ResponseRef("BadRequest"),
+		http.StatusNotFound:   openapi.ResponseRef("NotFound"),
+		http.StatusConflict:   openapi.ResponseRef("Conflict"),
+	})
+	review.Description = "Submitted applications move to under_review or rejected, and applications under review to approved or rejected. Other changes are rejected with 409 and the code invalid_transition. Approving an application marks the cat or dog adopted and rejects the other open applications for it in the same transaction."
+	review.RequestBody = &openapi.RequestBody{
+		Required: true,
+		Content:  openapi.JSON("application/json", doc.Schema("ReviewRequest", ReviewRequest{})),
+	}
+	doc.Add("POST", "/applications/:id/review", withTenant(withDatabase(review)))
+}
+
+// addStats describes the statistics routes.
+func addStats(doc *openapi.Document) {
+	schema := doc.Schema("AnimalStats", models.AnimalStats{})
+	status := &openapi.Schema{Type: "string", Enum: []string{models.StatusIntake, models.StatusAvailable, models.StatusOnHold, models.StatusAdopted, models.StatusReturned}}
+	cacheControl := map[string]*openapi.Header{"Cache-Control": openapi.HeaderRef("CacheControl")}
+	lightest := float64(1)
+
+	for _, species := range []struct{ resource, name string }{{"cats", "Cat"}, {"dogs", "Dog"}} {
+		op := operation("get"+species.name+"Stats", "Gets statistics about the "+species.resource, "stats", []*openapi.Parameter{
+			query("status", "Only the "+species.resource+" with this status", status),
+			query("breed", "Only the "+species.resource+" of this breed, ignoring case", &openapi.Schema{Type: "string"}),
+			query("color", "Only the "+species.resource+" of this color, ignoring case", &openapi.Schema{Type: "string"}),
+			query("min_weight", "Only the "+species.resource+" at least this heavy", &openapi.Schema{Type: "integer", Minimum: &lightest}),
+			query("max_weight", "Only the "+species.resource+" at most this heavy", &openapi.Schema{Type: "integer", Minimum: &lightest}),
+			query("born_after", "Only the "+species.resource+" born at or after this time", &openapi.Schema{Type: "string", Format: "date-time"}),
+			query("born_before", "Only the "+species.resource+" born before this time", &openapi.Schema{Type: "string", Format: "date-time"}),
+		}, map[int]*openapi.Response{
+			http.StatusOK:         withHeaders(jsonResponse("ok", schema), cacheControl),
+			http.StatusBadRequest: openapi.ResponseRef("BadRequest"),
+		})
+		op.Description = "Counts by breed and color, age buckets derived from the birthdate, and the mean, median and 25th, 75th and 90th nearest-rank percentiles of the weight per breed. Responses may be reused for the cache TTL."
+		doc.Add("GET", "/stats/"+species.resource, withTenant(withDatabase(op)))
+	}
+}
+
+// addAnimal describes the routes shared by cats and dogs.
+func addAnimal(doc *openapi.Document, resource string, singular string, name string, schema *openapi.Schema) {
+	prefix := "/" + resource
+	id := openapi.ParameterRef("ID")
+	minimal := openapi.ParameterRef("Prefer")
+	actor := openapi.ParameterRef("Actor")
+	body := &openapi.RequestBody{
+		Required: true,
+		Content:  openapi.JSON("application/json", schema),
+	}
+	cacheControl := map[string]*openapi.Header{"Cache-Control": openapi.HeaderRef("CacheControl")}
+
+	status := &openapi.Schema{Type: "string", Enum: []string{models.StatusIntake, models.StatusAvailable, models.StatusOnHold, models.StatusAdopted, models.StatusReturned}}
+	doc.Add("GET", prefix, withTenant(withDatabase(operation("list"+name+"s", "Gets all the "+resource, resource, []*openapi.Parameter{
+		query("status", "Only the "+resource+" with this status", status),
+	}, map[int]*openapi.Response{
+		http.StatusOK:         withHeaders(jsonResponse("ok", openapi.ArrayOf(schema)), cacheControl),
+		http.StatusBadRequest: openapi.ResponseRef("BadRequest"),
+	}))))
+	doc.Add("POST", prefix+"/count", withTenant(withDatabase(operation("count"+name+"s", "Counts the "+resource, resource, nil, map[int]*openapi.Response{
+		http.StatusOK: jsonResponse("ok", doc.Schema("Count", Count{})),
+	}))))
+	doc.Add("GET", prefix+"/:id", withTenant(withDatabase(operation("get"+name, "Gets a "+singular+" by ID", resource, []*openapi.Parameter{id}, map[int]*openapi.Response{
+		http.StatusOK:         withHeaders(jsonResponse("ok", schema), cacheControl),
+		http.StatusBadRequest: openapi.ResponseRef("BadRequest"),
+		http.StatusNotFound:   openapi.ResponseRef("NotFound"),
+	}))))
+	doc.Add("GET", prefix+"/:id/history", withTenant(withDatabase(operation("get"+name+"History", "Gets the change history of a "+singular, resource, []*openapi.Parameter{id}, map[int]*openapi.Response{
+		http.StatusOK:         jsonResponse("ok", openapi.ArrayOf(doc.Schema("AuditEntry", models.AuditEntry{}))),
+		http.StatusBadRequest: openapi.ResponseRef("BadRequest"),
+	}))))
+
+	transitionSchema := doc.Schema("Transition", models.Transition{})
+	doc.Add("GET", prefix+"/:id/transitions", withTenant(withDatabase(operation("get"+name+"Transitions", "Gets the status transitions of a "+singular, resource, []*openapi.Parameter{id}, map[int]*openapi.Response{
+		http.StatusOK:         jsonResponse("ok", openapi.ArrayOf(transitionSchema)),
+		http.StatusBadRequest: openapi.ResponseRef("BadRequest"),
+	}))))
+	transition := operation("transition"+name, "Changes the status of a "+singular, resource, []*openapi.Parameter{id, actor}, map[int]*openapi.Response{
+		http.StatusCreated:    jsonResponse("created", transitionSchema),
+		http.StatusBadRequest: openapi.ResponseRef("BadRequest"),
+		http.StatusNotFound:   openapi.ResponseRef("NotFound"),
+		http.StatusConflict:   openapi.ResponseRef("Conflict"),
+	})
+	transition.Description = "Statuses move from intake to available or on_hold, between available and on_hold, from either to adopted, from adopted to returned, and from returned to intake, available or on_hold. Other changes are rejected with 409 and the code invalid_transition."
+	transition.RequestBody = &openapi.RequestBody{
+		Required: true,
+		Content:  openapi.JSON("application/json", doc.Schema("TransitionRequest", TransitionRequest{})),
+	}
+	doc.Add("POST", prefix+"/:id/transitions", withTenant(withDatabase(transition)))
+
+	location := map[string]*openapi.Header{"Location": {
+		Description: "URL of the new " + singular,
+		Schema:      &openapi.Schema{Type: "string", Format: "uri-reference"},
+	}}
+	create := operation("create"+name, "Adds a "+singular, resource, []*openapi.Parameter{minimal, openapi.ParameterRef("IdempotencyKey"), actor}, map[int]*openapi.Response{
+		http.StatusCreated:             withHeaders(jsonResponse("created", schema), location),
+		http.StatusNoContent:           {Description: "created, the client sent Prefer: return=minimal", Headers: location},
+		http.StatusBadRequest:          openapi.ResponseRef("BadRequest"),
+		http.StatusConflict:            openapi.ResponseRef("Conflict"),
+		http.StatusUnprocessableEntity: openapi.ResponseRef("UnprocessableEntity"),
+	})
+	create.Description = "An ID is generated when the body has none. Requests repeated with the same Idempotency-Key replay the first response."
+	create.RequestBody = body
+	doc.Add("POST", prefix, withTenant(withDatabase(create)))
+
+	update := operation("update"+name, "Updates a "+singular+" by ID", resource, []*openapi.Parameter{id, minimal, actor}, map[int]*openapi.Response{
+		http.StatusOK:         jsonResponse("updated", schema),
+		http.StatusNoContent:  {Description: "updated, the client sent Prefer: return=minimal"},
+		http.StatusBadRequest: openapi.ResponseRef("BadRequest"),
+		http.StatusNotFound:   openapi.ResponseRef("NotFound"),
+	})
+	update.RequestBody = body
+	doc.Add("PUT", prefix+"/:id", withTenant(withDatabase(update)))
+
+	doc.Add("DELETE", prefix+"/:id", withTenant(withDatabase(operation("delete"+name, "Deletes a "+singular+" by ID", resource, []*openapi.Parameter{id, actor}, map[int]*openapi.Response{
+		http.StatusOK:         jsonResponse("ok", doc.Schema("Deleted", Deleted{})),
+		http.StatusBadRequest: openapi.ResponseRef("BadRequest"),
+		http.StatusNotFound:   openapi.ResponseRef("NotFound"),
+	}))))
+}
+
+// addComponents registers the parameters, headers and problem responses
+// the operations refer to.
+func addComponents(doc *openapi.Document) {
+	problem := doc.Schema("Problem", problems.Problem{})
+	requestID := openapi.HeaderRef("RequestID")
+
+	doc.Components.Parameters["ID"] = &openapi.Parameter{
+		Name: "id", In: "path", Required: true,
+		Schema: &openapi.Schema{Type: "string", Format: "uuid"},
+	}
+	doc.Components.Parameters["Prefer"] = &openapi.Parameter{
+		Name: "Prefer", In: "header",
+		Description: "return=minimal answers with an empty 204 No Content",
+		Schema:      &openapi.Schema{Type: "string"},
+	}
+	doc.Components.Parameters["IdempotencyKey"] = &openapi.Parameter{
+		Name: middlewares.IdempotencyKeyHeader, In: "header",
+		Description: "Replays the first response to requests with the same key",
+		Schema:      &openapi.Schema{Type: "string", MaxLength: intPtr(255)},
+	}
+	doc.Components.Parameters["Actor"] = &openapi.Parameter{
+		Name: middlewares.ActorHeader, In: "header",
+		Description: "Who made the change, recorded in the audit log",
+		Schema:      &openapi.Schema{Type: "string"},
+	}
+	doc.Components.Parameters["Tenant"] = &openapi.Parameter{
+		Name: middlewares.TenantHeader, In: "header",
+		Description: "The tenant the request is made for. It must match the tenant of the API key, and only the admin API key may name any tenant. Requests without an API key act for the default tenant.",
+		Schema:      &openapi.Schema{Type: "string", MaxLength: intPtr(63)},
+	}
+	doc.Components.Parameters["Species"] = &openapi.Parameter{
+		Name: "species", In: "path", Required: true,
+		Schema: &openapi.Schema{Type: "string", Enum: []string{"cats", "dogs"}},
+	}
+	doc.Components.Parameters["TenantID"] = &openapi.Parameter{
+		Name: "id", In: "path", Required: true,
+		Schema: &openapi.Schema{Type: "string", MaxLength: intPtr(63)},
+	}
+
+	doc.Components.Headers["RequestID"] = &openapi.Header{
+		Description: "The request ID sent by the client or generated by the server",
+		Schema:      &openapi.Schema{Type: "string"},
+	}
+	doc.Components.Headers["CacheControl"] = &openapi.Header{
+		Description: "How long the response may be reused",
+		Schema:      &openapi.Schema{Type: "string"},
+	}
+	doc.Components.Headers["RetryAfter"] = &openapi.Header{
+		Description: "Seconds to wait before retrying",
+		Schema:      &openapi.Schema{Type: "integer"},
+	}
+
+	for name, status := range map[string]int{
+		"BadRequest":          http.StatusBadRequest,
+		"Forbidden":           http.StatusForbidden,
+		"NotFound":            http.StatusNotFound,
+		"Conflict":            http.StatusConflict,
+		"UnprocessableEntity": http.StatusUnprocessableEntity,
+		"TooManyRequests":     http.StatusTooManyRequests,
+		"InternalServerError": http.StatusInternalServerError,
+		"ServiceUnavailable":  http.StatusServiceUnavailable,
+	} {
+		response := &openapi.Response{
+			Description: openapi.StatusText(status),
+			Headers:     map[string]*openapi.Header{"X-Request-ID": requestID},
+			Content:     openapi.JSON(problems.ContentType, problem),
+		}
+		if status == http.StatusTooManyRequests || status == http.StatusServiceUnavailable {
+			response.Headers["Retry-After"] = openapi.HeaderRef("RetryAfter")
+		}
+		doc.Components.Responses[name] = response
+	}
+}
+
+// operation adds the problems every route can answer with: 403 for
+// rejected headers and origins, 429 from the rate limiter and 500.
+func operation(id string, summary string, tag string, parameters []*openapi.Parameter, responses map[int]*openapi.Response) *openapi.Operation {
+	op := &openapi.Operation{
+		OperationID: id,
+		Summary:     summary,
+		Tags:        []string{tag},
+		Parameters:  parameters,
+		Responses: map[string]*openapi.Response{
+			openapi.StatusKey(http.StatusForbidden):           openapi.ResponseRef("Forbidden"),
+			openapi.StatusKey(http.StatusTooManyRequests):     openapi.ResponseRef("TooManyRequests"),
+			openapi.StatusKey(http.StatusInternalServerError): openapi.ResponseRef("InternalServerError"),
+		},
+	}
+	for status, response := range responses {
+		if response.Ref == "" {
+			if response.Headers == nil {
+				response.Headers = make(map[string]*openapi.Header)
+			}
+			response.Headers["X-Request-ID"] = openapi.HeaderRef("RequestID")
+		}
+		op.Responses[openapi.StatusKey(status)] = response
+	}
+	return op
+}
+
+// withDatabase adds the 503 answered while the database is unavailable.
+func withDatabase(op *openapi.Operation) *openapi.Operation {
+	op.Responses[openapi.StatusKey(http.StatusServiceUnavailable)] = openapi.ResponseRef("ServiceUnavailable")
+	return op
+}
+
+// withTenant adds the X-Tenant-ID header and the 400 answered for invalid
+// or unknown tenants.
+func withTenant(op *openapi.Operation) *openapi.Operation {
+	op.Parameters = append(op.Parameters, openapi.ParameterRef("Tenant"))
+	op.Responses[openapi.StatusKey(http.StatusBadRequest)] = openapi.ResponseRef("BadRequest")
+	return op
+}
+
+// admin marks an operation as requiring the admin API key.
+func admin(op *openapi.Operation) *openapi.Operation {
+	op.Security = []openapi.SecurityRequirement{{"ApiKey": {}}}
+	return op
+}
+
+func jsonResponse(description string, schema *openapi.Schema) *openapi.Response {
+	return &openapi.Response{
+		Description: description,
+		Content:     openapi.JSON("application/json", schema),
+	}
+}
+
+func withHeaders(response *openapi.Response, headers map[string]*openapi.Header) *openapi.Response {
+	response.Headers = make(map[string]*openapi.Header, len(headers))
+	for name, header := range headers {
+		response.Headers[name] = header
+	}
+	return response
+}
+
+func query(name string, description string, schema *openapi.Schema) *openapi.Parameter {
+	return &openapi.Parameter{Name: name, In: "query", Description: description, Schema: schema}
+}
+
+func intPtr(i int) *int {
+	return &i
+}
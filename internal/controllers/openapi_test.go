@@ -0,0 +1,255 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/one-byte-data/go-api-sample/docs"
+	"github.com/one-byte-data/go-api-sample/internal/openapi"
+	"github.com/one-byte-data/go-api-sample/internal/repositories"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// TestOpenAPIGet fails when the served document and the routes registered
+// by SetupRouter drift apart.
+func TestOpenAPIGet(t *testing.T) {
+	db, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	gdb, err := gorm.Open(postgres.Dialector{
+		Config: &postgres.Config{Conn: db},
+	})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	router, err := SetupRouter(repositories.NewGorm(gdb))
+	if err != nil {
+		panic(err)
+	}
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/openapi.json", nil)
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("OpenAPIGet() error = %v, wantCode %v", w.Code, http.StatusOK)
+	}
+
+	var doc struct {
+		OpenAPI    string                                `json:"openapi"`
+		Paths      map[string]map[string]json.RawMessage `json:"paths"`
+		Components map[string]map[string]json.RawMessage `json:"components"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("OpenAPIGet() returned invalid JSON: %v", err)
+	}
+	if doc.OpenAPI != openapi.Version {
+		t.Errorf("OpenAPIGet() openapi = %v, want %v", doc.OpenAPI, openapi.Version)
+	}
+
+	documented := make(map[string]bool)
+	for path, item := range doc.Paths {
+		for method := range item {
+			documented[strings.ToUpper(method)+" "+path] = true
+		}
+	}
+	registered := make(map[string]bool)
+	for _, route := range router.Routes() {
+		registered[route.Method+" "+openapi.Path(route.Path)] = true
+	}
+
+	for _, route := range difference(registered, documented) {
+		t.Errorf("OpenAPIGet() does not document %s", route)
+	}
+	for _, route := range difference(documented, registered) {
+		t.Errorf("OpenAPIGet() documents %s, which is not registered", route)
+	}
+
+	for _, ref := range refs(w.Body.Bytes()) {
+		parts := strings.Split(strings.TrimPrefix(ref, "#/components/"), "/")
+		if len(parts) != 2 || doc.Components[parts[0]][parts[1]] == nil {
+			t.Errorf("OpenAPIGet() refers to %s, which does not exist", ref)
+		}
+	}
+}
+
+// notInSwagger lists the operations without swag annotations: this
+// document itself and the Prometheus handler.
+var notInSwagger = map[string]bool{
+	"GET /openapi.json": true,
+	"GET /metrics":      true,
+}
+
+// swaggerSchemas maps the definitions swag names after another type than
+// the OpenAPI document does. Other definitions drop their package, e.g.
+// models.Cat is Cat.
+var swaggerSchemas = map[string]string{
+	"cache.Stats":         "CacheStats",
+	"graphqlapi.Request":  "GraphQLRequest",
+	"graphqlapi.Response": "GraphQLResponse",
+}
+
+type specParameter struct {
+	Ref  string `json:"$ref"`
+	Name string `json:"name"`
+	In   string `json:"in"`
+}
+
+type specSchema struct {
+	Properties map[string]json.RawMessage `json:"properties"`
+}
+
+// TestOpenAPIDocument_swagger fails when the Swagger 2.0 document of the docs
+// package, which swag generates from the annotations of the handlers, and
+// the OpenAPI 3.1 document disagree on the operations, their path and query
+// parameters, the schemas or their properties.
+func TestOpenAPIDocument_swagger(t *testing.T) {
+	var swagger struct {
+		Paths       map[string]map[string]struct{ Parameters []specParameter } `json:"paths"`
+		Definitions map[string]specSchema                                      `json:"definitions"`
+	}
+	if err := json.Unmarshal([]byte(docs.SwaggerInfo.ReadDoc()), &swagger); err != nil {
+		t.Fatalf("ReadDoc() returned invalid JSON: %v", err)
+	}
+
+	data, err := json.Marshal(OpenAPIDocument())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc struct {
+		Paths      map[string]map[string]struct{ Parameters []specParameter } `json:"paths"`
+		Components struct {
+			Parameters map[string]specParameter `json:"parameters"`
+			Schemas    map[string]specSchema    `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("OpenAPIDocument() returned invalid JSON: %v", err)
+	}
+
+	// Only the path and query parameters are compared, as swag does not
+	// know the headers added by the middlewares.
+	operations := func(paths map[string]map[string]struct{ Parameters []specParameter }) map[string]map[string]bool {
+		result := make(map[string]map[string]bool)
+		for path, item := range paths {
+			for method, op := range item {
+				parameters := make(map[string]bool)
+				for _, parameter := range op.Parameters {
+					if parameter.Ref != "" {
+						parameter = doc.Components.Parameters[strings.TrimPrefix(parameter.Ref, "#/components/parameters/")]
+					}
+					if parameter.In == "path" || parameter.In == "query" {
+						parameters[parameter.In+" "+parameter.Name] = true
+					}
+				}
+				result[strings.ToUpper(method)+" "+path] = parameters
+			}
+		}
+		return result
+	}
+	documented := operations(swagger.Paths)
+	described := operations(doc.Paths)
+
+	for route, parameters := range described {
+		if notInSwagger[route] {
+			continue
+		}
+		swaggerParameters, ok := documented[route]
+		if !ok {
+			t.Errorf("swagger.json does not document %s", route)
+			continue
+		}
+		for _, parameter := range difference(parameters, swaggerParameters) {
+			t.Errorf("swagger.json does not document the %s parameter of %s", parameter, route)
+		}
+		for _, parameter := range difference(swaggerParameters, parameters) {
+			t.Errorf("OpenAPIDocument() does not describe the %s parameter of %s", parameter, route)
+		}
+	}
+	for route := range documented {
+		if _, ok := described[route]; !ok {
+			t.Errorf("OpenAPIDocument() does not describe %s", route)
+		}
+	}
+
+	names := make(map[string]bool)
+	for definition, swaggerSchema := range swagger.Definitions {
+		name, ok := swaggerSchemas[definition]
+		if !ok {
+			name = definition[strings.LastIndex(definition, ".")+1:]
+		}
+		names[name] = true
+
+		schema, ok := doc.Components.Schemas[name]
+		if !ok {
+			t.Errorf("OpenAPIDocument() has no schema %s for %s", name, definition)
+			continue
+		}
+		for _, property := range difference(keys(swaggerSchema.Properties), keys(schema.Properties)) {
+			t.Errorf("OpenAPIDocument() schema %s does not have the property %s of %s", name, property, definition)
+		}
+		for _, property := range difference(keys(schema.Properties), keys(swaggerSchema.Properties)) {
+			t.Errorf("swagger.json definition %s does not have the property %s of %s", definition, property, name)
+		}
+	}
+	for name := range doc.Components.Schemas {
+		if !names[name] {
+			t.Errorf("swagger.json has no definition for the schema %s", name)
+		}
+	}
+}
+
+func keys(properties map[string]json.RawMessage) map[string]bool {
+	result := make(map[string]bool, len(properties))
+	for key := range properties {
+		result[key] = true
+	}
+	return result
+}
+
+func difference(a map[string]bool, b map[string]bool) []string {
+	missing := make([]string, 0)
+	for key := range a {
+		if !b[key] {
+			missing = append(missing, key)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+// refs returns every $ref in a JSON document.
+func refs(data []byte) []string {
+	var value interface{}
+	json.Unmarshal(data, &value)
+
+	found := make([]string, 0)
+	var walk func(value interface{})
+	walk = func(value interface{}) {
+		switch value := value.(type) {
+		case map[string]interface{}:
+			for key, child := range value {
+				if ref, ok := child.(string); ok && key == "$ref" {
+					found = append(found, ref)
+				}
+				walk(child)
+			}
+		case []interface{}:
+			for _, child := range value {
+				walk(child)
+			}
+		}
+	}
+	walk(value)
+	return found
+}
@@ -0,0 +1,32 @@
+package controllers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// respond writes obj with status, or an empty 204 No Content when the
+// client asked for Prefer: return=minimal (RFC 7240).
+func respond(c *gin.Context, status int, obj interface{}) {
+	if preferMinimal(c.Request) {
+		c.Header("Preference-Applied", "return=minimal")
+		c.Status(http.StatusNoContent)
+		return
+	}
+	c.JSON(status, obj)
+}
+
+func preferMinimal(req *http.Request) bool {
+	for _, header := range req.Header.Values("Prefer") {
+		for _, preference := range strings.Split(header, ",") {
+			preference, _, _ = strings.Cut(preference, ";")
+			name, value, _ := strings.Cut(strings.TrimSpace(preference), "=")
+			if strings.EqualFold(strings.TrimSpace(name), "return") && strings.EqualFold(strings.Trim(strings.TrimSpace(value), `"`), "minimal") {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,15 @@
+package controllers
+
+// Count is the response of the count endpoints.
+type Count struct {
+	Count int64 `json:"count"`
+}
+
+// Deleted is the response of the delete endpoints.
+type Deleted struct {
+	Deleted string `json:"deleted"`
+}
+
+type Message struct {
+	Message string `json:"message"`
+}
@@ -0,0 +1,91 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/one-byte-data/go-api-sample/internal/problems"
+	"github.com/one-byte-data/go-api-sample/internal/services"
+)
+
+// @Summary Gets statistics about the cats
+// @Description get the counts by breed and color, the age buckets and the weights per breed of the cats, optionally filtered. The aggregation is done by the database
+// @Produce  json
+// @Param        status       query  string   false  "Status"  Enums(intake, available, on_hold, adopted, returned)
+// @Param        breed        query  string   false  "Breed, ignoring case"
+// @Param        color        query  string   false  "Color, ignoring case"
+// @Param        min_weight   query  integer  false  "Minimum weight"
+// @Param        max_weight   query  integer  false  "Maximum weight"
+// @Param        born_after   query  string   false  "Only cats born at or after this RFC 3339 time"
+// @Param        born_before  query  string   false  "Only cats born before this RFC 3339 time"
+// @Success 200 {object} models.AnimalStats	"ok"
+// @Failure      400   {object}  problems.Problem  "problem"
+// @Failure      500   {object}  problems.Problem  "problem"
+// @Failure      503   {object}  problems.Problem  "problem"
+// @Router /stats/cats [get]
+func StatsCatsGet(c *gin.Context) {
+	statsGet(c, services.CatsResource)
+}
+
+// @Summary Gets statistics about the dogs
+// @Description get the counts by breed and color, the age buckets and the weights per breed of the dogs, optionally filtered. The aggregation is done by the database
+// @Produce  json
+// @Param        status       query  string   false  "Status"  Enums(intake, available, on_hold, adopted, returned)
+// @Param        breed        query  string   false  "Breed, ignoring case"
+// @Param        color        query  string   false  "Color, ignoring case"
+// @Param        min_weight   query  integer  false  "Minimum weight"
+// @Param        max_weight   query  integer  false  "Maximum weight"
+// @Param        born_after   query  string   false  "Only dogs born at or after this RFC 3339 time"
+// @Param        born_before  query  string   false  "Only dogs born before this RFC 3339 time"
+// @Success 200 {object} models.AnimalStats	"ok"
+// @Failure      400   {object}  problems.Problem  "problem"
+// @Failure      500   {object}  problems.Problem  "problem"
+// @Failure      503   {object}  problems.Problem  "problem"
+// @Router /stats/dogs [get]
+func StatsDogsGet(c *gin.Context) {
+	statsGet(c, services.DogsResource)
+}
+
+func statsGet(c *gin.Context, species string) {
+	filter := &services.AnimalFilter{
+		Status: c.Query("status"),
+		Breed:  c.Query("breed"),
+		Color:  c.Query("color"),
+	}
+	if filter.Status != "" && !services.KnownStatus(filter.Status) {
+		problems.Abort(c, problems.New(http.StatusBadRequest, problems.CodeInvalidQuery, "invalid status"))
+		return
+	}
+
+	for name, weight := range map[string]*int{"min_weight": &filter.MinWeight, "max_weight": &filter.MaxWeight} {
+		if value := c.Query(name); value != "" {
+			parsed, err := strconv.Atoi(value)
+			if err != nil || parsed < 1 {
+				problems.Abort(c, problems.New(http.StatusBadRequest, problems.CodeInvalidQuery, "invalid "+name))
+				return
+			}
+			*weight = parsed
+		}
+	}
+
+	for name, birthdate := range map[string]**time.Time{"born_after": &filter.BornAfter, "born_before": &filter.BornBefore} {
+		if value := c.Query(name); value != "" {
+			parsed, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				problems.Abort(c, problems.New(http.StatusBadRequest, problems.CodeInvalidQuery, "invalid "+name))
+				return
+			}
+			*birthdate = &parsed
+		}
+	}
+
+	stats, err := statsService.Get(c.Request.Context(), species, filter)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	setCacheControl(c)
+	c.JSON(http.StatusOK, stats)
+}
@@ -0,0 +1,111 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/one-byte-data/go-api-sample/internal/repositories"
+)
+
+func TestStats(t *testing.T) {
+	router, err := SetupRouter(repositories.NewMemory())
+	if err != nil {
+		panic(err)
+	}
+
+	birthdate := func(years int) string {
+		return time.Now().UTC().AddDate(-years, -1, 0).Format(time.RFC3339)
+	}
+
+	type args struct {
+		method   string
+		endpoint string
+		body     string
+	}
+	tests := []struct {
+		name         string
+		args         args
+		wantCode     int
+		wantResponse string
+	}{
+		{
+			name:         "Should summarize no dogs",
+			args:         args{method: "GET", endpoint: "/stats/dogs"},
+			wantCode:     http.StatusOK,
+			wantResponse: `{"total":0,"breeds":[],"colors":[],"ages":[{"label":"0-1","min_years":0,"max_years":1,"count":0}`,
+		},
+		{
+			name:     "Should add a young cat",
+			args:     args{method: "POST", endpoint: "/cats", body: `{"name": "Nacho", "breed": "Siamese", "color": "Grey", "birthdate": "` + birthdate(2) + `", "weight": 8}`},
+			wantCode: http.StatusCreated,
+		},
+		{
+			name:     "Should add an older cat",
+			args:     args{method: "POST", endpoint: "/cats", body: `{"name": "Tom", "breed": "Siamese", "color": "White", "birthdate": "` + birthdate(8) + `", "weight": 12}`},
+			wantCode: http.StatusCreated,
+		},
+		{
+			name:         "Should count the cats by breed",
+			args:         args{method: "GET", endpoint: "/stats/cats"},
+			wantCode:     http.StatusOK,
+			wantResponse: `"breeds":[{"value":"Siamese","count":2}]`,
+		},
+		{
+			name:         "Should bucket the cats by age",
+			args:         args{method: "GET", endpoint: "/stats/cats?breed=siamese"},
+			wantCode:     http.StatusOK,
+			wantResponse: `{"label":"1-3","min_years":1,"max_years":3,"count":1},{"label":"3-7","min_years":3,"max_years":7,"count":0},{"label":"7-10","min_years":7,"max_years":10,"count":1}`,
+		},
+		{
+			name:         "Should summarize the weights per breed",
+			args:         args{method: "GET", endpoint: "/stats/cats?status=intake"},
+			wantCode:     http.StatusOK,
+			wantResponse: `"weights":[{"breed":"Siamese","count":2,"mean":10,"median":10,"p25":8,"p75":12,"p90":12}]`,
+		},
+		{
+			name:         "Should filter by color",
+			args:         args{method: "GET", endpoint: "/stats/cats?color=white&max_weight=20"},
+			wantCode:     http.StatusOK,
+			wantResponse: `"total":1,`,
+		},
+		{
+			name:         "Should not filter by an unknown status",
+			args:         args{method: "GET", endpoint: "/stats/cats?status=lost"},
+			wantCode:     http.StatusBadRequest,
+			wantResponse: `"code":"invalid_query"`,
+		},
+		{
+			name:         "Should not filter by an invalid weight",
+			args:         args{method: "GET", endpoint: "/stats/cats?min_weight=heavy"},
+			wantCode:     http.StatusBadRequest,
+			wantResponse: `"detail":"invalid min_weight"`,
+		},
+		{
+			name:         "Should not filter by an invalid birthdate",
+			args:         args{method: "GET", endpoint: "/stats/dogs?born_before=yesterday"},
+			wantCode:     http.StatusBadRequest,
+			wantResponse: `"detail":"invalid born_before"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(tt.args.method, tt.args.endpoint, strings.NewReader(tt.args.body))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("%s %s code = %v, wantCode %v: %s", tt.args.method, tt.args.endpoint, w.Code, tt.wantCode, w.Body.String())
+			}
+			if !strings.Contains(w.Body.String(), tt.wantResponse) {
+				t.Errorf("%s %s = %v, wantResponse %v", tt.args.method, tt.args.endpoint, w.Body.String(), tt.wantResponse)
+			}
+			if tt.args.method == "GET" && w.Code == http.StatusOK && w.Header().Get("Cache-Control") == "" {
+				t.Errorf("%s %s has no Cache-Control header", tt.args.method, tt.args.endpoint)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/one-byte-data/go-api-sample/internal/middlewares"
+	"github.com/one-byte-data/go-api-sample/internal/models"
+	"github.com/one-byte-data/go-api-sample/internal/problems"
+	"github.com/one-byte-data/go-api-sample/internal/services"
+)
+
+// TenantScope scopes the request to the tenant of the caller's credentials,
+// so the services only see the rows of that tenant. Requests without
+// credentials use the default tenant, and the X-Tenant-ID header may only
+// name the tenant the request is scoped to.
+func TenantScope(c *gin.Context) {
+	tenant, ok := middlewares.RequestedTenant(c)
+	if !ok {
+		problems.Abort(c, problems.New(http.StatusForbidden, problems.CodeNotAllowed, "the credentials do not allow acting for this tenant"))
+		return
+	}
+
+	ctx, err := services.WithTenant(c.Request.Context(), tenantsService, tenant)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.Request = c.Request.WithContext(ctx)
+	c.Next()
+}
+
+// @Summary Gets all the tenants
+// @Description get a list of tenants, requires the admin API key
+// @Produce  json
+// @Param        X-Api-Key  header  string  true  "Admin API key"
+// @Success 200 {array} models.Tenant	"ok"
+// @Failure      403   {object}  problems.Problem  "problem"
+// @Failure      500   {object}  problems.Problem  "problem"
+// @Failure      503   {object}  problems.Problem  "problem"
+// @Router /tenants [get]
+func TenantsGet(c *gin.Context) {
+	tenants, err := tenantsService.Get(c.Request.Context())
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, tenants)
+}
+
+// @Summary Gets a tenant by ID
+// @Description get a tenant, requires the admin API key
+// @Produce  json
+// @Param        id         path    string  true  "Tenant ID"
+// @Param        X-Api-Key  header  string  true  "Admin API key"
+// @Success 200 {object} models.Tenant	"ok"
+// @Failure      403   {object}  problems.Problem  "problem"
+// @Failure      404   {object}  problems.Problem  "problem"
+// @Failure      500   {object}  problems.Problem  "problem"
+// @Failure      503   {object}  problems.Problem  "problem"
+// @Router /tenants/{id} [get]
+func TenantsGetOne(c *gin.Context) {
+	tenant, err := tenantsService.GetOne(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, tenant)
+}
+
+// @Summary Adds a tenant
+// @Description adds a tenant, requires the admin API key. IDs are 1 to 63 lowercase letters, digits and hyphens
+// @Accept   json
+// @Produce  json
+// @Param        message    body    models.Tenant  true  "Tenant"
+// @Param        X-Api-Key  header  string         true  "Admin API key"
+// @Success      201   {object}  models.Tenant  "created"
+// @Header       201   {string}  Location  "URL of the new tenant"
+// @Failure      400   {object}  problems.Problem  "problem"
+// @Failure      403   {object}  problems.Problem  "problem"
+// @Failure      409   {object}  problems.Problem  "problem"
+// @Failure      500   {object}  problems.Problem  "problem"
+// @Failure      503   {object}  problems.Problem  "problem"
+// @Router /tenants [post]
+func TenantsPost(c *gin.Context) {
+	tenant := new(models.Tenant)
+	if err := bind(c, tenant); err != nil {
+		c.Error(err)
+		problems.Abort(c, problems.Binding(err))
+		return
+	}
+
+	if err := tenantsService.Add(c.Request.Context(), tenant); err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.Header("Location", fmt.Sprintf("/tenants/%s", tenant.ID))
+	c.JSON(http.StatusCreated, tenant)
+}
+
+// @Summary Deletes a tenant by ID
+// @Description deletes a tenant without cats or dogs, requires the admin API key. The default tenant cannot be deleted
+// @Produce  json
+// @Param        id         path    string  true  "Tenant ID"
+// @Param        X-Api-Key  header  string  true  "Admin API key"
+// @Success 200 {object} controllers.Deleted	"ok"
+// @Failure      403   {object}  problems.Problem  "problem"
+// @Failure      404   {object}  problems.Problem  "problem"
+// @Failure      409   {object}  problems.Problem  "problem"
+// @Failure      500   {object}  problems.Problem  "problem"
+// @Failure      503   {object}  problems.Problem  "problem"
+// @Router /tenants/{id} [delete]
+func TenantsDelete(c *gin.Context) {
+	id := c.Param("id")
+	if err := tenantsService.Delete(c.Request.Context(), id); err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, Deleted{Deleted: id})
+}
@@ -0,0 +1,174 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/one-byte-data/go-api-sample/internal/middlewares"
+	"github.com/one-byte-data/go-api-sample/internal/repositories"
+)
+
+func TestTenants(t *testing.T) {
+	t.Setenv("ADMIN_API_KEY", "secret")
+	t.Setenv("TENANT_API_KEYS", "shelter-a=key-a,shelter-b=key-b")
+	router, err := SetupRouter(repositories.NewMemory())
+	if err != nil {
+		panic(err)
+	}
+
+	const catID = "1e1a5b7c-0000-4000-8000-000000000001"
+	cat := `{"id": "` + catID + `", "name": "Nacho", "breed": "Tabby", "color": "Orange", "birthdate": "2019-05-01T00:00:00Z", "weight": 5}`
+
+	type args struct {
+		method   string
+		endpoint string
+		tenant   string
+		apiKey   string
+		body     string
+	}
+	tests := []struct {
+		name         string
+		args         args
+		wantCode     int
+		wantResponse string
+	}{
+		{
+			name:     "Should not manage tenants without the admin key",
+			args:     args{method: "POST", endpoint: "/tenants", apiKey: "guess", body: `{"id": "shelter-a", "name": "Shelter A"}`},
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:     "Should add a tenant",
+			args:     args{method: "POST", endpoint: "/tenants", apiKey: "secret", body: `{"id": "shelter-a", "name": "Shelter A"}`},
+			wantCode: http.StatusCreated,
+		},
+		{
+			name:     "Should add another tenant",
+			args:     args{method: "POST", endpoint: "/tenants", apiKey: "secret", body: `{"id": "shelter-b", "name": "Shelter B"}`},
+			wantCode: http.StatusCreated,
+		},
+		{
+			name:         "Should not add a tenant twice",
+			args:         args{method: "POST", endpoint: "/tenants", apiKey: "secret", body: `{"id": "shelter-a", "name": "Shelter A"}`},
+			wantCode:     http.StatusConflict,
+			wantResponse: `"code":"conflict"`,
+		},
+		{
+			name:         "Should not add a tenant with an invalid ID",
+			args:         args{method: "POST", endpoint: "/tenants", apiKey: "secret", body: `{"id": "Shelter C", "name": "Shelter C"}`},
+			wantCode:     http.StatusBadRequest,
+			wantResponse: `"code":"invalid_tenant"`,
+		},
+		{
+			name:         "Should list the tenants",
+			args:         args{method: "GET", endpoint: "/tenants", apiKey: "secret"},
+			wantCode:     http.StatusOK,
+			wantResponse: `"id":"shelter-b"`,
+		},
+		{
+			name:     "Should add a cat for a tenant",
+			args:     args{method: "POST", endpoint: "/cats", apiKey: "key-a", body: cat},
+			wantCode: http.StatusCreated,
+		},
+		{
+			name:         "Should not accept an unknown tenant",
+			args:         args{method: "GET", endpoint: "/cats", apiKey: "secret", tenant: "shelter-c"},
+			wantCode:     http.StatusBadRequest,
+			wantResponse: `"code":"invalid_tenant"`,
+		},
+		{
+			name:     "Should get the cat for its tenant",
+			args:     args{method: "GET", endpoint: "/cats/" + catID, apiKey: "key-a"},
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "Should not get the cat for another tenant",
+			args:     args{method: "GET", endpoint: "/cats/" + catID, apiKey: "key-b"},
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:         "Should not act for a tenant named without its credentials",
+			args:         args{method: "GET", endpoint: "/cats/" + catID, tenant: "shelter-a"},
+			wantCode:     http.StatusForbidden,
+			wantResponse: `"code":"not_allowed"`,
+		},
+		{
+			name:         "Should not act for a tenant other than the one of the credentials",
+			args:         args{method: "GET", endpoint: "/cats/" + catID, apiKey: "key-b", tenant: "shelter-a"},
+			wantCode:     http.StatusForbidden,
+			wantResponse: `"code":"not_allowed"`,
+		},
+		{
+			name:     "Should not get the cat for the default tenant",
+			args:     args{method: "GET", endpoint: "/cats/" + catID},
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:         "Should not list the cat for another tenant",
+			args:         args{method: "GET", endpoint: "/cats", apiKey: "key-b"},
+			wantCode:     http.StatusOK,
+			wantResponse: `[]`,
+		},
+		{
+			name:         "Should not count the cat for another tenant",
+			args:         args{method: "POST", endpoint: "/cats/count", apiKey: "key-b"},
+			wantCode:     http.StatusOK,
+			wantResponse: `{"count":0}`,
+		},
+		{
+			name:     "Should not update the cat for another tenant",
+			args:     args{method: "PUT", endpoint: "/cats/" + catID, apiKey: "key-b", body: cat},
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "Should not delete the cat for another tenant",
+			args:     args{method: "DELETE", endpoint: "/cats/" + catID, apiKey: "key-b"},
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:         "Should not show the audit log to another tenant",
+			args:         args{method: "GET", endpoint: "/audit", apiKey: "key-b"},
+			wantCode:     http.StatusOK,
+			wantResponse: `[]`,
+		},
+		{
+			name:         "Should not delete a tenant with cats",
+			args:         args{method: "DELETE", endpoint: "/tenants/shelter-a", apiKey: "secret"},
+			wantCode:     http.StatusConflict,
+			wantResponse: `"code":"conflict"`,
+		},
+		{
+			name:     "Should delete an empty tenant",
+			args:     args{method: "DELETE", endpoint: "/tenants/shelter-b", apiKey: "secret"},
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "Should not get a deleted tenant",
+			args:     args{method: "GET", endpoint: "/tenants/shelter-b", apiKey: "secret"},
+			wantCode: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(tt.args.method, tt.args.endpoint, strings.NewReader(tt.args.body))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.args.tenant != "" {
+				req.Header.Set(middlewares.TenantHeader, tt.args.tenant)
+			}
+			if tt.args.apiKey != "" {
+				req.Header.Set(middlewares.APIKeyHeader, tt.args.apiKey)
+			}
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("%s %s code = %v, wantCode %v: %s", tt.args.method, tt.args.endpoint, w.Code, tt.wantCode, w.Body.String())
+			}
+			if !strings.Contains(w.Body.String(), tt.wantResponse) {
+				t.Errorf("%s %s = %v, wantResponse %v", tt.args.method, tt.args.endpoint, w.Body.String(), tt.wantResponse)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/one-byte-data/go-api-sample/internal/models"
+	"github.com/one-byte-data/go-api-sample/internal/problems"
+	"github.com/one-byte-data/go-api-sample/internal/services"
+)
+
+// TransitionRequest is the body of the transition endpoints.
+type TransitionRequest struct {
+	Status string `json:"status" binding:"required,oneof=intake available on_hold adopted returned"`
+}
+
+// @Summary Changes the status of a cat
+// @Description moves a cat to another shelter status, following the allowed transitions
+// @Accept   json
+// @Produce  json
+// @Param        id       path  string                         true  "Cat ID"
+// @Param        message  body  controllers.TransitionRequest  true  "Status"
+// @Success      201   {object}  models.Transition  "created"
+// @Failure      400   {object}  problems.Problem  "problem"
+// @Failure      404   {object}  problems.Problem  "problem"
+// @Failure      409   {object}  problems.Problem  "problem"
+// @Failure      500   {object}  problems.Problem  "problem"
+// @Failure      503   {object}  problems.Problem  "problem"
+// @Router /cats/{id}/transitions [post]
+func CatsTransitionsPost(c *gin.Context) {
+	transitionsPost(c, catsService.Transition)
+}
+
+// @Summary Gets the status transitions of a cat
+// @Description get the status changes of a cat, oldest first
+// @Produce  json
+// @Param        id  path  string  true  "Cat ID"
+// @Success 200 {array} models.Transition	"ok"
+// @Failure      400   {object}  problems.Problem  "problem"
+// @Failure      500   {object}  problems.Problem  "problem"
+// @Failure      503   {object}  problems.Problem  "problem"
+// @Router /cats/{id}/transitions [get]
+func CatsTransitionsGet(c *gin.Context) {
+	transitionsGet(c, catsService.Transitions)
+}
+
+// @Summary Changes the status of a dog
+// @Description moves a dog to another shelter status, following the allowed transitions
+// @Accept   json
+// @Produce  json
+// @Param        id       path  string                         true  "Dog ID"
+// @Param        message  body  controllers.TransitionRequest  true  "Status"
+// @Success      201   {object}  models.Transition  "created"
+// @Failure      400   {object}  problems.Problem  "problem"
+// @Failure      404   {object}  problems.Problem  "problem"
+// @Failure      409   {object}  problems.Problem  "problem"
+// @Failure      500   {object}  problems.Problem  "problem"
+// @Failure      503   {object}  problems.Problem  "problem"
+// @Router /dogs/{id}/transitions [post]
+func DogsTransitionsPost(c *gin.Context) {
+	transitionsPost(c, dogsService.Transition)
+}
+
+// @Summary Gets the status transitions of a dog
+// @Description get the status changes of a dog, oldest first
+// @Produce  json
+// @Param        id  path  string  true  "Dog ID"
+// @Success 200 {array} models.Transition	"ok"
+// @Failure      400   {object}  problems.Problem  "problem"
+// @Failure      500   {object}  problems.Problem  "problem"
+// @Failure      503   {object}  problems.Problem  "problem"
+// @Router /dogs/{id}/transitions [get]
+func DogsTransitionsGet(c *gin.Context) {
+	transitionsGet(c, dogsService.Transitions)
+}
+
+func transitionsPost(c *gin.Context, transition func(ctx context.Context, id uuid.UUID, status string) (*models.Transition, error)) {
+	request := new(TransitionRequest)
+	if err := bind(c, request); err != nil {
+		c.Error(err)
+		problems.Abort(c, problems.Binding(err))
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		abortInvalidID(c)
+		return
+	}
+
+	recorded, err := transition(c.Request.Context(), id, request.Status)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, recorded)
+}
+
+func transitionsGet(c *gin.Context, transitions func(ctx context.Context, id uuid.UUID) ([]models.Transition, error)) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		abortInvalidID(c)
+		return
+	}
+
+	recorded, err := transitions(c.Request.Context(), id)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, recorded)
+}
+
+// statusFilter returns the filter of the status query parameter of the
+// list endpoints, or nil when there is none. It aborts with 400 and returns
+// false for an unknown status.
+func statusFilter(c *gin.Context) (interface{}, bool) {
+	status := c.Query("status")
+	if status == "" {
+		return nil, true
+	}
+	if !services.KnownStatus(status) {
+		problems.Abort(c, problems.New(http.StatusBadRequest, problems.CodeInvalidQuery, "invalid status"))
+		return nil, false
+	}
+	return &services.AnimalFilter{Status: status}, true
+}
@@ -0,0 +1,115 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/one-byte-data/go-api-sample/internal/middlewares"
+	"github.com/one-byte-data/go-api-sample/internal/repositories"
+)
+
+func TestTransitions(t *testing.T) {
+	router, err := SetupRouter(repositories.NewMemory())
+	if err != nil {
+		panic(err)
+	}
+
+	const dogID = "d06d0000-0000-4000-8000-000000000001"
+	dog := `{"id": "` + dogID + `", "name": "Spot", "breed": "Samoyed", "color": "White", "birthdate": "2019-05-01T00:00:00Z", "weight": 50, "status": "adopted"}`
+
+	type args struct {
+		method   string
+		endpoint string
+		body     string
+	}
+	tests := []struct {
+		name         string
+		args         args
+		wantCode     int
+		wantResponse string
+	}{
+		{
+			name:         "Should start new dogs at intake",
+			args:         args{method: "POST", endpoint: "/dogs", body: dog},
+			wantCode:     http.StatusCreated,
+			wantResponse: `"status":"intake"`,
+		},
+		{
+			name:         "Should not create a second dog with the same ID",
+			args:         args{method: "POST", endpoint: "/dogs", body: dog},
+			wantCode:     http.StatusConflict,
+			wantResponse: `"code":"conflict"`,
+		},
+		{
+			name:         "Should not list the dog as available yet",
+			args:         args{method: "GET", endpoint: "/dogs?status=available"},
+			wantCode:     http.StatusOK,
+			wantResponse: `[]`,
+		},
+		{
+			name:         "Should make the dog available",
+			args:         args{method: "POST", endpoint: "/dogs/" + dogID + "/transitions", body: `{"status": "available"}`},
+			wantCode:     http.StatusCreated,
+			wantResponse: `"from":"intake","to":"available","actor":"shelter-staff"`,
+		},
+		{
+			name:         "Should list the dog by its new status",
+			args:         args{method: "GET", endpoint: "/dogs?status=available"},
+			wantCode:     http.StatusOK,
+			wantResponse: `"status":"available"`,
+		},
+		{
+			name:         "Should reject a transition the state machine does not allow",
+			args:         args{method: "POST", endpoint: "/dogs/" + dogID + "/transitions", body: `{"status": "returned"}`},
+			wantCode:     http.StatusConflict,
+			wantResponse: `"code":"invalid_transition"`,
+		},
+		{
+			name:         "Should reject an unknown status",
+			args:         args{method: "POST", endpoint: "/dogs/" + dogID + "/transitions", body: `{"status": "lost"}`},
+			wantCode:     http.StatusBadRequest,
+			wantResponse: `"rule":"oneof"`,
+		},
+		{
+			name:     "Should not change the status of a dog that does not exist",
+			args:     args{method: "POST", endpoint: "/dogs/d06d0000-0000-4000-8000-000000000002/transitions", body: `{"status": "available"}`},
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:         "Should keep the status on updates",
+			args:         args{method: "PUT", endpoint: "/dogs/" + dogID, body: dog},
+			wantCode:     http.StatusOK,
+			wantResponse: `"status":"available"`,
+		},
+		{
+			name:         "Should list the transitions of the dog",
+			args:         args{method: "GET", endpoint: "/dogs/" + dogID + "/transitions"},
+			wantCode:     http.StatusOK,
+			wantResponse: `"resource":"dogs","resource_id":"` + dogID + `","from":"intake","to":"available"`,
+		},
+		{
+			name:         "Should not filter by an unknown status",
+			args:         args{method: "GET", endpoint: "/cats?status=lost"},
+			wantCode:     http.StatusBadRequest,
+			wantResponse: `"code":"invalid_query"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(tt.args.method, tt.args.endpoint, strings.NewReader(tt.args.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set(middlewares.ActorHeader, "shelter-staff")
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("%s %s code = %v, wantCode %v: %s", tt.args.method, tt.args.endpoint, w.Code, tt.wantCode, w.Body.String())
+			}
+			if !strings.Contains(w.Body.String(), tt.wantResponse) {
+				t.Errorf("%s %s = %v, wantResponse %v", tt.args.method, tt.args.endpoint, w.Body.String(), tt.wantResponse)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+// Package graphqlapi serves cats and dogs over GraphQL on top of the same
+// services as the REST API.
+package graphqlapi
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+	"github.com/one-byte-data/go-api-sample/internal/services"
+)
+
+// Request is the body of a GraphQL request.
+type Request struct {
+	Query         string                 `json:"query" binding:"required"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+}
+
+// Response is the body of a GraphQL response. Data is null when the query
+// could not be executed.
+type Response struct {
+	Data   interface{}     `json:"data"`
+	Errors []ResponseError `json:"errors,omitempty"`
+}
+
+// ResponseError is a GraphQL error. Extensions hold the code, and for an
+// invalid input the failing fields.
+type ResponseError struct {
+	Message    string                 `json:"message"`
+	Locations  []Location             `json:"locations,omitempty"`
+	Path       []interface{}          `json:"path,omitempty"`
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+}
+
+type Location struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+type API struct {
+	config    Config
+	schema    graphql.Schema
+	resources []resource
+	audit     services.AuditService
+}
+
+func New(cats services.CatsService, dogs services.DogsService, audit services.AuditService, config Config) (*API, error) {
+	resources := []resource{catsResource(cats), dogsResource(dogs)}
+	schema, err := newSchema(resources)
+	if err != nil {
+		return nil, err
+	}
+	return &API{
+		config:    config,
+		schema:    schema,
+		resources: resources,
+		audit:     audit,
+	}, nil
+}
+
+// Execute parses and validates the query, rejects it when it is deeper or
+// more complex than allowed and runs it with fresh batching loaders.
+func (a *API) Execute(ctx context.Context, req Request) *Response {
+	doc, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{
+		Body: []byte(req.Query),
+		Name: "GraphQL request",
+	})})
+	if err != nil {
+		return response(nil, gqlerrors.FormatErrors(err))
+	}
+
+	if validation := graphql.ValidateDocument(&a.schema, doc, nil); !validation.IsValid {
+		return response(nil, validation.Errors)
+	}
+
+	depth, complexity, err := measure(doc, req.OperationName, req.Variables)
+	if err != nil {
+		return response(nil, gqlerrors.FormatErrors(err))
+	}
+	if depth > a.config.MaxDepth {
+		return errorResponse(&Error{
+			Message: fmt.Sprintf("the query has a depth of %d, more than the maximum of %d", depth, a.config.MaxDepth),
+			Code:    CodeQueryTooDeep,
+		})
+	}
+	if complexity > a.config.MaxComplexity {
+		return errorResponse(&Error{
+			Message: fmt.Sprintf("the query has a complexity of %d, more than the maximum of %d", complexity, a.config.MaxComplexity),
+			Code:    CodeQueryTooComplex,
+		})
+	}
+
+	result := graphql.Execute(graphql.ExecuteParams{
+		Schema:        a.schema,
+		AST:           doc,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       withLoaders(ctx, newLoaders(ctx, a.resources, a.audit)),
+	})
+	return response(result.Data, result.Errors)
+}
+
+func response(data interface{}, errs []gqlerrors.FormattedError) *Response {
+	resp := &Response{Data: data}
+	for _, err := range errs {
+		responseError := ResponseError{
+			Message:    err.Message,
+			Path:       err.Path,
+			Extensions: err.Extensions,
+		}
+		for _, location := range err.Locations {
+			responseError.Locations = append(responseError.Locations, Location{Line: location.Line, Column: location.Column})
+		}
+		resp.Errors = append(resp.Errors, responseError)
+	}
+	return resp
+}
+
+func errorResponse(err *Error) *Response {
+	return &Response{Errors: []ResponseError{{Message: err.Message, Extensions: err.Extensions()}}}
+}
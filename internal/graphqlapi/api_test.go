@@ -0,0 +1,333 @@
+package graphqlapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/one-byte-data/go-api-sample/internal/models"
+	"github.com/one-byte-data/go-api-sample/internal/services"
+)
+
+// memoryCats is an in-memory services.CatsService honoring the
+// AnimalFilter fields the schema uses. It counts calls to Get.
+type memoryCats struct {
+	mu   sync.Mutex
+	cats map[uuid.UUID]models.Cat
+	gets int
+}
+
+func (s *memoryCats) Add(ctx context.Context, cat *models.Cat) (*uuid.UUID, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cats[cat.ID] = *cat
+	return &cat.ID, nil
+}
+
+func (s *memoryCats) Count(ctx context.Context, filter interface{}) (int64, error) {
+	cats, err := s.find(filter.(*services.AnimalFilter), false)
+	return int64(len(cats)), err
+}
+
+func (s *memoryCats) Delete(ctx context.Context, id uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.cats[id]; !ok {
+		return services.ErrNotFound
+	}
+	delete(s.cats, id)
+	return nil
+}
+
+func (s *memoryCats) Get(ctx context.Context, filter interface{}) ([]models.Cat, error) {
+	s.mu.Lock()
+	s.gets++
+	s.mu.Unlock()
+	return s.find(filter.(*services.AnimalFilter), true)
+}
+
+func (s *memoryCats) find(filter *services.AnimalFilter, page bool) ([]models.Cat, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids := make(map[uuid.UUID]bool)
+	for _, id := range filter.IDs {
+		ids[id] = true
+	}
+	cats := make([]models.Cat, 0)
+	for _, cat := range s.cats {
+		if filter.IDs != nil && !ids[cat.ID] {
+			continue
+		}
+		if filter.Name != "" && !strings.Contains(strings.ToLower(cat.Name), strings.ToLower(filter.Name)) {
+			continue
+		}
+		if page && filter.After != nil && bytes.Compare(cat.ID[:], filter.After[:]) <= 0 {
+			continue
+		}
+		cats = append(cats, cat)
+	}
+	sort.Slice(cats, func(i, j int) bool { return bytes.Compare(cats[i].ID[:], cats[j].ID[:]) < 0 })
+	if page && filter.Limit > 0 && len(cats) > filter.Limit {
+		cats = cats[:filter.Limit]
+	}
+	return cats, nil
+}
+
+func (s *memoryCats) GetOne(ctx context.Context, id uuid.UUID) (*models.Cat, error) {
+	return nil, fmt.Errorf("GetOne should not be called, lookups are batched")
+}
+
+func (s *memoryCats) Update(ctx context.Context, id uuid.UUID, cat *models.Cat) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.cats[id]; !ok {
+		return fmt.Errorf("row with id=%v cannot be updated because it doesn't exist: %w", id, services.ErrNotFound)
+	}
+	cat.ID = id
+	s.cats[id] = *cat
+	return nil
+}
+
+func (s *memoryCats) Transition(ctx context.Context, id uuid.UUID, status string) (*models.Transition, error) {
+	return nil, fmt.Errorf("Transition should not be called, the schema has no transitions")
+}
+
+func (s *memoryCats) Transitions(ctx context.Context, id uuid.UUID) ([]models.Transition, error) {
+	return nil, fmt.Errorf("Transitions should not be called, the schema has no transitions")
+}
+
+// emptyDogs is a services.DogsService without dogs.
+type emptyDogs struct {
+	services.DogsService
+}
+
+// memoryAudit returns one create entry for every requested resource and
+// counts calls to Get.
+type memoryAudit struct {
+	mu   sync.Mutex
+	gets int
+}
+
+func (s *memoryAudit) Get(ctx context.Context, filter *services.AuditFilter) ([]models.AuditEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.gets++
+	entries := make([]models.AuditEntry, 0, len(filter.ResourceIDs))
+	for _, id := range filter.ResourceIDs {
+		entries = append(entries, models.AuditEntry{ID: uuid.New(), Resource: filter.Resource, ResourceID: id, Action: models.AuditActionCreate, Actor: "test"})
+	}
+	return entries, nil
+}
+
+func (s *memoryAudit) History(ctx context.Context, resource string, id uuid.UUID) ([]models.AuditEntry, error) {
+	return nil, fmt.Errorf("History should not be called, lookups are batched")
+}
+
+func newTestAPI(t *testing.T, config Config, names ...string) (*API, *memoryCats, *memoryAudit, []uuid.UUID) {
+	t.Helper()
+
+	cats := &memoryCats{cats: make(map[uuid.UUID]models.Cat)}
+	ids := make([]uuid.UUID, 0, len(names))
+	for _, name := range names {
+		id := uuid.New()
+		cats.cats[id] = models.Cat{ID: id, Name: name, Breed: "Tabby", Color: "Orange", Birthdate: time.Date(2019, 5, 1, 0, 0, 0, 0, time.UTC), Weight: 5}
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return bytes.Compare(ids[i][:], ids[j][:]) < 0 })
+
+	audit := &memoryAudit{}
+	api, err := New(cats, emptyDogs{}, audit, config)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return api, cats, audit, ids
+}
+
+func execute(t *testing.T, api *API, query string, variables map[string]interface{}) (map[string]interface{}, []ResponseError) {
+	t.Helper()
+
+	resp := api.Execute(context.Background(), Request{Query: query, Variables: variables})
+	data, err := json.Marshal(resp.Data)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	var result map[string]interface{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	return result, resp.Errors
+}
+
+func errorCodes(errs []ResponseError) []string {
+	codes := make([]string, 0, len(errs))
+	for _, err := range errs {
+		code, _ := err.Extensions["code"].(string)
+		codes = append(codes, code)
+	}
+	return codes
+}
+
+func TestAPI_Execute_batchesLookups(t *testing.T) {
+	api, cats, audit, ids := newTestAPI(t, DefaultConfig(), "Nacho", "Tom", "Felix")
+
+	data, errs := execute(t, api, `query($a: ID!, $b: ID!, $missing: ID!) {
+		a: cat(id: $a) { name history { action } }
+		b: cat(id: $b) { name history { action } }
+		missing: cat(id: $missing) { name }
+		cats { edges { node { history { actor } } } }
+	}`, map[string]interface{}{"a": ids[0].String(), "b": ids[1].String(), "missing": uuid.New().String()})
+	if len(errs) > 0 {
+		t.Fatalf("Execute() errors = %v", errs)
+	}
+
+	if data["missing"] != nil {
+		t.Errorf("Execute() missing = %v, want null", data["missing"])
+	}
+	if got := data["a"].(map[string]interface{})["history"].([]interface{}); len(got) != 1 {
+		t.Errorf("Execute() a.history = %v, want one entry", got)
+	}
+	// One Get for the list and one for the three cat lookups. The history of
+	// the two cats and of the list is loaded in a single batch.
+	if cats.gets != 2 {
+		t.Errorf("Execute() called CatsService.Get %d times, want 2", cats.gets)
+	}
+	if audit.gets != 1 {
+		t.Errorf("Execute() called AuditService.Get %d times, want 1", audit.gets)
+	}
+}
+
+func TestAPI_Execute_paginates(t *testing.T) {
+	api, _, _, ids := newTestAPI(t, DefaultConfig(), "Nacho", "Tom", "Felix")
+	query := `query($after: String) {
+		cats(first: 2, after: $after) {
+			totalCount
+			edges { node { id } }
+			pageInfo { hasNextPage endCursor }
+		}
+	}`
+
+	var after interface{}
+	var got []string
+	pages := 0
+	for {
+		data, errs := execute(t, api, query, map[string]interface{}{"after": after})
+		if len(errs) > 0 {
+			t.Fatalf("Execute() errors = %v", errs)
+		}
+		connection := data["cats"].(map[string]interface{})
+		if connection["totalCount"] != float64(3) {
+			t.Errorf("Execute() totalCount = %v, want 3", connection["totalCount"])
+		}
+		for _, edge := range connection["edges"].([]interface{}) {
+			got = append(got, edge.(map[string]interface{})["node"].(map[string]interface{})["id"].(string))
+		}
+		pages++
+
+		info := connection["pageInfo"].(map[string]interface{})
+		if info["hasNextPage"] != true {
+			break
+		}
+		after = info["endCursor"]
+	}
+
+	if pages != 2 {
+		t.Errorf("Execute() returned %d pages, want 2", pages)
+	}
+	want := []string{ids[0].String(), ids[1].String(), ids[2].String()}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("Execute() ids = %v, want %v", got, want)
+	}
+}
+
+func TestAPI_Execute(t *testing.T) {
+	api, _, _, ids := newTestAPI(t, Config{MaxDepth: 4, MaxComplexity: 50}, "Nacho")
+
+	tests := []struct {
+		name      string
+		query     string
+		variables map[string]interface{}
+		wantCodes []string
+		wantData  string
+	}{
+		{
+			name:     "Should filter cats",
+			query:    `{ cats(filter: {name: "ach"}) { totalCount } }`,
+			wantData: `{"cats":{"totalCount":1}}`,
+		},
+		{
+			name:     "Should create a cat",
+			query:    `mutation { createCat(input: {name: "Tom", breed: "Tabby", color: "Grey", birthdate: "2020-01-02T00:00:00Z", weight: 4}) { name weight } }`,
+			wantData: `{"createCat":{"name":"Tom","weight":4}}`,
+		},
+		{
+			name:      "Should not create an invalid cat",
+			query:     `mutation { createCat(input: {name: "Tom", breed: "Tabby", color: "Grey", birthdate: "2020-01-02T00:00:00Z", weight: 100}) { name } }`,
+			wantCodes: []string{"invalid_body"},
+		},
+		{
+			name:      "Should update a cat",
+			query:     `mutation($id: ID!) { updateCat(id: $id, input: {name: "Nacho", breed: "Tabby", color: "Orange", birthdate: "2019-05-01T00:00:00Z", weight: 6}) { weight } }`,
+			variables: map[string]interface{}{"id": ids[0].String()},
+			wantData:  `{"updateCat":{"weight":6}}`,
+		},
+		{
+			name:      "Should not update a missing cat",
+			query:     `mutation($id: ID!) { updateCat(id: $id, input: {name: "Nacho", breed: "Tabby", color: "Orange", birthdate: "2019-05-01T00:00:00Z", weight: 6}) { weight } }`,
+			variables: map[string]interface{}{"id": uuid.New().String()},
+			wantCodes: []string{"not_found"},
+		},
+		{
+			name:      "Should not accept an invalid id",
+			query:     `{ cat(id: "nacho") { name } }`,
+			wantCodes: []string{"invalid_id"},
+		},
+		{
+			name:      "Should not accept an empty page",
+			query:     `{ cats(first: 0) { totalCount } }`,
+			wantCodes: []string{"invalid_query"},
+		},
+		{
+			name:      "Should reject a query that is too deep",
+			query:     `{ cats(first: 1) { edges { node { history { actor } } } } }`,
+			wantCodes: []string{CodeQueryTooDeep},
+		},
+		{
+			name:      "Should reject a query that is too complex",
+			query:     `{ cats { edges { node { name breed color } } } }`,
+			wantCodes: []string{CodeQueryTooComplex},
+		},
+		{
+			name:      "Should reject an invalid query",
+			query:     `{ cats { whiskers } }`,
+			wantCodes: []string{""},
+		},
+		{
+			name:     "Should delete a cat",
+			query:    fmt.Sprintf(`mutation { deleteCat(id: %q) }`, ids[0]),
+			wantData: fmt.Sprintf(`{"deleteCat":%q}`, ids[0]),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, errs := execute(t, api, tt.query, tt.variables)
+			if got := errorCodes(errs); fmt.Sprint(got) != fmt.Sprint(append([]string{}, tt.wantCodes...)) {
+				t.Errorf("Execute() error codes = %v, want %v (errors = %v)", got, tt.wantCodes, errs)
+				return
+			}
+			if tt.wantData != "" {
+				got, _ := json.Marshal(data)
+				if string(got) != tt.wantData {
+					t.Errorf("Execute() data = %s, want %s", got, tt.wantData)
+				}
+			}
+		})
+	}
+}
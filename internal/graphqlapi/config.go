@@ -0,0 +1,52 @@
+package graphqlapi
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+)
+
+const (
+	// DefaultPageSize is the number of cats or dogs a connection returns
+	// when the query does not set first.
+	DefaultPageSize = 20
+	// MaxPageSize is the largest first a connection accepts.
+	MaxPageSize = 100
+)
+
+type Config struct {
+	// MaxDepth is the deepest nesting of fields a query may select.
+	MaxDepth int
+	// MaxComplexity is the highest cost a query may have, see measure.
+	MaxComplexity int
+}
+
+func DefaultConfig() Config {
+	return Config{
+		MaxDepth:      10,
+		MaxComplexity: 1000,
+	}
+}
+
+// ConfigFromEnv reads GRAPHQL_MAX_DEPTH and GRAPHQL_MAX_COMPLEXITY.
+func ConfigFromEnv() (Config, error) {
+	config := DefaultConfig()
+
+	if value := os.Getenv("GRAPHQL_MAX_DEPTH"); value != "" {
+		depth, err := strconv.Atoi(value)
+		if err != nil || depth < 1 {
+			return config, fmt.Errorf("GRAPHQL_MAX_DEPTH must be a positive number, got %q", value)
+		}
+		config.MaxDepth = depth
+	}
+
+	if value := os.Getenv("GRAPHQL_MAX_COMPLEXITY"); value != "" {
+		complexity, err := strconv.Atoi(value)
+		if err != nil || complexity < 1 {
+			return config, fmt.Errorf("GRAPHQL_MAX_COMPLEXITY must be a positive number, got %q", value)
+		}
+		config.MaxComplexity = complexity
+	}
+
+	return config, nil
+}
@@ -0,0 +1,65 @@
+package graphqlapi
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/one-byte-data/go-api-sample/internal/problems"
+	"github.com/one-byte-data/go-api-sample/internal/services"
+)
+
+// Codes of errors that only the GraphQL endpoint returns. The others are
+// the problem codes of the REST API.
+const (
+	CodeQueryTooDeep    = "query_too_deep"
+	CodeQueryTooComplex = "query_too_complex"
+)
+
+// Error is a GraphQL error with the code, and for invalid input the fields,
+// in its extensions.
+type Error struct {
+	Message string
+	Code    string
+	Fields  []problems.FieldError
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Extensions() map[string]interface{} {
+	extensions := map[string]interface{}{"code": e.Code}
+	if len(e.Fields) > 0 {
+		extensions["errors"] = e.Fields
+	}
+	return extensions
+}
+
+// resolveError maps a service error to a GraphQL error the way
+// abortWithError maps it to a problem. Internal errors are logged and not
+// returned to the client.
+func resolveError(ctx context.Context, err error) error {
+	if errors.Is(err, services.ErrNotFound) {
+		return &Error{Message: "the resource does not exist", Code: problems.CodeNotFound}
+	}
+	if errors.Is(err, services.ErrInvalid) {
+		return invalidInput(err)
+	}
+	if errors.Is(err, services.ErrUnavailable) {
+		return &Error{Message: "the database is unavailable, try again later", Code: problems.CodeUnavailable}
+	}
+	slog.ErrorContext(ctx, "graphql resolver failed", "error", err)
+	return &Error{Message: "there was an error", Code: problems.CodeInternal}
+}
+
+func invalidID(argument string) error {
+	return &Error{Message: argument + " is not a valid ID", Code: problems.CodeInvalidID}
+}
+
+// invalidInput turns a validation error into an error listing the invalid
+// fields of the input argument.
+func invalidInput(err error) error {
+	problem := problems.Binding(err)
+	return &Error{Message: "the input is not valid", Code: problems.CodeInvalidBody, Fields: problem.Errors}
+}
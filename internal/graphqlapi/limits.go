@@ -0,0 +1,102 @@
+package graphqlapi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// listCosts is how many items a list field is assumed to return when the
+// query does not set first. Connections default to a full page.
+var listCosts = map[string]int{
+	"cats":    DefaultPageSize,
+	"dogs":    DefaultPageSize,
+	"history": 10,
+}
+
+// measure returns the depth and complexity of the operation that will be
+// executed. Every field costs one, and the selections under a list field are
+// multiplied by its first argument or its entry in listCosts. Introspection
+// fields are not counted so tools can load the schema.
+func measure(doc *ast.Document, operationName string, variables map[string]interface{}) (depth int, complexity int, err error) {
+	fragments := make(map[string]*ast.FragmentDefinition)
+	var operation *ast.OperationDefinition
+	operations := 0
+	for _, definition := range doc.Definitions {
+		switch definition := definition.(type) {
+		case *ast.FragmentDefinition:
+			fragments[definition.Name.Value] = definition
+		case *ast.OperationDefinition:
+			operations++
+			if operationName == "" || (definition.Name != nil && definition.Name.Value == operationName) {
+				operation = definition
+			}
+		}
+	}
+	if operation == nil || (operationName == "" && operations > 1) {
+		return 0, 0, fmt.Errorf("unknown operation %q", operationName)
+	}
+
+	m := &measurer{fragments: fragments, variables: variables}
+	depth, complexity = m.selectionSet(operation.SelectionSet)
+	return depth, complexity, nil
+}
+
+type measurer struct {
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]interface{}
+}
+
+func (m *measurer) selectionSet(set *ast.SelectionSet) (depth int, complexity int) {
+	if set == nil {
+		return 0, 0
+	}
+	for _, selection := range set.Selections {
+		var d, c int
+		switch selection := selection.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(selection.Name.Value, "__") {
+				continue
+			}
+			childDepth, childComplexity := m.selectionSet(selection.SelectionSet)
+			d = childDepth + 1
+			c = 1 + m.multiplier(selection)*childComplexity
+		case *ast.InlineFragment:
+			d, c = m.selectionSet(selection.SelectionSet)
+		case *ast.FragmentSpread:
+			// Validation has already rejected unknown and cyclic fragments.
+			if fragment, ok := m.fragments[selection.Name.Value]; ok {
+				d, c = m.selectionSet(fragment.SelectionSet)
+			}
+		}
+		if d > depth {
+			depth = d
+		}
+		complexity += c
+	}
+	return depth, complexity
+}
+
+func (m *measurer) multiplier(field *ast.Field) int {
+	for _, argument := range field.Arguments {
+		if argument.Name.Value != "first" {
+			continue
+		}
+		switch value := argument.Value.(type) {
+		case *ast.IntValue:
+			if n, err := strconv.Atoi(value.Value); err == nil && n > 0 {
+				return n
+			}
+		case *ast.Variable:
+			if n, ok := m.variables[value.Name.Value].(float64); ok && n > 0 {
+				return int(n)
+			}
+		}
+	}
+	if cost, ok := listCosts[field.Name.Value]; ok {
+		return cost
+	}
+	return 1
+}
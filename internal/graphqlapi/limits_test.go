@@ -0,0 +1,86 @@
+package graphqlapi
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+func Test_measure(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		operationName  string
+		variables      map[string]interface{}
+		wantDepth      int
+		wantComplexity int
+		wantErr        bool
+	}{
+		{
+			name:           "Should count every field",
+			query:          `{ cat(id: "1") { name breed } }`,
+			wantDepth:      2,
+			wantComplexity: 3,
+		},
+		{
+			name:           "Should multiply by first",
+			query:          `{ cats(first: 5) { edges { node { name } } } }`,
+			wantDepth:      4,
+			wantComplexity: 1 + 5*3,
+		},
+		{
+			name:           "Should multiply by a default page when first is not set",
+			query:          `{ cats { totalCount } }`,
+			wantDepth:      2,
+			wantComplexity: 1 + DefaultPageSize,
+		},
+		{
+			name:           "Should read first from a variable",
+			query:          `query($first: Int) { dogs(first: $first) { totalCount } }`,
+			variables:      map[string]interface{}{"first": float64(3)},
+			wantDepth:      2,
+			wantComplexity: 4,
+		},
+		{
+			name:           "Should follow fragments",
+			query:          `query { cat(id: "1") { ...fields } } fragment fields on Cat { name history { actor } }`,
+			wantDepth:      3,
+			wantComplexity: 1 + 1 + 1 + 10,
+		},
+		{
+			name:           "Should measure the named operation",
+			query:          `query A { cat(id: "1") { name } } query B { cat(id: "1") { history { actor } } }`,
+			operationName:  "B",
+			wantDepth:      3,
+			wantComplexity: 1 + 1 + 10,
+		},
+		{
+			name:           "Should not count introspection",
+			query:          `{ __schema { types { name fields { name type { ofType { ofType { name } } } } } } }`,
+			wantDepth:      0,
+			wantComplexity: 0,
+		},
+		{
+			name:    "Should need an operation name when there are several operations",
+			query:   `query A { cat(id: "1") { name } } query B { cat(id: "1") { name } }`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := parser.Parse(parser.ParseParams{Source: tt.query})
+			if err != nil {
+				t.Fatalf("parser.Parse() error = %v", err)
+			}
+
+			depth, complexity, err := measure(doc, tt.operationName, tt.variables)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("measure() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if depth != tt.wantDepth || complexity != tt.wantComplexity {
+				t.Errorf("measure() = %v, %v, want %v, %v", depth, complexity, tt.wantDepth, tt.wantComplexity)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package graphqlapi
+
+import (
+	"context"
+	"sync"
+)
+
+// batchFunc loads the values of many keys at once. Keys missing from the
+// result resolve to the zero value.
+type batchFunc[K comparable, V any] func(ctx context.Context, keys []K) (map[K]V, error)
+
+// loader batches the lookups of one request. Load returns a thunk; the
+// executor resolves every field of a level before calling their thunks, so
+// the first thunk called fetches the keys of the whole level in one batch.
+// Values are kept for the rest of the request.
+type loader[K comparable, V any] struct {
+	ctx     context.Context
+	batch   batchFunc[K, V]
+	mu      sync.Mutex
+	pending []K
+	values  map[K]V
+	errs    map[K]error
+}
+
+func newLoader[K comparable, V any](ctx context.Context, batch batchFunc[K, V]) *loader[K, V] {
+	return &loader[K, V]{
+		ctx:    ctx,
+		batch:  batch,
+		values: make(map[K]V),
+		errs:   make(map[K]error),
+	}
+}
+
+func (l *loader[K, V]) Load(key K) func() (V, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, ok := l.values[key]; !ok {
+		if _, ok := l.errs[key]; !ok {
+			l.pending = append(l.pending, key)
+		}
+	}
+
+	return func() (V, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		if len(l.pending) > 0 {
+			l.dispatch()
+		}
+		if err, ok := l.errs[key]; ok {
+			var zero V
+			return zero, err
+		}
+		return l.values[key], nil
+	}
+}
+
+// dispatch loads the pending keys. It must be called with mu held.
+func (l *loader[K, V]) dispatch() {
+	keys := unique(l.pending)
+	l.pending = nil
+
+	values, err := l.batch(l.ctx, keys)
+	for _, key := range keys {
+		if err != nil {
+			l.errs[key] = err
+			continue
+		}
+		l.values[key] = values[key]
+	}
+}
+
+func unique[K comparable](keys []K) []K {
+	seen := make(map[K]bool, len(keys))
+	result := make([]K, 0, len(keys))
+	for _, key := range keys {
+		if !seen[key] {
+			seen[key] = true
+			result = append(result, key)
+		}
+	}
+	return result
+}
@@ -0,0 +1,61 @@
+package graphqlapi
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/one-byte-data/go-api-sample/internal/models"
+	"github.com/one-byte-data/go-api-sample/internal/services"
+)
+
+type contextKey string
+
+const loadersKey contextKey = "loaders"
+
+// loaders holds the batching loaders of one request, keyed by resource
+// name.
+type loaders struct {
+	animals map[string]*loader[uuid.UUID, *animal]
+	history map[string]*loader[uuid.UUID, []models.AuditEntry]
+}
+
+func newLoaders(ctx context.Context, resources []resource, audit services.AuditService) *loaders {
+	l := &loaders{
+		animals: make(map[string]*loader[uuid.UUID, *animal]),
+		history: make(map[string]*loader[uuid.UUID, []models.AuditEntry]),
+	}
+	for _, r := range resources {
+		r := r
+		l.animals[r.Name()] = newLoader(ctx, func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*animal, error) {
+			animals, err := r.Get(ctx, &services.AnimalFilter{IDs: ids})
+			if err != nil {
+				return nil, err
+			}
+			byID := make(map[uuid.UUID]*animal, len(animals))
+			for i := range animals {
+				byID[animals[i].ID] = &animals[i]
+			}
+			return byID, nil
+		})
+		l.history[r.Name()] = newLoader(ctx, func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID][]models.AuditEntry, error) {
+			entries, err := audit.Get(ctx, &services.AuditFilter{Resource: r.Name(), ResourceIDs: ids})
+			if err != nil {
+				return nil, err
+			}
+			byID := make(map[uuid.UUID][]models.AuditEntry, len(ids))
+			for _, entry := range entries {
+				byID[entry.ResourceID] = append(byID[entry.ResourceID], entry)
+			}
+			return byID, nil
+		})
+	}
+	return l
+}
+
+func withLoaders(ctx context.Context, l *loaders) context.Context {
+	return context.WithValue(ctx, loadersKey, l)
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey).(*loaders)
+}
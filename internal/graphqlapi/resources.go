@@ -0,0 +1,131 @@
+package graphqlapi
+
+import (
+	"context"
+	"time"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+	"github.com/one-byte-data/go-api-sample/internal/models"
+	"github.com/one-byte-data/go-api-sample/internal/services"
+)
+
+// animal is the GraphQL view of a cat or a dog. Both models convert to it.
+type animal struct {
+	ID        uuid.UUID
+	Name      string    `graphql:"name"`
+	Breed     string    `graphql:"breed"`
+	Color     string    `graphql:"color"`
+	Birthdate time.Time `graphql:"birthdate"`
+	Weight    int       `graphql:"weight"`
+	// BreedStatus is a models.BreedKnown or models.BreedUnknown.
+	BreedStatus string `graphql:"breedStatus"`
+	Status      string `graphql:"status"`
+	TenantID    string
+}
+
+// animalService is what services.CatsService and services.DogsService have
+// in common.
+type animalService[T any] interface {
+	Add(ctx context.Context, item *T) (*uuid.UUID, error)
+	Count(ctx context.Context, filter interface{}) (int64, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	Get(ctx context.Context, filter interface{}) ([]T, error)
+	Update(ctx context.Context, id uuid.UUID, item *T) error
+}
+
+// resource adapts a cats or dogs service to the animal view so the schema
+// is built once for both.
+type resource interface {
+	// Type is the GraphQL type name, e.g. Cat.
+	Type() string
+	// Name is the plural name the resource is queried and audited under,
+	// e.g. cats.
+	Name() string
+	Add(ctx context.Context, a *animal) error
+	Count(ctx context.Context, filter *services.AnimalFilter) (int64, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	Get(ctx context.Context, filter *services.AnimalFilter) ([]animal, error)
+	Update(ctx context.Context, id uuid.UUID, a *animal) error
+	// Validate checks the animal with the model's binding rules.
+	Validate(a *animal) error
+}
+
+type serviceResource[T any] struct {
+	typeName string
+	name     string
+	service  animalService[T]
+	to       func(T) animal
+	from     func(animal) T
+}
+
+func catsResource(service services.CatsService) resource {
+	return &serviceResource[models.Cat]{
+		typeName: "Cat",
+		name:     services.CatsResource,
+		service:  service,
+		to:       func(cat models.Cat) animal { return animal(cat) },
+		from:     func(a animal) models.Cat { return models.Cat(a) },
+	}
+}
+
+func dogsResource(service services.DogsService) resource {
+	return &serviceResource[models.Dog]{
+		typeName: "Dog",
+		name:     services.DogsResource,
+		service:  service,
+		to:       func(dog models.Dog) animal { return animal(dog) },
+		from:     func(a animal) models.Dog { return models.Dog(a) },
+	}
+}
+
+func (r *serviceResource[T]) Type() string {
+	return r.typeName
+}
+
+func (r *serviceResource[T]) Name() string {
+	return r.name
+}
+
+func (r *serviceResource[T]) Add(ctx context.Context, a *animal) error {
+	item := r.from(*a)
+	if _, err := r.service.Add(ctx, &item); err != nil {
+		return err
+	}
+	*a = r.to(item)
+	return nil
+}
+
+func (r *serviceResource[T]) Count(ctx context.Context, filter *services.AnimalFilter) (int64, error) {
+	return r.service.Count(ctx, filter)
+}
+
+func (r *serviceResource[T]) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.service.Delete(ctx, id)
+}
+
+func (r *serviceResource[T]) Get(ctx context.Context, filter *services.AnimalFilter) ([]animal, error) {
+	items, err := r.service.Get(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	animals := make([]animal, 0, len(items))
+	for _, item := range items {
+		animals = append(animals, r.to(item))
+	}
+	return animals, nil
+}
+
+func (r *serviceResource[T]) Update(ctx context.Context, id uuid.UUID, a *animal) error {
+	item := r.from(*a)
+	if err := r.service.Update(ctx, id, &item); err != nil {
+		return err
+	}
+	*a = r.to(item)
+	return nil
+}
+
+func (r *serviceResource[T]) Validate(a *animal) error {
+	item := r.from(*a)
+	return binding.Validator.ValidateStruct(&item)
+}
@@ -0,0 +1,411 @@
+package graphqlapi
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/graphql-go/graphql"
+	"github.com/one-byte-data/go-api-sample/internal/models"
+	"github.com/one-byte-data/go-api-sample/internal/problems"
+	"github.com/one-byte-data/go-api-sample/internal/services"
+)
+
+// connection is a page of cats or dogs. The page is only loaded when edges
+// or pageInfo are selected, so counting does not list.
+type connection struct {
+	resource resource
+	filter   *services.AnimalFilter
+	page     *services.AnimalFilter
+	first    int
+
+	once        sync.Once
+	animals     []animal
+	hasNextPage bool
+	err         error
+}
+
+func (c *connection) load(ctx context.Context) error {
+	c.once.Do(func() {
+		animals, err := c.resource.Get(ctx, c.page)
+		if err != nil {
+			c.err = resolveError(ctx, err)
+			return
+		}
+		c.animals = animals
+		if len(animals) > c.first {
+			c.animals = animals[:c.first]
+			c.hasNextPage = true
+		}
+	})
+	return c.err
+}
+
+type edge struct {
+	Cursor string  `graphql:"cursor"`
+	Node   *animal `graphql:"node"`
+}
+
+type pageInfo struct {
+	HasNextPage bool    `graphql:"hasNextPage"`
+	EndCursor   *string `graphql:"endCursor"`
+}
+
+// Cursors are opaque to clients but are the base64 encoded ID of the last
+// animal on the page.
+func encodeCursor(id uuid.UUID) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(id.String()))
+}
+
+func decodeCursor(cursor string) (uuid.UUID, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return uuid.Parse(string(data))
+}
+
+var auditEntryType = graphql.NewObject(graphql.ObjectConfig{
+	Name:        "AuditEntry",
+	Description: "A create, update or delete recorded in the audit log.",
+	Fields: graphql.Fields{
+		"id": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.ID),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(models.AuditEntry).ID.String(), nil
+			},
+		},
+		"action": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.String),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(models.AuditEntry).Action, nil
+			},
+		},
+		"actor": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.String),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(models.AuditEntry).Actor, nil
+			},
+		},
+		"requestId": &graphql.Field{
+			Type: graphql.String,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(models.AuditEntry).RequestID, nil
+			},
+		},
+		"timestamp": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.DateTime),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(models.AuditEntry).Timestamp, nil
+			},
+		},
+		"changes": &graphql.Field{
+			Type:        graphql.String,
+			Description: "JSON object with the old and new value of every changed field.",
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return string(p.Source.(models.AuditEntry).Changes), nil
+			},
+		},
+	},
+})
+
+var pageInfoType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "PageInfo",
+	Fields: graphql.Fields{
+		"hasNextPage": &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+		"endCursor":   &graphql.Field{Type: graphql.String},
+	},
+})
+
+var animalFilterType = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name:        "AnimalFilter",
+	Description: "Narrows a list of cats or dogs. Name matches a substring, breed and color match exactly, and all three ignore case. Status matches exactly.",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"name":       &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"breed":      &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"color":      &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"status":     &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"minWeight":  &graphql.InputObjectFieldConfig{Type: graphql.Int},
+		"maxWeight":  &graphql.InputObjectFieldConfig{Type: graphql.Int},
+		"bornAfter":  &graphql.InputObjectFieldConfig{Type: graphql.DateTime},
+		"bornBefore": &graphql.InputObjectFieldConfig{Type: graphql.DateTime},
+	},
+})
+
+func newSchema(resources []resource) (graphql.Schema, error) {
+	query := graphql.Fields{}
+	mutation := graphql.Fields{}
+	for _, r := range resources {
+		addResource(r, query, mutation)
+	}
+
+	return graphql.NewSchema(graphql.SchemaConfig{
+		Query:    graphql.NewObject(graphql.ObjectConfig{Name: "Query", Fields: query}),
+		Mutation: graphql.NewObject(graphql.ObjectConfig{Name: "Mutation", Fields: mutation}),
+	})
+}
+
+// addResource adds the type of r and its queries and mutations, e.g. cat,
+// cats, createCat, updateCat and deleteCat.
+func addResource(r resource, query graphql.Fields, mutation graphql.Fields) {
+	singular := strings.ToLower(r.Type())
+
+	objectType := graphql.NewObject(graphql.ObjectConfig{
+		Name: r.Type(),
+		Fields: graphql.Fields{
+			"id": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.ID),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*animal).ID.String(), nil
+				},
+			},
+			"name":      &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"breed":     &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"color":     &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"birthdate": &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+			"weight":    &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"breedStatus": &graphql.Field{
+				Type:        graphql.String,
+				Description: "Whether the breed is in the breed catalogue: known, or unknown when strict mode is off.",
+			},
+			"status": &graphql.Field{
+				Type:        graphql.String,
+				Description: "The shelter status: intake, available, on_hold, adopted or returned.",
+			},
+			"history": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(auditEntryType))),
+				Description: "Changes to the " + singular + " from the audit log, oldest first.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					thunk := loadersFrom(p.Context).history[r.Name()].Load(p.Source.(*animal).ID)
+					return func() (interface{}, error) {
+						entries, err := thunk()
+						if err != nil {
+							return nil, resolveError(p.Context, err)
+						}
+						if entries == nil {
+							entries = []models.AuditEntry{}
+						}
+						return entries, nil
+					}, nil
+				},
+			},
+		},
+	})
+
+	edgeType := graphql.NewObject(graphql.ObjectConfig{
+		Name: r.Type() + "Edge",
+		Fields: graphql.Fields{
+			"cursor": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"node":   &graphql.Field{Type: graphql.NewNonNull(objectType)},
+		},
+	})
+
+	connectionType := graphql.NewObject(graphql.ObjectConfig{
+		Name: r.Type() + "Connection",
+		Fields: graphql.Fields{
+			"totalCount": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Int),
+				Description: "Number of " + r.Name() + " matching the filter on all pages.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					c := p.Source.(*connection)
+					count, err := c.resource.Count(p.Context, c.filter)
+					if err != nil {
+						return nil, resolveError(p.Context, err)
+					}
+					return count, nil
+				},
+			},
+			"edges": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(edgeType))),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					c := p.Source.(*connection)
+					if err := c.load(p.Context); err != nil {
+						return nil, err
+					}
+					edges := make([]edge, 0, len(c.animals))
+					for i := range c.animals {
+						edges = append(edges, edge{Cursor: encodeCursor(c.animals[i].ID), Node: &c.animals[i]})
+					}
+					return edges, nil
+				},
+			},
+			"pageInfo": &graphql.Field{
+				Type: graphql.NewNonNull(pageInfoType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					c := p.Source.(*connection)
+					if err := c.load(p.Context); err != nil {
+						return nil, err
+					}
+					info := pageInfo{HasNextPage: c.hasNextPage}
+					if len(c.animals) > 0 {
+						cursor := encodeCursor(c.animals[len(c.animals)-1].ID)
+						info.EndCursor = &cursor
+					}
+					return info, nil
+				},
+			},
+		},
+	})
+
+	inputType := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: r.Type() + "Input",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"name":      &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"breed":     &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"color":     &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"birthdate": &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.DateTime)},
+			"weight":    &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.Int)},
+		},
+	})
+
+	query[singular] = &graphql.Field{
+		Type:        objectType,
+		Description: "Gets a " + singular + " by ID, or null when it does not exist.",
+		Args: graphql.FieldConfigArgument{
+			"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			id, err := uuid.Parse(p.Args["id"].(string))
+			if err != nil {
+				return nil, invalidID("id")
+			}
+			thunk := loadersFrom(p.Context).animals[r.Name()].Load(id)
+			return func() (interface{}, error) {
+				a, err := thunk()
+				if err != nil {
+					return nil, resolveError(p.Context, err)
+				}
+				if a == nil {
+					return nil, nil
+				}
+				return a, nil
+			}, nil
+		},
+	}
+
+	query[r.Name()] = &graphql.Field{
+		Type:        graphql.NewNonNull(connectionType),
+		Description: "Lists " + r.Name() + " a page at a time, ordered by ID.",
+		Args: graphql.FieldConfigArgument{
+			"filter": &graphql.ArgumentConfig{Type: animalFilterType},
+			"first":  &graphql.ArgumentConfig{Type: graphql.Int, Description: "Page size, at most 100.", DefaultValue: DefaultPageSize},
+			"after":  &graphql.ArgumentConfig{Type: graphql.String, Description: "endCursor of the previous page."},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			first, _ := p.Args["first"].(int)
+			if first < 1 || first > MaxPageSize {
+				return nil, &Error{Message: "first must be between 1 and 100", Code: problems.CodeInvalidQuery}
+			}
+
+			filter := animalFilter(p.Args["filter"])
+			page := *filter
+			page.Limit = first + 1
+			if after, ok := p.Args["after"].(string); ok && after != "" {
+				id, err := decodeCursor(after)
+				if err != nil {
+					return nil, &Error{Message: "after is not a valid cursor", Code: problems.CodeInvalidQuery}
+				}
+				page.After = &id
+			}
+
+			return &connection{resource: r, filter: filter, page: &page, first: first}, nil
+		},
+	}
+
+	mutation["create"+r.Type()] = &graphql.Field{
+		Type: graphql.NewNonNull(objectType),
+		Args: graphql.FieldConfigArgument{
+			"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(inputType)},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			a := animalInput(p.Args["input"])
+			a.ID = uuid.New()
+			if err := r.Validate(a); err != nil {
+				return nil, invalidInput(err)
+			}
+			if err := r.Add(p.Context, a); err != nil {
+				return nil, resolveError(p.Context, err)
+			}
+			return a, nil
+		},
+	}
+
+	mutation["update"+r.Type()] = &graphql.Field{
+		Type:        graphql.NewNonNull(objectType),
+		Description: "Replaces a " + singular + " and returns the stored row.",
+		Args: graphql.FieldConfigArgument{
+			"id":    &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+			"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(inputType)},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			id, err := uuid.Parse(p.Args["id"].(string))
+			if err != nil {
+				return nil, invalidID("id")
+			}
+			a := animalInput(p.Args["input"])
+			if err := r.Validate(a); err != nil {
+				return nil, invalidInput(err)
+			}
+			if err := r.Update(p.Context, id, a); err != nil {
+				return nil, resolveError(p.Context, err)
+			}
+			return a, nil
+		},
+	}
+
+	mutation["delete"+r.Type()] = &graphql.Field{
+		Type:        graphql.NewNonNull(graphql.ID),
+		Description: "Deletes a " + singular + " and returns its ID.",
+		Args: graphql.FieldConfigArgument{
+			"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			id, err := uuid.Parse(p.Args["id"].(string))
+			if err != nil {
+				return nil, invalidID("id")
+			}
+			if err := r.Delete(p.Context, id); err != nil {
+				return nil, resolveError(p.Context, err)
+			}
+			return id.String(), nil
+		},
+	}
+}
+
+// animalFilter converts the filter argument. It never returns nil so lists
+// are always ordered by ID.
+func animalFilter(arg interface{}) *services.AnimalFilter {
+	filter := new(services.AnimalFilter)
+	values, _ := arg.(map[string]interface{})
+	filter.Name, _ = values["name"].(string)
+	filter.Breed, _ = values["breed"].(string)
+	filter.Color, _ = values["color"].(string)
+	filter.Status, _ = values["status"].(string)
+	filter.MinWeight, _ = values["minWeight"].(int)
+	filter.MaxWeight, _ = values["maxWeight"].(int)
+	if bornAfter, ok := values["bornAfter"].(time.Time); ok {
+		filter.BornAfter = &bornAfter
+	}
+	if bornBefore, ok := values["bornBefore"].(time.Time); ok {
+		filter.BornBefore = &bornBefore
+	}
+	return filter
+}
+
+// animalInput converts a CatInput or DogInput argument. A birthdate that is
+// not an RFC 3339 time is left zero and fails validation.
+func animalInput(arg interface{}) *animal {
+	a := new(animal)
+	values, _ := arg.(map[string]interface{})
+	a.Name, _ = values["name"].(string)
+	a.Breed, _ = values["breed"].(string)
+	a.Color, _ = values["color"].(string)
+	a.Weight, _ = values["weight"].(int)
+	if birthdate, ok := values["birthdate"].(time.Time); ok {
+		a.Birthdate = birthdate
+	}
+	return a
+}
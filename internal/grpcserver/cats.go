@@ -0,0 +1,132 @@
+package grpcserver
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+	"github.com/one-byte-data/go-api-sample/internal/models"
+	"github.com/one-byte-data/go-api-sample/internal/services"
+	animalsv1 "github.com/one-byte-data/go-api-sample/pkg/proto/animals/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type catsServer struct {
+	animalsv1.UnimplementedCatsServiceServer
+	service services.CatsService
+}
+
+func (s *catsServer) ListCats(req *animalsv1.ListCatsRequest, stream animalsv1.CatsService_ListCatsServer) error {
+	ctx := stream.Context()
+	cats, err := s.service.Get(ctx, nil)
+	if err != nil {
+		return toStatus(ctx, err)
+	}
+	for i := range cats {
+		if err := stream.Send(catToProto(&cats[i])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *catsServer) GetCat(ctx context.Context, req *animalsv1.GetCatRequest) (*animalsv1.Cat, error) {
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, invalidID("id")
+	}
+
+	cat, err := s.service.GetOne(ctx, id)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return catToProto(cat), nil
+}
+
+func (s *catsServer) CreateCat(ctx context.Context, req *animalsv1.CreateCatRequest) (*animalsv1.Cat, error) {
+	cat, err := catFromProto(req.GetCat())
+	if err != nil {
+		return nil, err
+	}
+	if cat.ID == uuid.Nil {
+		cat.ID = uuid.New()
+	}
+
+	if _, err := s.service.Add(ctx, cat); err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return catToProto(cat), nil
+}
+
+func (s *catsServer) UpdateCat(ctx context.Context, req *animalsv1.UpdateCatRequest) (*animalsv1.Cat, error) {
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, invalidID("id")
+	}
+	cat, err := catFromProto(req.GetCat())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.service.Update(ctx, id, cat); err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return catToProto(cat), nil
+}
+
+func (s *catsServer) DeleteCat(ctx context.Context, req *animalsv1.DeleteCatRequest) (*animalsv1.DeleteCatResponse, error) {
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, invalidID("id")
+	}
+
+	if err := s.service.Delete(ctx, id); err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return &animalsv1.DeleteCatResponse{Id: id.String()}, nil
+}
+
+func (s *catsServer) CountCats(ctx context.Context, req *animalsv1.CountCatsRequest) (*animalsv1.CountCatsResponse, error) {
+	count, err := s.service.Count(ctx, nil)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return &animalsv1.CountCatsResponse{Count: count}, nil
+}
+
+func catToProto(cat *models.Cat) *animalsv1.Cat {
+	return &animalsv1.Cat{
+		Id:        cat.ID.String(),
+		Name:      cat.Name,
+		Breed:     cat.Breed,
+		Color:     cat.Color,
+		Birthdate: timestamppb.New(cat.Birthdate),
+		Weight:    int32(cat.Weight),
+	}
+}
+
+// catFromProto converts and validates a cat with the same rules as the REST
+// API's request bodies.
+func catFromProto(msg *animalsv1.Cat) (*models.Cat, error) {
+	cat := &models.Cat{
+		Name:   msg.GetName(),
+		Breed:  msg.GetBreed(),
+		Color:  msg.GetColor(),
+		Weight: int(msg.GetWeight()),
+	}
+	if msg.GetId() != "" {
+		id, err := uuid.Parse(msg.GetId())
+		if err != nil {
+			return nil, invalidID("cat.id")
+		}
+		cat.ID = id
+	}
+	if msg.GetBirthdate() != nil {
+		cat.Birthdate = msg.GetBirthdate().AsTime()
+	}
+
+	if err := binding.Validator.ValidateStruct(cat); err != nil {
+		return nil, invalidBody("cat", err)
+	}
+	return cat, nil
+}
@@ -0,0 +1,132 @@
+package grpcserver
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+	"github.com/one-byte-data/go-api-sample/internal/models"
+	"github.com/one-byte-data/go-api-sample/internal/services"
+	animalsv1 "github.com/one-byte-data/go-api-sample/pkg/proto/animals/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type dogsServer struct {
+	animalsv1.UnimplementedDogsServiceServer
+	service services.DogsService
+}
+
+func (s *dogsServer) ListDogs(req *animalsv1.ListDogsRequest, stream animalsv1.DogsService_ListDogsServer) error {
+	ctx := stream.Context()
+	dogs, err := s.service.Get(ctx, nil)
+	if err != nil {
+		return toStatus(ctx, err)
+	}
+	for i := range dogs {
+		if err := stream.Send(dogToProto(&dogs[i])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *dogsServer) GetDog(ctx context.Context, req *animalsv1.GetDogRequest) (*animalsv1.Dog, error) {
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, invalidID("id")
+	}
+
+	dog, err := s.service.GetOne(ctx, id)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return dogToProto(dog), nil
+}
+
+func (s *dogsServer) CreateDog(ctx context.Context, req *animalsv1.CreateDogRequest) (*animalsv1.Dog, error) {
+	dog, err := dogFromProto(req.GetDog())
+	if err != nil {
+		return nil, err
+	}
+	if dog.ID == uuid.Nil {
+		dog.ID = uuid.New()
+	}
+
+	if _, err := s.service.Add(ctx, dog); err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return dogToProto(dog), nil
+}
+
+func (s *dogsServer) UpdateDog(ctx context.Context, req *animalsv1.UpdateDogRequest) (*animalsv1.Dog, error) {
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, invalidID("id")
+	}
+	dog, err := dogFromProto(req.GetDog())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.service.Update(ctx, id, dog); err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return dogToProto(dog), nil
+}
+
+func (s *dogsServer) DeleteDog(ctx context.Context, req *animalsv1.DeleteDogRequest) (*animalsv1.DeleteDogResponse, error) {
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, invalidID("id")
+	}
+
+	if err := s.service.Delete(ctx, id); err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return &animalsv1.DeleteDogResponse{Id: id.String()}, nil
+}
+
+func (s *dogsServer) CountDogs(ctx context.Context, req *animalsv1.CountDogsRequest) (*animalsv1.CountDogsResponse, error) {
+	count, err := s.service.Count(ctx, nil)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return &animalsv1.CountDogsResponse{Count: count}, nil
+}
+
+func dogToProto(dog *models.Dog) *animalsv1.Dog {
+	return &animalsv1.Dog{
+		Id:        dog.ID.String(),
+		Name:      dog.Name,
+		Breed:     dog.Breed,
+		Color:     dog.Color,
+		Birthdate: timestamppb.New(dog.Birthdate),
+		Weight:    int32(dog.Weight),
+	}
+}
+
+// dogFromProto converts and validates a dog with the same rules as the REST
+// API's request bodies.
+func dogFromProto(msg *animalsv1.Dog) (*models.Dog, error) {
+	dog := &models.Dog{
+		Name:   msg.GetName(),
+		Breed:  msg.GetBreed(),
+		Color:  msg.GetColor(),
+		Weight: int(msg.GetWeight()),
+	}
+	if msg.GetId() != "" {
+		id, err := uuid.Parse(msg.GetId())
+		if err != nil {
+			return nil, invalidID("dog.id")
+		}
+		dog.ID = id
+	}
+	if msg.GetBirthdate() != nil {
+		dog.Birthdate = msg.GetBirthdate().AsTime()
+	}
+
+	if err := binding.Validator.ValidateStruct(dog); err != nil {
+		return nil, invalidBody("dog", err)
+	}
+	return dog, nil
+}
@@ -0,0 +1,77 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/one-byte-data/go-api-sample/internal/problems"
+	"github.com/one-byte-data/go-api-sample/internal/services"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// toStatus maps a service error to a gRPC status the way abortWithError maps
+// it to a problem. Internal errors are logged and not returned to the caller.
+func toStatus(ctx context.Context, err error) error {
+	switch {
+	case errors.Is(err, services.ErrNotFound):
+		return status.Error(codes.NotFound, "the resource does not exist")
+	case errors.Is(err, services.ErrInvalid):
+		problem := problems.Binding(err)
+		return invalidArgument(problem.Detail, problem.Errors)
+	case errors.Is(err, services.ErrInvalidTenant):
+		return invalidArgument("the tenant is not valid or does not exist", nil)
+	case errors.Is(err, services.ErrConflict):
+		return status.Error(codes.FailedPrecondition, "the change conflicts with the stored data")
+	case errors.Is(err, services.ErrUnavailable):
+		return status.Error(codes.Unavailable, "the database is unavailable, try again later")
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	default:
+		slog.ErrorContext(ctx, "grpc call failed", "error", err)
+		return status.Error(codes.Internal, "there was an error")
+	}
+}
+
+// invalidID is returned when field does not hold a UUID.
+func invalidID(field string) error {
+	return invalidArgument("the id is not valid", []problems.FieldError{
+		{Field: field, Rule: "uuid", Message: "must be a UUID"},
+	})
+}
+
+// invalidBody turns a validation error into INVALID_ARGUMENT with a
+// google.rpc.BadRequest detail. Field names are prefixed with the request
+// field holding the message, e.g. cat.weight.
+func invalidBody(prefix string, err error) error {
+	problem := problems.Binding(err)
+	fields := make([]problems.FieldError, 0, len(problem.Errors))
+	for _, field := range problem.Errors {
+		field.Field = prefix + "." + field.Field
+		fields = append(fields, field)
+	}
+	return invalidArgument(problem.Detail, fields)
+}
+
+func invalidArgument(message string, fields []problems.FieldError) error {
+	st := status.New(codes.InvalidArgument, message)
+	if len(fields) == 0 {
+		return st.Err()
+	}
+
+	badRequest := &errdetails.BadRequest{}
+	for _, field := range fields {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       field.Field,
+			Description: field.Message,
+		})
+	}
+	if detailed, err := st.WithDetails(badRequest); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
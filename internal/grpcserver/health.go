@@ -0,0 +1,52 @@
+package grpcserver
+
+import (
+	"context"
+	"time"
+
+	"github.com/one-byte-data/go-api-sample/internal/repositories"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Health serves the standard health service. Like the readiness probe of
+// the REST API it reports NOT_SERVING while the database cannot be pinged,
+// e.g. while a repositories.Monitor is degraded.
+type Health struct {
+	server *health.Server
+	repo   repositories.Repository
+}
+
+// NewHealth returns a health service for repo that is NOT_SERVING until
+// Check has succeeded.
+func NewHealth(repo repositories.Repository) *Health {
+	h := &Health{server: health.NewServer(), repo: repo}
+	h.server.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	return h
+}
+
+// Check pings the database and updates the status of the server.
+func (h *Health) Check(ctx context.Context) {
+	status := healthpb.HealthCheckResponse_SERVING
+	if err := h.repo.Ping(ctx); err != nil {
+		status = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	h.server.SetServingStatus("", status)
+}
+
+// Watch checks the database every interval until ctx is done.
+func (h *Health) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			checkCtx, cancel := context.WithTimeout(ctx, interval)
+			h.Check(checkCtx)
+			cancel()
+		}
+	}
+}
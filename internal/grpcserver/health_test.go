@@ -0,0 +1,50 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/one-byte-data/go-api-sample/internal/models"
+	"github.com/one-byte-data/go-api-sample/internal/repositories"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestHealth(t *testing.T) {
+	refused := errors.New("connection refused")
+	connect := refused
+	monitor := repositories.NewMonitor(repositories.NewMemory(), func(ctx context.Context) error {
+		return connect
+	})
+	health := NewHealth(monitor)
+	conn := dialHealth(t,
+		newMemoryService(func(cat *models.Cat) uuid.UUID { return cat.ID }),
+		newMemoryService(func(dog *models.Dog) uuid.UUID { return dog.ID }),
+		health)
+	client := healthpb.NewHealthClient(conn)
+
+	tests := []struct {
+		name    string
+		connect error
+		want    healthpb.HealthCheckResponse_ServingStatus
+	}{
+		{name: "Should not be serving without the database", connect: refused, want: healthpb.HealthCheckResponse_NOT_SERVING},
+		{name: "Should be serving once the database is available", connect: nil, want: healthpb.HealthCheckResponse_SERVING},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			connect = tt.connect
+			monitor.Check(context.Background())
+			health.Check(context.Background())
+
+			got, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{})
+			if err != nil {
+				t.Fatalf("Health.Check() error = %v", err)
+			}
+			if got.Status != tt.want {
+				t.Errorf("Health.Check() = %v, want %v", got.Status, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package grpcserver
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/one-byte-data/go-api-sample/internal/contexts"
+	"github.com/one-byte-data/go-api-sample/internal/middlewares"
+	"github.com/one-byte-data/go-api-sample/internal/services"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	actorKey          = strings.ToLower(middlewares.ActorHeader)
+	apiKeyKey         = strings.ToLower(middlewares.APIKeyHeader)
+	requestIDKey      = strings.ToLower(middlewares.RequestIDHeader)
+	readYourWritesKey = strings.ToLower(middlewares.ReadYourWritesHeader)
+	tenantKey         = strings.ToLower(middlewares.TenantHeader)
+)
+
+// withMetadata does for a call what the RequestID, Actor and ReadYourWrites
+// middlewares do for a request: it stores the x-request-id, x-actor and
+// x-read-your-writes metadata on the context and echoes the request ID back
+// in the response header.
+func withMetadata(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	requestID := first(md, requestIDKey)
+	if !middlewares.ValidRequestID(requestID) {
+		requestID = uuid.New().String()
+	}
+	ctx = contexts.WithRequestID(ctx, requestID)
+	_ = grpc.SetHeader(ctx, metadata.Pairs(requestIDKey, requestID))
+
+	if actor := first(md, actorKey); actor != "" {
+		ctx = contexts.WithActor(ctx, actor)
+	}
+	if middlewares.ParseReadYourWrites(first(md, readYourWritesKey)) {
+		ctx = contexts.WithReadYourWrites(ctx)
+	}
+	return ctx
+}
+
+func first(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func unaryContext(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	return handler(withMetadata(ctx), req)
+}
+
+func streamContext(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &contextStream{ServerStream: ss, ctx: withMetadata(ss.Context())})
+}
+
+// contextStream replaces the context of a server stream.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
+
+// unaryTenant does for a call what the TenantAuth middleware and the
+// TenantScope handler do for a request: it scopes the context to the tenant
+// of the x-api-key metadata, which the x-tenant-id metadata may only repeat.
+func unaryTenant(tenants services.TenantsService, credentials middlewares.TenantCredentials) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := withTenant(ctx, tenants, credentials)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func streamTenant(tenants services.TenantsService, credentials middlewares.TenantCredentials) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := withTenant(ss.Context(), tenants, credentials)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+func withTenant(ctx context.Context, tenants services.TenantsService, credentials middlewares.TenantCredentials) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	requested := first(md, tenantKey)
+	tenant, ok := middlewares.ResolveTenant(credentials.Claim(first(md, apiKeyKey), requested), requested)
+	if !ok {
+		return nil, status.Error(codes.PermissionDenied, "the credentials do not allow acting for this tenant")
+	}
+	tenantCtx, err := services.WithTenant(ctx, tenants, tenant)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+	return tenantCtx, nil
+}
+
+func unaryLogger(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	logCall(ctx, info.FullMethod, start, err)
+	return resp, err
+}
+
+func streamLogger(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := handler(srv, ss)
+	logCall(ss.Context(), info.FullMethod, start, err)
+	return err
+}
+
+// logCall writes one structured line per call, like the Logger middleware
+// does per request.
+func logCall(ctx context.Context, method string, start time.Time, err error) {
+	code := status.Code(err)
+	level := slog.LevelInfo
+	switch code {
+	case codes.OK:
+	case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unavailable:
+		level = slog.LevelError
+	default:
+		level = slog.LevelWarn
+	}
+	slog.Log(ctx, level, "grpc call",
+		"method", method,
+		"code", code.String(),
+		"duration", time.Since(start),
+	)
+}
+
+func unaryRecovery(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = recoverPanic(ctx, recovered)
+		}
+	}()
+	return handler(ctx, req)
+}
+
+func streamRecovery(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = recoverPanic(ss.Context(), recovered)
+		}
+	}()
+	return handler(srv, ss)
+}
+
+// recoverPanic logs the stack trace and turns the panic into INTERNAL, like
+// the Recovery middleware.
+func recoverPanic(ctx context.Context, recovered interface{}) error {
+	slog.ErrorContext(ctx, "recovered from panic",
+		"panic", fmt.Sprint(recovered),
+		"stack", string(debug.Stack()),
+	)
+	return status.Error(codes.Internal, "there was an error")
+}
@@ -0,0 +1,51 @@
+package grpcserver
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/one-byte-data/go-api-sample/internal/middlewares"
+	"github.com/one-byte-data/go-api-sample/internal/services"
+	animalsv1 "github.com/one-byte-data/go-api-sample/pkg/proto/animals/v1"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+// DefaultPort is the port the gRPC API listens on unless GRPC_PORT says
+// otherwise.
+const DefaultPort = 9090
+
+// PortFromEnv reads GRPC_PORT. A port of 0 disables the gRPC API.
+func PortFromEnv() (int, error) {
+	value := os.Getenv("GRPC_PORT")
+	if value == "" {
+		return DefaultPort, nil
+	}
+	port, err := strconv.Atoi(value)
+	if err != nil || port < 0 || port > 65535 {
+		return 0, fmt.Errorf("GRPC_PORT must be a port number, got %q", value)
+	}
+	return port, nil
+}
+
+// New returns a gRPC server exposing the cats and dogs services, the standard
+// health service backed by health and server reflection. Calls are scoped to the tenant of
+// the API key in their x-api-key metadata, which must exist in tenants, and
+// to the default tenant without one. It should be given the same service
+// instances as the REST API so both share caches.
+func New(cats services.CatsService, dogs services.DogsService, tenants services.TenantsService, credentials middlewares.TenantCredentials, health *Health) *grpc.Server {
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(unaryContext, unaryLogger, unaryRecovery, unaryTenant(tenants, credentials)),
+		grpc.ChainStreamInterceptor(streamContext, streamLogger, streamRecovery, streamTenant(tenants, credentials)),
+	)
+
+	animalsv1.RegisterCatsServiceServer(server, &catsServer{service: cats})
+	animalsv1.RegisterDogsServiceServer(server, &dogsServer{service: dogs})
+
+	healthpb.RegisterHealthServer(server, health.server)
+	reflection.Register(server)
+
+	return server
+}
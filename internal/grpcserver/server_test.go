@@ -0,0 +1,431 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/one-byte-data/go-api-sample/internal/contexts"
+	"github.com/one-byte-data/go-api-sample/internal/middlewares"
+	"github.com/one-byte-data/go-api-sample/internal/models"
+	"github.com/one-byte-data/go-api-sample/internal/repositories"
+	"github.com/one-byte-data/go-api-sample/internal/services"
+	animalsv1 "github.com/one-byte-data/go-api-sample/pkg/proto/animals/v1"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// memoryService is an in-memory services.CatsService and services.DogsService
+// that remembers the actor and tenant of the last write.
+type memoryService[T any] struct {
+	mu         sync.Mutex
+	items      map[uuid.UUID]T
+	order      []uuid.UUID
+	id         func(*T) uuid.UUID
+	lastActor  string
+	lastTenant string
+	err        error
+}
+
+func newMemoryService[T any](id func(*T) uuid.UUID) *memoryService[T] {
+	return &memoryService[T]{items: make(map[uuid.UUID]T), id: id}
+}
+
+func (s *memoryService[T]) Add(ctx context.Context, item *T) (*uuid.UUID, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return nil, s.err
+	}
+	id := s.id(item)
+	s.items[id] = *item
+	s.order = append(s.order, id)
+	s.lastActor = contexts.Actor(ctx)
+	s.lastTenant = contexts.Tenant(ctx)
+	return &id, nil
+}
+
+func (s *memoryService[T]) Count(ctx context.Context, filter interface{}) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return int64(len(s.items)), s.err
+}
+
+func (s *memoryService[T]) Delete(ctx context.Context, id uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.items[id]; !ok {
+		return fmt.Errorf("row with id=%v cannot be deleted because it doesn't exist: %w", id, services.ErrNotFound)
+	}
+	delete(s.items, id)
+	return nil
+}
+
+func (s *memoryService[T]) Get(ctx context.Context, filter interface{}) ([]T, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return nil, s.err
+	}
+	items := make([]T, 0, len(s.items))
+	for _, id := range s.order {
+		if item, ok := s.items[id]; ok {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+func (s *memoryService[T]) GetOne(ctx context.Context, id uuid.UUID) (*T, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	item, ok := s.items[id]
+	if !ok {
+		return nil, services.ErrNotFound
+	}
+	return &item, nil
+}
+
+func (s *memoryService[T]) Update(ctx context.Context, id uuid.UUID, item *T) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.items[id]; !ok {
+		return fmt.Errorf("row with id=%v cannot be updated because it doesn't exist: %w", id, services.ErrNotFound)
+	}
+	updated := *item
+	s.items[id] = updated
+	*item = updated
+	return nil
+}
+
+func (s *memoryService[T]) Transition(ctx context.Context, id uuid.UUID, status string) (*models.Transition, error) {
+	return nil, fmt.Errorf("Transition should not be called, the gRPC API has no transitions")
+}
+
+func (s *memoryService[T]) Transitions(ctx context.Context, id uuid.UUID) ([]models.Transition, error) {
+	return nil, fmt.Errorf("Transitions should not be called, the gRPC API has no transitions")
+}
+
+// dial serves cats and dogs to a client. The shelter-a tenant exists besides
+// the default one, and is reached with the API key key-a.
+func dial(t *testing.T, cats services.CatsService, dogs services.DogsService) *grpc.ClientConn {
+	t.Helper()
+	health := NewHealth(repositories.NewMemory())
+	health.Check(context.Background())
+	return dialHealth(t, cats, dogs, health)
+}
+
+func dialHealth(t *testing.T, cats services.CatsService, dogs services.DogsService, health *Health) *grpc.ClientConn {
+	t.Helper()
+
+	tenants := services.NewTenantsService(repositories.NewMemory())
+	if err := tenants.Add(context.Background(), &models.Tenant{ID: "shelter-a", Name: "Shelter A"}); err != nil {
+		t.Fatalf("TenantsService.Add() error = %v", err)
+	}
+
+	listener := bufconn.Listen(1024 * 1024)
+	server := New(cats, dogs, tenants, middlewares.TenantCredentials{
+		AdminKey: "admin",
+		Keys:     map[string]string{"shelter-a": "key-a"},
+	}, health)
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("grpc.DialContext() error = %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestPortFromEnv(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    int
+		wantErr bool
+	}{
+		{name: "Should use the default", value: "", want: DefaultPort},
+		{name: "Should read a port", value: "50051", want: 50051},
+		{name: "Should allow disabling the API", value: "0", want: 0},
+		{name: "Should not accept a name", value: "grpc", wantErr: true},
+		{name: "Should not accept a port out of range", value: "70000", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("GRPC_PORT", tt.value)
+
+			got, err := PortFromEnv()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PortFromEnv() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("PortFromEnv() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCatsServer(t *testing.T) {
+	cats := newMemoryService(func(cat *models.Cat) uuid.UUID { return cat.ID })
+	dogs := newMemoryService(func(dog *models.Dog) uuid.UUID { return dog.ID })
+	client := animalsv1.NewCatsServiceClient(dial(t, cats, dogs))
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-actor", "grpc-test")
+	birthdate := timestamppb.New(time.Date(2019, 5, 1, 0, 0, 0, 0, time.UTC))
+
+	var header metadata.MD
+	created, err := client.CreateCat(ctx, &animalsv1.CreateCatRequest{
+		Cat: &animalsv1.Cat{Name: "Nacho", Breed: "Tabby", Color: "Orange", Birthdate: birthdate, Weight: 5},
+	}, grpc.Header(&header))
+	if err != nil {
+		t.Fatalf("CreateCat() error = %v", err)
+	}
+	if _, err := uuid.Parse(created.GetId()); err != nil {
+		t.Errorf("CreateCat() id = %q, want a UUID", created.GetId())
+	}
+	if cats.lastActor != "grpc-test" {
+		t.Errorf("CreateCat() actor = %q, want %q", cats.lastActor, "grpc-test")
+	}
+	if len(header.Get("x-request-id")) != 1 {
+		t.Errorf("CreateCat() did not return an x-request-id header")
+	}
+
+	tests := []struct {
+		name     string
+		call     func() error
+		wantCode codes.Code
+	}{
+		{
+			name: "Should get a cat",
+			call: func() error {
+				cat, err := client.GetCat(ctx, &animalsv1.GetCatRequest{Id: created.GetId()})
+				if err == nil && cat.GetName() != "Nacho" {
+					return fmt.Errorf("name = %q", cat.GetName())
+				}
+				return err
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name: "Should not get a missing cat",
+			call: func() error {
+				_, err := client.GetCat(ctx, &animalsv1.GetCatRequest{Id: uuid.New().String()})
+				return err
+			},
+			wantCode: codes.NotFound,
+		},
+		{
+			name: "Should not accept an invalid id",
+			call: func() error {
+				_, err := client.GetCat(ctx, &animalsv1.GetCatRequest{Id: "nacho"})
+				return err
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "Should update a cat",
+			call: func() error {
+				cat, err := client.UpdateCat(ctx, &animalsv1.UpdateCatRequest{
+					Id:  created.GetId(),
+					Cat: &animalsv1.Cat{Name: "Nacho", Breed: "Tabby", Color: "Orange", Birthdate: birthdate, Weight: 6},
+				})
+				if err == nil && cat.GetWeight() != 6 {
+					return fmt.Errorf("weight = %d", cat.GetWeight())
+				}
+				return err
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name: "Should count cats",
+			call: func() error {
+				count, err := client.CountCats(ctx, &animalsv1.CountCatsRequest{})
+				if err == nil && count.GetCount() != 1 {
+					return fmt.Errorf("count = %d", count.GetCount())
+				}
+				return err
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name: "Should not update a missing cat",
+			call: func() error {
+				_, err := client.UpdateCat(ctx, &animalsv1.UpdateCatRequest{
+					Id:  uuid.New().String(),
+					Cat: &animalsv1.Cat{Name: "Nacho", Breed: "Tabby", Color: "Orange", Birthdate: birthdate, Weight: 6},
+				})
+				return err
+			},
+			wantCode: codes.NotFound,
+		},
+		{
+			name: "Should delete a cat",
+			call: func() error {
+				_, err := client.DeleteCat(ctx, &animalsv1.DeleteCatRequest{Id: created.GetId()})
+				return err
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name: "Should not delete a missing cat",
+			call: func() error {
+				_, err := client.DeleteCat(ctx, &animalsv1.DeleteCatRequest{Id: created.GetId()})
+				return err
+			},
+			wantCode: codes.NotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+			if got := status.Code(err); got != tt.wantCode {
+				t.Errorf("code = %v, want %v (error = %v)", got, tt.wantCode, err)
+			}
+		})
+	}
+}
+
+func TestCatsServer_CreateCatInvalid(t *testing.T) {
+	cats := newMemoryService(func(cat *models.Cat) uuid.UUID { return cat.ID })
+	dogs := newMemoryService(func(dog *models.Dog) uuid.UUID { return dog.ID })
+	client := animalsv1.NewCatsServiceClient(dial(t, cats, dogs))
+
+	_, err := client.CreateCat(context.Background(), &animalsv1.CreateCatRequest{
+		Cat: &animalsv1.Cat{Name: "Nacho", Breed: "Tabby", Color: "Orange", Weight: 100},
+	})
+	st := status.Convert(err)
+	if st.Code() != codes.InvalidArgument {
+		t.Fatalf("CreateCat() code = %v, want %v", st.Code(), codes.InvalidArgument)
+	}
+
+	fields := make(map[string]bool)
+	for _, detail := range st.Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			for _, violation := range badRequest.GetFieldViolations() {
+				fields[violation.GetField()] = true
+			}
+		}
+	}
+	for _, want := range []string{"cat.birthdate", "cat.weight"} {
+		if !fields[want] {
+			t.Errorf("CreateCat() field violations = %v, want %v", fields, want)
+		}
+	}
+}
+
+func TestCatsServer_Tenant(t *testing.T) {
+	cats := newMemoryService(func(cat *models.Cat) uuid.UUID { return cat.ID })
+	dogs := newMemoryService(func(dog *models.Dog) uuid.UUID { return dog.ID })
+	client := animalsv1.NewCatsServiceClient(dial(t, cats, dogs))
+	birthdate := timestamppb.New(time.Date(2019, 5, 1, 0, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name       string
+		apiKey     string
+		tenant     string
+		wantCode   codes.Code
+		wantTenant string
+	}{
+		{name: "Should use the default tenant", tenant: "", wantCode: codes.OK, wantTenant: contexts.DefaultTenant},
+		{name: "Should use the tenant of the API key", apiKey: "key-a", wantCode: codes.OK, wantTenant: "shelter-a"},
+		{name: "Should accept metadata matching the API key", apiKey: "key-a", tenant: "shelter-a", wantCode: codes.OK, wantTenant: "shelter-a"},
+		{name: "Should not accept a tenant without credentials", tenant: "shelter-a", wantCode: codes.PermissionDenied},
+		{name: "Should not accept a tenant other than the API key's", apiKey: "key-a", tenant: "default", wantCode: codes.PermissionDenied},
+		{name: "Should let the admin key act for a tenant", apiKey: "admin", tenant: "shelter-a", wantCode: codes.OK, wantTenant: "shelter-a"},
+		{name: "Should not accept an unknown tenant", apiKey: "admin", tenant: "shelter-b", wantCode: codes.InvalidArgument},
+		{name: "Should not accept an invalid tenant", apiKey: "admin", tenant: "Shelter A", wantCode: codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cats.lastTenant = ""
+			ctx := context.Background()
+			if tt.tenant != "" {
+				ctx = metadata.AppendToOutgoingContext(ctx, "x-tenant-id", tt.tenant)
+			}
+			if tt.apiKey != "" {
+				ctx = metadata.AppendToOutgoingContext(ctx, "x-api-key", tt.apiKey)
+			}
+
+			_, err := client.CreateCat(ctx, &animalsv1.CreateCatRequest{
+				Cat: &animalsv1.Cat{Name: "Nacho", Breed: "Tabby", Color: "Orange", Birthdate: birthdate, Weight: 5},
+			})
+			if got := status.Code(err); got != tt.wantCode {
+				t.Fatalf("CreateCat() code = %v, want %v (error = %v)", got, tt.wantCode, err)
+			}
+			if cats.lastTenant != tt.wantTenant {
+				t.Errorf("CreateCat() tenant = %q, want %q", cats.lastTenant, tt.wantTenant)
+			}
+		})
+	}
+}
+
+func TestCatsServer_InternalError(t *testing.T) {
+	cats := newMemoryService(func(cat *models.Cat) uuid.UUID { return cat.ID })
+	cats.err = errors.New("connection refused")
+	dogs := newMemoryService(func(dog *models.Dog) uuid.UUID { return dog.ID })
+	client := animalsv1.NewCatsServiceClient(dial(t, cats, dogs))
+
+	_, err := client.CountCats(context.Background(), &animalsv1.CountCatsRequest{})
+	st := status.Convert(err)
+	if st.Code() != codes.Internal || st.Message() != "there was an error" {
+		t.Errorf("CountCats() status = %v %q, want %v without the cause", st.Code(), st.Message(), codes.Internal)
+	}
+}
+
+func TestDogsServer_ListDogs(t *testing.T) {
+	cats := newMemoryService(func(cat *models.Cat) uuid.UUID { return cat.ID })
+	dogs := newMemoryService(func(dog *models.Dog) uuid.UUID { return dog.ID })
+	client := animalsv1.NewDogsServiceClient(dial(t, cats, dogs))
+
+	birthdate := time.Date(2019, 5, 1, 0, 0, 0, 0, time.UTC)
+	names := []string{"Rex", "Fido", "Bella"}
+	for _, name := range names {
+		_, _ = dogs.Add(context.Background(), &models.Dog{
+			ID: uuid.New(), Name: name, Breed: "Boxer", Color: "Brown", Birthdate: birthdate, Weight: 30,
+		})
+	}
+
+	stream, err := client.ListDogs(context.Background(), &animalsv1.ListDogsRequest{})
+	if err != nil {
+		t.Fatalf("ListDogs() error = %v", err)
+	}
+	got := make([]string, 0)
+	for {
+		dog, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("ListDogs() Recv() error = %v", err)
+		}
+		if !dog.GetBirthdate().AsTime().Equal(birthdate) {
+			t.Errorf("ListDogs() birthdate = %v, want %v", dog.GetBirthdate().AsTime(), birthdate)
+		}
+		got = append(got, dog.GetName())
+	}
+	if fmt.Sprint(got) != fmt.Sprint(names) {
+		t.Errorf("ListDogs() = %v, want %v", got, names)
+	}
+}
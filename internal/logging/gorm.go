@@ -0,0 +1,70 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// SlowQueryThreshold is the duration above which statements are logged as
+// warnings.
+const SlowQueryThreshold = 200 * time.Millisecond
+
+// GormLogger writes GORM's log output through slog so statements carry the
+// request ID of the context they ran with.
+type GormLogger struct {
+	level logger.LogLevel
+}
+
+func NewGormLogger() logger.Interface {
+	return &GormLogger{
+		level: logger.Warn,
+	}
+}
+
+func (l *GormLogger) LogMode(level logger.LogLevel) logger.Interface {
+	return &GormLogger{
+		level: level,
+	}
+}
+
+func (l *GormLogger) Info(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= logger.Info {
+		slog.InfoContext(ctx, msg, "args", args)
+	}
+}
+
+func (l *GormLogger) Warn(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= logger.Warn {
+		slog.WarnContext(ctx, msg, "args", args)
+	}
+}
+
+func (l *GormLogger) Error(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= logger.Error {
+		slog.ErrorContext(ctx, msg, "args", args)
+	}
+}
+
+func (l *GormLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	if l.level <= logger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+	sql, rows := fc()
+	attrs := []any{"sql", sql, "rows", rows, "duration", elapsed}
+
+	switch {
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound) && l.level >= logger.Error:
+		slog.ErrorContext(ctx, "query failed", append(attrs, "error", err)...)
+	case elapsed > SlowQueryThreshold && l.level >= logger.Warn:
+		slog.WarnContext(ctx, "slow query", attrs...)
+	default:
+		slog.DebugContext(ctx, "query", attrs...)
+	}
+}
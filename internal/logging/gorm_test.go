@@ -0,0 +1,87 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/one-byte-data/go-api-sample/internal/contexts"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestGormLogger_Trace(t *testing.T) {
+	defaultLogger := slog.Default()
+	defer slog.SetDefault(defaultLogger)
+
+	ctx := contexts.WithRequestID(context.Background(), "abc-123")
+
+	tests := []struct {
+		name      string
+		level     logger.LogLevel
+		begin     time.Time
+		err       error
+		wantLevel interface{}
+	}{
+		{
+			name:      "Should log a failed query as an error",
+			level:     logger.Warn,
+			begin:     time.Now(),
+			err:       errors.New("connection reset"),
+			wantLevel: "ERROR",
+		},
+		{
+			name:      "Should log a slow query as a warning",
+			level:     logger.Warn,
+			begin:     time.Now().Add(-time.Second),
+			wantLevel: "WARN",
+		},
+		{
+			name:      "Should not log a missing record as an error",
+			level:     logger.Warn,
+			begin:     time.Now(),
+			err:       gorm.ErrRecordNotFound,
+			wantLevel: "DEBUG",
+		},
+		{
+			name:      "Should not log when silent",
+			level:     logger.Silent,
+			begin:     time.Now(),
+			err:       errors.New("connection reset"),
+			wantLevel: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buffer bytes.Buffer
+			Setup(&buffer, slog.LevelDebug)
+
+			l := NewGormLogger().LogMode(tt.level)
+			l.Trace(ctx, tt.begin, func() (string, int64) {
+				return `SELECT * FROM "cats"`, 0
+			}, tt.err)
+
+			if tt.wantLevel == nil {
+				if buffer.Len() != 0 {
+					t.Errorf("GormLogger.Trace() logged %q, want nothing", buffer.String())
+				}
+				return
+			}
+
+			line := make(map[string]interface{})
+			if err := json.Unmarshal(buffer.Bytes(), &line); err != nil {
+				t.Fatalf("GormLogger.Trace() wrote %q, want a JSON line", buffer.String())
+			}
+			if line["level"] != tt.wantLevel {
+				t.Errorf("GormLogger.Trace() level = %v, want %v", line["level"], tt.wantLevel)
+			}
+			if line["request_id"] != "abc-123" {
+				t.Errorf("GormLogger.Trace() request_id = %v, want abc-123", line["request_id"])
+			}
+		})
+	}
+}
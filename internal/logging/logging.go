@@ -0,0 +1,87 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/one-byte-data/go-api-sample/internal/contexts"
+)
+
+// Redacted replaces the value of sensitive headers in log lines.
+const Redacted = "[REDACTED]"
+
+// SensitiveHeaders are never written to the log in clear text.
+var SensitiveHeaders = []string{
+	"Authorization",
+	"Cookie",
+	"Proxy-Authorization",
+	"Set-Cookie",
+	"X-Api-Key",
+}
+
+// ParseLevel turns debug, info, warn or error into a slog level. An empty
+// value means info.
+func ParseLevel(value string) (slog.Level, error) {
+	var level slog.Level
+	if value == "" {
+		return slog.LevelInfo, nil
+	}
+	if err := level.UnmarshalText([]byte(value)); err != nil {
+		return level, fmt.Errorf("unknown log level %q", value)
+	}
+	return level, nil
+}
+
+// LevelFromEnv reads the log level from LOG_LEVEL.
+func LevelFromEnv() (slog.Level, error) {
+	return ParseLevel(os.Getenv("LOG_LEVEL"))
+}
+
+// Setup makes a JSON logger writing to w the default slog logger. Every
+// record logged with a context carries the request ID of that context.
+func Setup(w io.Writer, level slog.Level) *slog.Logger {
+	logger := slog.New(&contextHandler{
+		Handler: slog.NewJSONHandler(w, &slog.HandlerOptions{Level: level}),
+	})
+	slog.SetDefault(logger)
+	return logger
+}
+
+// RedactHeaders returns a copy of the headers with sensitive values replaced.
+func RedactHeaders(header http.Header) map[string]string {
+	redacted := make(map[string]string, len(header))
+	for key, values := range header {
+		redacted[key] = strings.Join(values, ", ")
+	}
+	for _, key := range SensitiveHeaders {
+		key = http.CanonicalHeaderKey(key)
+		if _, ok := redacted[key]; ok {
+			redacted[key] = Redacted
+		}
+	}
+	return redacted
+}
+
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h *contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if requestID := contexts.RequestID(ctx); requestID != "" {
+		record.AddAttrs(slog.String("request_id", requestID))
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,124 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/one-byte-data/go-api-sample/internal/contexts"
+)
+
+func TestParseLevel(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    slog.Level
+		wantErr bool
+	}{
+		{name: "Should default to info", value: "", want: slog.LevelInfo},
+		{name: "Should parse debug", value: "debug", want: slog.LevelDebug},
+		{name: "Should parse upper case", value: "WARN", want: slog.LevelWarn},
+		{name: "Should not parse an unknown level", value: "verbose", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseLevel(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseLevel() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("ParseLevel() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedactHeaders(t *testing.T) {
+	tests := []struct {
+		name   string
+		header http.Header
+		want   map[string]string
+	}{
+		{
+			name: "Should redact sensitive headers",
+			header: http.Header{
+				"Authorization": {"Bearer secret"},
+				"X-Api-Key":     {"key"},
+				"Content-Type":  {"application/json"},
+			},
+			want: map[string]string{
+				"Authorization": Redacted,
+				"X-Api-Key":     Redacted,
+				"Content-Type":  "application/json",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RedactHeaders(tt.header); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RedactHeaders() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetup(t *testing.T) {
+	defaultLogger := slog.Default()
+	defer slog.SetDefault(defaultLogger)
+
+	tests := []struct {
+		name          string
+		ctx           context.Context
+		level         slog.Level
+		wantRequestID interface{}
+		wantLogged    bool
+	}{
+		{
+			name:          "Should add the request ID of the context",
+			ctx:           contexts.WithRequestID(context.Background(), "abc-123"),
+			level:         slog.LevelInfo,
+			wantRequestID: "abc-123",
+			wantLogged:    true,
+		},
+		{
+			name:          "Should not add a request ID without one",
+			ctx:           context.Background(),
+			level:         slog.LevelInfo,
+			wantRequestID: nil,
+			wantLogged:    true,
+		},
+		{
+			name:       "Should not log below the level",
+			ctx:        context.Background(),
+			level:      slog.LevelWarn,
+			wantLogged: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buffer bytes.Buffer
+			Setup(&buffer, tt.level)
+			slog.With("component", "test").InfoContext(tt.ctx, "hello")
+
+			if !tt.wantLogged {
+				if buffer.Len() != 0 {
+					t.Errorf("Setup() logged %q, want nothing", buffer.String())
+				}
+				return
+			}
+
+			line := make(map[string]interface{})
+			if err := json.Unmarshal(buffer.Bytes(), &line); err != nil {
+				t.Fatalf("Setup() wrote %q, want a JSON line", buffer.String())
+			}
+			if line["request_id"] != tt.wantRequestID {
+				t.Errorf("Setup() request_id = %v, want %v", line["request_id"], tt.wantRequestID)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package metrics
+
+import (
+	"sync"
+
+	"github.com/one-byte-data/go-api-sample/internal/cache"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	cacheHitsDesc      = prometheus.NewDesc(namespace+"_cache_hits_total", "Number of cache lookups that found an entry.", []string{"cache"}, nil)
+	cacheMissesDesc    = prometheus.NewDesc(namespace+"_cache_misses_total", "Number of cache lookups that found no entry.", []string{"cache"}, nil)
+	cacheEvictionsDesc = prometheus.NewDesc(namespace+"_cache_evictions_total", "Number of entries evicted because a cache was full.", []string{"cache"}, nil)
+	cacheEntriesDesc   = prometheus.NewDesc(namespace+"_cache_entries", "Number of entries in a cache.", []string{"cache"}, nil)
+)
+
+var caches = &cacheCollector{
+	reporters: make(map[string]cache.Reporter),
+}
+
+// TrackCache publishes the statistics of a cache. A cache tracked under the
+// name of an earlier one replaces it.
+func TrackCache(reporter cache.Reporter) {
+	caches.mu.Lock()
+	defer caches.mu.Unlock()
+
+	caches.reporters[reporter.Stats().Name] = reporter
+}
+
+type cacheCollector struct {
+	mu        sync.RWMutex
+	reporters map[string]cache.Reporter
+}
+
+func (c *cacheCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- cacheHitsDesc
+	ch <- cacheMissesDesc
+	ch <- cacheEvictionsDesc
+	ch <- cacheEntriesDesc
+}
+
+func (c *cacheCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for name, reporter := range c.reporters {
+		stats := reporter.Stats()
+		ch <- prometheus.MustNewConstMetric(cacheHitsDesc, prometheus.CounterValue, float64(stats.Hits), name)
+		ch <- prometheus.MustNewConstMetric(cacheMissesDesc, prometheus.CounterValue, float64(stats.Misses), name)
+		ch <- prometheus.MustNewConstMetric(cacheEvictionsDesc, prometheus.CounterValue, float64(stats.Evictions), name)
+		ch <- prometheus.MustNewConstMetric(cacheEntriesDesc, prometheus.GaugeValue, float64(stats.Size), name)
+	}
+}
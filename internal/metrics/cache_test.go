@@ -0,0 +1,24 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/one-byte-data/go-api-sample/internal/cache"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestTrackCache(t *testing.T) {
+	c := cache.New[string, int]("test", cache.Config{Size: 1, TTL: 1})
+	c.Get("missing")
+	TrackCache(c)
+
+	if got := testutil.CollectAndCount(caches, "go_api_sample_cache_misses_total"); got != 1 {
+		t.Errorf("TrackCache() published %v series, want 1", got)
+	}
+
+	// Tracking a new cache under the same name replaces the old one.
+	TrackCache(cache.New[string, int]("test", cache.Config{Size: 1, TTL: 1}))
+	if got := testutil.CollectAndCount(caches, "go_api_sample_cache_misses_total"); got != 1 {
+		t.Errorf("TrackCache() published %v series, want 1", got)
+	}
+}
@@ -0,0 +1,92 @@
+package metrics
+
+import (
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"gorm.io/gorm"
+)
+
+const startTimeKey = "metrics:start_time"
+
+// GormPlugin records the duration and errors of every statement GORM runs.
+type GormPlugin struct{}
+
+func (p *GormPlugin) Name() string {
+	return "metrics"
+}
+
+func (p *GormPlugin) Initialize(db *gorm.DB) error {
+	callbacks := []struct {
+		operation string
+		before    registerer
+		after     registerer
+	}{
+		{"create", db.Callback().Create().Before("gorm:create"), db.Callback().Create().After("gorm:create")},
+		{"query", db.Callback().Query().Before("gorm:query"), db.Callback().Query().After("gorm:query")},
+		{"update", db.Callback().Update().Before("gorm:update"), db.Callback().Update().After("gorm:update")},
+		{"delete", db.Callback().Delete().Before("gorm:delete"), db.Callback().Delete().After("gorm:delete")},
+		{"row", db.Callback().Row().Before("gorm:row"), db.Callback().Row().After("gorm:row")},
+		{"raw", db.Callback().Raw().Before("gorm:raw"), db.Callback().Raw().After("gorm:raw")},
+	}
+
+	for _, callback := range callbacks {
+		if err := callback.before.Register("metrics:before_"+callback.operation, before); err != nil {
+			return err
+		}
+		if err := callback.after.Register("metrics:after_"+callback.operation, after(callback.operation)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// InstrumentDatabase installs the GORM plugin and publishes the connection
+// pool statistics of the underlying sql.DB.
+func InstrumentDatabase(db *gorm.DB, name string) error {
+	if err := db.Use(&GormPlugin{}); err != nil {
+		return err
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+
+	if err := prometheus.Register(collectors.NewDBStatsCollector(sqlDB, name)); err != nil {
+		var alreadyRegistered prometheus.AlreadyRegisteredError
+		if !errors.As(err, &alreadyRegistered) {
+			return err
+		}
+	}
+	return nil
+}
+
+type registerer interface {
+	Register(name string, fn func(*gorm.DB)) error
+}
+
+func before(db *gorm.DB) {
+	db.InstanceSet(startTimeKey, time.Now())
+}
+
+func after(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		value, ok := db.InstanceGet(startTimeKey)
+		if !ok {
+			return
+		}
+		start, ok := value.(time.Time)
+		if !ok {
+			return
+		}
+
+		table := db.Statement.Table
+		DBQueryDuration.WithLabelValues(operation, table).Observe(time.Since(start).Seconds())
+		if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+			DBQueryErrors.WithLabelValues(operation, table).Inc()
+		}
+	}
+}
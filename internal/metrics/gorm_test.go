@@ -0,0 +1,74 @@
+package metrics
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type widget struct {
+	ID   int
+	Name string
+}
+
+func TestGormPlugin(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	gdb, err := gorm.Open(postgres.Dialector{
+		Config: &postgres.Config{Conn: db},
+	})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	if err := InstrumentDatabase(gdb, "test"); err != nil {
+		t.Fatalf("InstrumentDatabase() error = %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		queryErr   error
+		wantErrors float64
+	}{
+		{
+			name:       "Should record a successful query",
+			queryErr:   nil,
+			wantErrors: 0,
+		},
+		{
+			name:       "Should record a failed query",
+			queryErr:   errors.New("connection reset"),
+			wantErrors: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := mock.ExpectQuery(`SELECT \* FROM "widgets"`)
+			if tt.queryErr != nil {
+				query.WillReturnError(tt.queryErr)
+			} else {
+				query.WillReturnRows(sqlmock.NewRows([]string{"id", "name"}))
+			}
+
+			errorsBefore := testutil.ToFloat64(DBQueryErrors.WithLabelValues("query", "widgets"))
+
+			widgets := make([]widget, 0)
+			gdb.Find(&widgets)
+
+			if got := testutil.CollectAndCount(DBQueryDuration, "go_api_sample_database_query_duration_seconds"); got < 1 {
+				t.Errorf("GormPlugin recorded %v durations, want at least 1", got)
+			}
+			if got := testutil.ToFloat64(DBQueryErrors.WithLabelValues("query", "widgets")) - errorsBefore; got != tt.wantErrors {
+				t.Errorf("GormPlugin recorded %v errors, want %v", got, tt.wantErrors)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestSetBuildInfo(t *testing.T) {
+	tests := []struct {
+		name    string
+		version string
+	}{
+		{
+			name:    "Should publish the build version",
+			version: "build.42",
+		},
+		{
+			name:    "Should replace a previous build version",
+			version: "development",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetBuildInfo(tt.version)
+
+			if got := testutil.CollectAndCount(buildInfo); got != 1 {
+				t.Errorf("SetBuildInfo() published %v series, want 1", got)
+			}
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/metrics", nil)
+			Handler().ServeHTTP(w, req)
+
+			if !strings.Contains(w.Body.String(), `go_api_sample_build_info{goversion=`) || !strings.Contains(w.Body.String(), `version="`+tt.version+`"`) {
+				t.Errorf("Handler() does not expose build info for %v", tt.version)
+			}
+		})
+	}
+}
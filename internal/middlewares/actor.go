@@ -0,0 +1,25 @@
+package middlewares
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/one-byte-data/go-api-sample/internal/contexts"
+)
+
+const ActorHeader = "X-Actor"
+
+// Actor stores who is making the request on the request context so the
+// services can attribute changes in the audit log. The identity set by the
+// authentication middleware wins over the ActorHeader, which is only used
+// for unauthenticated requests.
+func Actor() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actor := c.GetString(IdentityKey)
+		if actor == "" {
+			actor = c.Request.Header.Get(ActorHeader)
+		}
+		if actor != "" {
+			c.Request = c.Request.WithContext(contexts.WithActor(c.Request.Context(), actor))
+		}
+		c.Next()
+	}
+}
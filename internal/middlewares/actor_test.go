@@ -0,0 +1,75 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/one-byte-data/go-api-sample/internal/contexts"
+)
+
+func TestActor(t *testing.T) {
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		if identity := c.Request.Header.Get("X-Test-Identity"); identity != "" {
+			c.Set(IdentityKey, identity)
+		}
+	})
+	router.Use(Actor())
+	router.GET("/whoami", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{
+			"actor": contexts.Actor(c.Request.Context()),
+		})
+	})
+
+	type args struct {
+		header map[string]string
+	}
+	tests := []struct {
+		name         string
+		args         args
+		wantResponse string
+	}{
+		{
+			name: "Should use the actor header",
+			args: args{
+				header: map[string]string{
+					ActorHeader: "jdoe",
+				},
+			},
+			wantResponse: "{\"actor\":\"jdoe\"}",
+		},
+		{
+			name: "Should prefer the authenticated identity over the header",
+			args: args{
+				header: map[string]string{
+					"X-Test-Identity": "alice",
+					ActorHeader:       "jdoe",
+				},
+			},
+			wantResponse: "{\"actor\":\"alice\"}",
+		},
+		{
+			name: "Should fall back to anonymous",
+			args: args{
+				header: map[string]string{},
+			},
+			wantResponse: "{\"actor\":\"anonymous\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/whoami", nil)
+			for key, value := range tt.args.header {
+				req.Header.Add(key, value)
+			}
+			router.ServeHTTP(w, req)
+
+			if w.Body.String() != tt.wantResponse {
+				t.Errorf("Actor() error = %v, wantResponse %v", w.Body.String(), tt.wantResponse)
+			}
+		})
+	}
+}
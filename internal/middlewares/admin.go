@@ -0,0 +1,29 @@
+package middlewares
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"os"
+
+	"github.com/gin-gonic/gin"
+	"github.com/one-byte-data/go-api-sample/internal/problems"
+)
+
+// AdminKeyFromEnv reads ADMIN_API_KEY.
+func AdminKeyFromEnv() string {
+	return os.Getenv("ADMIN_API_KEY")
+}
+
+// Admin only lets requests through whose APIKeyHeader holds key. Every
+// request is refused when key is empty, so administration is disabled
+// unless a key is configured.
+func Admin(key string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		given := c.Request.Header.Get(APIKeyHeader)
+		if key == "" || subtle.ConstantTimeCompare([]byte(given), []byte(key)) != 1 {
+			problems.Abort(c, problems.New(http.StatusForbidden, problems.CodeNotAllowed, "an admin API key is required"))
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,43 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestAdmin(t *testing.T) {
+	tests := []struct {
+		name     string
+		key      string
+		header   string
+		wantCode int
+	}{
+		{name: "Should let the admin key through", key: "secret", header: "secret", wantCode: http.StatusOK},
+		{name: "Should refuse another key", key: "secret", header: "guess", wantCode: http.StatusForbidden},
+		{name: "Should refuse a request without a key", key: "secret", header: "", wantCode: http.StatusForbidden},
+		{name: "Should refuse everything without a configured key", key: "", header: "", wantCode: http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.Use(Admin(tt.key))
+			router.GET("/tenants", func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/tenants", nil)
+			if tt.header != "" {
+				req.Header.Set(APIKeyHeader, tt.header)
+			}
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Errorf("Admin() code = %v, wantCode %v", w.Code, tt.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package middlewares
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/one-byte-data/go-api-sample/internal/contexts"
+)
+
+const ReadYourWritesHeader = "X-Read-Your-Writes"
+
+// ReadYourWrites lets a client that just made a change ask for reads that
+// see it by sending X-Read-Your-Writes: true. Such reads skip the caches
+// and the read replicas.
+func ReadYourWrites() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if ParseReadYourWrites(c.Request.Header.Get(ReadYourWritesHeader)) {
+			c.Request = c.Request.WithContext(contexts.WithReadYourWrites(c.Request.Context()))
+		}
+		c.Next()
+	}
+}
+
+// ParseReadYourWrites reports whether the value of the X-Read-Your-Writes
+// header asks for read-your-writes consistency. Anything that is not a
+// boolean is ignored.
+func ParseReadYourWrites(value string) bool {
+	readYourWrites, err := strconv.ParseBool(value)
+	return err == nil && readYourWrites
+}
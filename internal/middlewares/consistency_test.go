@@ -0,0 +1,56 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/one-byte-data/go-api-sample/internal/contexts"
+)
+
+func TestReadYourWrites(t *testing.T) {
+	router := gin.New()
+	router.Use(ReadYourWrites())
+	router.GET("/consistency", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{
+			"read_your_writes": contexts.ReadYourWrites(c.Request.Context()),
+		})
+	})
+
+	tests := []struct {
+		name         string
+		header       string
+		wantResponse string
+	}{
+		{
+			name:         "Should read your writes when asked to",
+			header:       "true",
+			wantResponse: "{\"read_your_writes\":true}",
+		},
+		{
+			name:         "Should not read your writes by default",
+			header:       "",
+			wantResponse: "{\"read_your_writes\":false}",
+		},
+		{
+			name:         "Should ignore a value that is not a boolean",
+			header:       "please",
+			wantResponse: "{\"read_your_writes\":false}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/consistency", nil)
+			if tt.header != "" {
+				req.Header.Set(ReadYourWritesHeader, tt.header)
+			}
+			router.ServeHTTP(w, req)
+
+			if w.Body.String() != tt.wantResponse {
+				t.Errorf("ReadYourWrites() error = %v, wantResponse %v", w.Body.String(), tt.wantResponse)
+			}
+		})
+	}
+}
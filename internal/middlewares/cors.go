@@ -0,0 +1,282 @@
+package middlewares
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-contrib/cors"
+	"github.com/gin-gonic/gin"
+	"github.com/one-byte-data/go-api-sample/internal/problems"
+)
+
+// CORSPolicy decides which cross-origin requests browsers may make. Origins
+// are either exact, like https://app.example.com, or match every subdomain,
+// like https://*.example.com. A policy without origins allows no
+// cross-origin requests.
+type CORSPolicy struct {
+	AllowOrigins     []string
+	AllowMethods     []string
+	AllowHeaders     []string
+	ExposeHeaders    []string
+	AllowCredentials bool
+	MaxAge           time.Duration
+}
+
+// CORSConfig holds the default policy and overrides keyed by path prefix.
+// The override with the longest matching prefix wins.
+type CORSConfig struct {
+	Default CORSPolicy
+	Routes  map[string]CORSPolicy
+}
+
+func DefaultCORSConfig() CORSConfig {
+	return CORSConfig{
+		Default: CORSPolicy{
+			AllowOrigins:  []string{},
+			AllowMethods:  []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+			AllowHeaders:  []string{"Content-Type", "Authorization", ActorHeader, APIKeyHeader, RequestIDHeader, IdempotencyKeyHeader, ReadYourWritesHeader, TenantHeader, "Prefer"},
+			ExposeHeaders: []string{RequestIDHeader, "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "Retry-After", IdempotentReplayedHeader, "Location", "Preference-Applied"},
+			MaxAge:        12 * time.Hour,
+		},
+		Routes: map[string]CORSPolicy{},
+	}
+}
+
+// CORSConfigFromEnv reads the default policy from the CORS_* variables.
+// CORS_ROUTES overrides the allowed origins below a path prefix as a
+// semicolon separated list of "/prefix=origin,origin".
+func CORSConfigFromEnv() (CORSConfig, error) {
+	config := DefaultCORSConfig()
+
+	if value, ok := os.LookupEnv("CORS_ALLOWED_ORIGINS"); ok {
+		config.Default.AllowOrigins = splitList(value)
+	}
+	if value := os.Getenv("CORS_ALLOWED_METHODS"); value != "" {
+		config.Default.AllowMethods = splitList(value)
+	}
+	if value := os.Getenv("CORS_ALLOWED_HEADERS"); value != "" {
+		config.Default.AllowHeaders = splitList(value)
+	}
+	if value := os.Getenv("CORS_EXPOSED_HEADERS"); value != "" {
+		config.Default.ExposeHeaders = splitList(value)
+	}
+	if value := os.Getenv("CORS_ALLOW_CREDENTIALS"); value != "" {
+		allow, err := strconv.ParseBool(value)
+		if err != nil {
+			return config, fmt.Errorf("CORS_ALLOW_CREDENTIALS: %q is not a boolean", value)
+		}
+		config.Default.AllowCredentials = allow
+	}
+	if value := os.Getenv("CORS_MAX_AGE"); value != "" {
+		maxAge, err := time.ParseDuration(value)
+		if err != nil {
+			return config, fmt.Errorf("CORS_MAX_AGE: %q is not a duration", value)
+		}
+		config.Default.MaxAge = maxAge
+	}
+
+	if value := os.Getenv("CORS_ROUTES"); value != "" {
+		for _, entry := range strings.Split(value, ";") {
+			prefix, origins, ok := strings.Cut(strings.TrimSpace(entry), "=")
+			if !ok || !strings.HasPrefix(prefix, "/") {
+				return config, fmt.Errorf("CORS_ROUTES: %q is not /prefix=origin,origin", entry)
+			}
+			policy := config.Default
+			policy.AllowOrigins = splitList(origins)
+			config.Routes[strings.TrimSuffix(prefix, "/")] = policy
+		}
+	}
+
+	return config, nil
+}
+
+// Validate rejects policies browsers would refuse or that would expose
+// credentialed responses to any site.
+func (config CORSConfig) Validate() error {
+	if err := config.Default.Validate(); err != nil {
+		return err
+	}
+	for prefix, policy := range config.Routes {
+		if err := policy.Validate(); err != nil {
+			return fmt.Errorf("cors policy for %s: %w", prefix, err)
+		}
+	}
+	return nil
+}
+
+func (policy CORSPolicy) Validate() error {
+	if policy.MaxAge < 0 {
+		return errors.New("max age must not be negative")
+	}
+
+	for _, origin := range policy.AllowOrigins {
+		switch {
+		case origin == "*":
+			if policy.AllowCredentials {
+				return errors.New("credentials cannot be allowed for every origin")
+			}
+			if len(policy.AllowOrigins) > 1 {
+				return errors.New("* cannot be combined with other origins")
+			}
+		case origin == "null":
+			return errors.New("the null origin cannot be allowed")
+		default:
+			if err := validateOrigin(origin); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func validateOrigin(origin string) error {
+	u, err := url.Parse(origin)
+	if err != nil || u.Scheme == "" || u.Host == "" || (u.Path != "" && u.Path != "/") || u.RawQuery != "" || u.User != nil {
+		return fmt.Errorf("origin %q must be scheme://host[:port]", origin)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("origin %q must use http or https", origin)
+	}
+
+	host := u.Hostname()
+	if !strings.Contains(host, "*") {
+		return nil
+	}
+	labels := strings.Split(host, ".")
+	if labels[0] != "*" || strings.Contains(strings.Join(labels[1:], "."), "*") {
+		return fmt.Errorf("origin %q may only use * as the first label", origin)
+	}
+	if len(labels) < 3 {
+		return fmt.Errorf("origin %q matches too many sites, use at least *.example.com", origin)
+	}
+	return nil
+}
+
+// CORS validates the configuration and returns a middleware that applies
+// the policy of the longest matching path prefix. Cross-origin requests
+// from an origin the policy doesn't allow are refused with a problem.
+func CORS(config CORSConfig) (gin.HandlerFunc, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	prefixes := make([]string, 0, len(config.Routes))
+	routes := make(map[string]corsRoute, len(config.Routes))
+	for prefix, policy := range config.Routes {
+		prefixes = append(prefixes, prefix)
+		routes[prefix] = policy.route()
+	}
+	sort.Slice(prefixes, func(i, j int) bool {
+		return len(prefixes[i]) > len(prefixes[j])
+	})
+	defaultRoute := config.Default.route()
+
+	return func(c *gin.Context) {
+		route := defaultRoute
+		for _, prefix := range prefixes {
+			if c.Request.URL.Path == prefix || strings.HasPrefix(c.Request.URL.Path, prefix+"/") {
+				route = routes[prefix]
+				break
+			}
+		}
+		if route.handler == nil {
+			return
+		}
+
+		origin := c.Request.Header.Get("Origin")
+		sameOrigin := origin == "http://"+c.Request.Host || origin == "https://"+c.Request.Host
+		if origin != "" && !sameOrigin && !route.allowOrigin(origin) {
+			problems.Abort(c, problems.New(http.StatusForbidden, problems.CodeOriginNotAllowed, fmt.Sprintf("origin %s is not allowed", origin)))
+			return
+		}
+		route.handler(c)
+	}, nil
+}
+
+type corsRoute struct {
+	handler     gin.HandlerFunc
+	allowOrigin func(origin string) bool
+}
+
+// route leaves the handler nil when the policy allows no origins.
+func (policy CORSPolicy) route() corsRoute {
+	if len(policy.AllowOrigins) == 0 {
+		return corsRoute{}
+	}
+
+	config := cors.Config{
+		AllowMethods:     policy.AllowMethods,
+		AllowHeaders:     policy.AllowHeaders,
+		ExposeHeaders:    policy.ExposeHeaders,
+		AllowCredentials: policy.AllowCredentials,
+		MaxAge:           policy.MaxAge,
+	}
+
+	if policy.AllowOrigins[0] == "*" {
+		config.AllowAllOrigins = true
+		return corsRoute{
+			handler:     cors.New(config),
+			allowOrigin: func(string) bool { return true },
+		}
+	}
+
+	exact := make(map[string]bool)
+	patterns := make([]originPattern, 0)
+	for _, origin := range policy.AllowOrigins {
+		origin = strings.TrimSuffix(strings.ToLower(origin), "/")
+		if scheme, suffix, ok := strings.Cut(origin, "://*"); ok {
+			patterns = append(patterns, originPattern{prefix: scheme + "://", suffix: suffix})
+			continue
+		}
+		exact[origin] = true
+	}
+
+	config.AllowOriginFunc = func(origin string) bool {
+		origin = strings.ToLower(origin)
+		if exact[origin] {
+			return true
+		}
+		for _, pattern := range patterns {
+			if pattern.matches(origin) {
+				return true
+			}
+		}
+		return false
+	}
+	return corsRoute{
+		handler:     cors.New(config),
+		allowOrigin: config.AllowOriginFunc,
+	}
+}
+
+// originPattern matches https://*.example.com as the prefix https:// and
+// the suffix .example.com with at least one subdomain label in between.
+type originPattern struct {
+	prefix string
+	suffix string
+}
+
+func (p originPattern) matches(origin string) bool {
+	if !strings.HasPrefix(origin, p.prefix) || !strings.HasSuffix(origin, p.suffix) {
+		return false
+	}
+	subdomain := origin[len(p.prefix) : len(origin)-len(p.suffix)]
+	return subdomain != "" && !strings.ContainsAny(subdomain, ":/?#@")
+}
+
+func splitList(value string) []string {
+	list := make([]string, 0)
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
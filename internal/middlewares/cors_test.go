@@ -0,0 +1,235 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestCORSConfigFromEnv(t *testing.T) {
+	tests := []struct {
+		name        string
+		env         map[string]string
+		wantOrigins []string
+		wantRoutes  int
+		wantErr     bool
+	}{
+		{
+			name:        "Should allow no origins by default",
+			env:         map[string]string{"CORS_ALLOWED_ORIGINS": "", "CORS_ALLOW_CREDENTIALS": "", "CORS_MAX_AGE": "", "CORS_ROUTES": ""},
+			wantOrigins: []string{},
+		},
+		{
+			name: "Should read origins and route overrides",
+			env: map[string]string{
+				"CORS_ALLOWED_ORIGINS":   "https://app.example.com, https://*.example.org",
+				"CORS_ALLOW_CREDENTIALS": "true",
+				"CORS_MAX_AGE":           "1h",
+				"CORS_ROUTES":            "/metrics=https://grafana.example.com",
+			},
+			wantOrigins: []string{"https://app.example.com", "https://*.example.org"},
+			wantRoutes:  1,
+		},
+		{
+			name:    "Should not accept an invalid max age",
+			env:     map[string]string{"CORS_ALLOW_CREDENTIALS": "", "CORS_MAX_AGE": "forever", "CORS_ROUTES": ""},
+			wantErr: true,
+		},
+		{
+			name:    "Should not accept a route without a prefix",
+			env:     map[string]string{"CORS_ALLOW_CREDENTIALS": "", "CORS_MAX_AGE": "", "CORS_ROUTES": "metrics=https://grafana.example.com"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			got, err := CORSConfigFromEnv()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CORSConfigFromEnv() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(got.Default.AllowOrigins) != len(tt.wantOrigins) {
+				t.Errorf("CORSConfigFromEnv() origins = %v, want %v", got.Default.AllowOrigins, tt.wantOrigins)
+			}
+			for i := range tt.wantOrigins {
+				if got.Default.AllowOrigins[i] != tt.wantOrigins[i] {
+					t.Errorf("CORSConfigFromEnv() origins = %v, want %v", got.Default.AllowOrigins, tt.wantOrigins)
+				}
+			}
+			if len(got.Routes) != tt.wantRoutes {
+				t.Errorf("CORSConfigFromEnv() routes = %v, want %v", got.Routes, tt.wantRoutes)
+			}
+		})
+	}
+}
+
+func TestCORSPolicy_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  CORSPolicy
+		wantErr bool
+	}{
+		{
+			name:   "Should accept explicit origins with credentials",
+			policy: CORSPolicy{AllowOrigins: []string{"https://app.example.com", "http://localhost:3000"}, AllowCredentials: true},
+		},
+		{
+			name:   "Should accept a wildcard subdomain",
+			policy: CORSPolicy{AllowOrigins: []string{"https://*.example.com"}, AllowCredentials: true},
+		},
+		{
+			name:   "Should accept every origin without credentials",
+			policy: CORSPolicy{AllowOrigins: []string{"*"}},
+		},
+		{
+			name:    "Should not accept every origin with credentials",
+			policy:  CORSPolicy{AllowOrigins: []string{"*"}, AllowCredentials: true},
+			wantErr: true,
+		},
+		{
+			name:    "Should not accept the null origin",
+			policy:  CORSPolicy{AllowOrigins: []string{"null"}},
+			wantErr: true,
+		},
+		{
+			name:    "Should not accept a wildcard top level domain",
+			policy:  CORSPolicy{AllowOrigins: []string{"https://*.com"}},
+			wantErr: true,
+		},
+		{
+			name:    "Should not accept a wildcard in the middle of a host",
+			policy:  CORSPolicy{AllowOrigins: []string{"https://app.*.example.com"}},
+			wantErr: true,
+		},
+		{
+			name:    "Should not accept an origin with a path",
+			policy:  CORSPolicy{AllowOrigins: []string{"https://app.example.com/login"}},
+			wantErr: true,
+		},
+		{
+			name:    "Should not accept a negative max age",
+			policy:  CORSPolicy{MaxAge: -time.Second},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.policy.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("CORSPolicy.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCORS(t *testing.T) {
+	config := DefaultCORSConfig()
+	config.Default.AllowOrigins = []string{"https://app.example.com", "https://*.example.org"}
+	config.Default.AllowCredentials = true
+	config.Routes["/metrics"] = CORSPolicy{AllowOrigins: []string{"https://grafana.example.com"}}
+
+	handler, err := CORS(config)
+	if err != nil {
+		t.Fatalf("CORS() error = %v", err)
+	}
+
+	router := gin.New()
+	router.Use(handler)
+	ok := func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{
+			"message": "ok",
+		})
+	}
+	router.GET("/cats", ok)
+	router.GET("/metrics", ok)
+
+	type args struct {
+		method   string
+		endpoint string
+		origin   string
+	}
+	tests := []struct {
+		name            string
+		args            args
+		wantCode        int
+		wantAllowOrigin string
+	}{
+		{
+			name:            "Should allow an exact origin",
+			args:            args{method: "GET", endpoint: "/cats", origin: "https://app.example.com"},
+			wantCode:        http.StatusOK,
+			wantAllowOrigin: "https://app.example.com",
+		},
+		{
+			name:            "Should allow a subdomain of a wildcard origin",
+			args:            args{method: "GET", endpoint: "/cats", origin: "https://shelter.example.org"},
+			wantCode:        http.StatusOK,
+			wantAllowOrigin: "https://shelter.example.org",
+		},
+		{
+			name:     "Should not allow the bare domain of a wildcard origin",
+			args:     args{method: "GET", endpoint: "/cats", origin: "https://example.org"},
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:     "Should not allow another origin",
+			args:     args{method: "GET", endpoint: "/cats", origin: "https://evil.example.net"},
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:            "Should answer a preflight request",
+			args:            args{method: "OPTIONS", endpoint: "/cats", origin: "https://app.example.com"},
+			wantCode:        http.StatusNoContent,
+			wantAllowOrigin: "https://app.example.com",
+		},
+		{
+			name:            "Should use the route override",
+			args:            args{method: "GET", endpoint: "/metrics", origin: "https://grafana.example.com"},
+			wantCode:        http.StatusOK,
+			wantAllowOrigin: "https://grafana.example.com",
+		},
+		{
+			name:     "Should not use the default policy for an overridden route",
+			args:     args{method: "GET", endpoint: "/metrics", origin: "https://app.example.com"},
+			wantCode: http.StatusForbidden,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(tt.args.method, tt.args.endpoint, nil)
+			req.Header.Add("Origin", tt.args.origin)
+			if tt.args.method == "OPTIONS" {
+				req.Header.Add("Access-Control-Request-Method", "POST")
+			}
+			router.ServeHTTP(w, req)
+
+			if tt.wantCode != w.Code {
+				t.Errorf("CORS() error = %v, wantCode %v", w.Code, tt.wantCode)
+				return
+			}
+			if got := w.Header().Get("Access-Control-Allow-Origin"); got != tt.wantAllowOrigin {
+				t.Errorf("CORS() Access-Control-Allow-Origin = %v, want %v", got, tt.wantAllowOrigin)
+			}
+		})
+	}
+}
+
+func TestCORS_invalid(t *testing.T) {
+	config := DefaultCORSConfig()
+	config.Default.AllowOrigins = []string{"*"}
+	config.Default.AllowCredentials = true
+
+	if _, err := CORS(config); err == nil {
+		t.Errorf("CORS() error = nil, want an error for an unsafe policy")
+	}
+}
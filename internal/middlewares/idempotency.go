@@ -0,0 +1,250 @@
+package middlewares
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/one-byte-data/go-api-sample/internal/contexts"
+	"github.com/one-byte-data/go-api-sample/internal/problems"
+)
+
+const (
+	IdempotencyKeyHeader     = "Idempotency-Key"
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+	maxIdempotencyKeyLength  = 255
+	defaultIdempotencyTTL    = 24 * time.Hour
+)
+
+// replayedHeaders are the response headers stored and replayed with the
+// body. Others, such as the request ID, rate limit and trace headers,
+// belong to the request being answered rather than the first one.
+var replayedHeaders = []string{"Content-Type", "Location", "Preference-Applied"}
+
+// IdempotencyRecord is the outcome of the first request made with a key.
+// Completed is false while that request is still being handled.
+type IdempotencyRecord struct {
+	RequestHash string
+	Completed   bool
+	Status      int
+	Header      http.Header
+	Body        []byte
+}
+
+// IdempotencyStore remembers the response to every Idempotency-Key. The
+// in-memory store only covers a single instance; a shared store can
+// implement this interface to cover several.
+type IdempotencyStore interface {
+	// Start reserves key for a request. When the key was used before it
+	// returns the earlier record and false instead.
+	Start(ctx context.Context, key string, requestHash string) (*IdempotencyRecord, bool, error)
+	Complete(ctx context.Context, key string, record IdempotencyRecord) error
+	// Release forgets a key whose request failed so it can be retried.
+	Release(ctx context.Context, key string) error
+}
+
+// IdempotencyTTLFromEnv reads how long responses are kept from
+// IDEMPOTENCY_TTL.
+func IdempotencyTTLFromEnv() (time.Duration, error) {
+	value := os.Getenv("IDEMPOTENCY_TTL")
+	if value == "" {
+		return defaultIdempotencyTTL, nil
+	}
+	ttl, err := time.ParseDuration(value)
+	if err != nil || ttl <= 0 {
+		return 0, fmt.Errorf("IDEMPOTENCY_TTL must be a positive duration, got %q", value)
+	}
+	return ttl, nil
+}
+
+// Idempotency replays the stored status, body and replayedHeaders when a
+// request is repeated with the same Idempotency-Key. A repeat that arrives while the first request is
+// in flight is rejected with 409 Conflict, and reusing a key for a different
+// request body is rejected with 422 Unprocessable Entity. Keys are scoped to
+// the client, tenant and route, so it must run after the tenant is
+// resolved. Server errors and panics are not stored so the request can be
+// retried.
+func Idempotency(store IdempotencyStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.Request.Header.Get(IdempotencyKeyHeader)
+		if key == "" {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			problems.Abort(c, problems.New(http.StatusBadRequest, problems.CodeInvalidIdempotencyKey, "Idempotency-Key is too long"))
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			problems.Abort(c, problems.New(http.StatusBadRequest, problems.CodeInvalidBody, "the request body could not be read"))
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		ctx := c.Request.Context()
+		storeKey := clientKey(c) + "|" + contexts.Tenant(ctx) + "|" + c.Request.Method + " " + c.FullPath() + "|" + key
+		hash := sha256.Sum256(body)
+		requestHash := hex.EncodeToString(hash[:])
+
+		record, started, err := store.Start(ctx, storeKey, requestHash)
+		if err != nil {
+			problems.Internal(c, err)
+			return
+		}
+
+		if !started {
+			switch {
+			case record.RequestHash != requestHash:
+				problems.Abort(c, problems.New(http.StatusUnprocessableEntity, problems.CodeIdempotencyKeyReused, "Idempotency-Key was already used for a different request"))
+			case !record.Completed:
+				problems.Abort(c, problems.New(http.StatusConflict, problems.CodeIdempotencyKeyInUse, "a request with this Idempotency-Key is in progress"))
+			default:
+				for name, values := range record.Header {
+					c.Writer.Header()[name] = values
+				}
+				c.Header(IdempotentReplayedHeader, "true")
+				c.Data(record.Status, record.Header.Get("Content-Type"), record.Body)
+				c.Abort()
+			}
+			return
+		}
+
+		writer := &recordingWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		defer func() {
+			// A panicking handler left nothing to replay, so the key is
+			// released before Recovery turns the panic into a 500.
+			if recovered := recover(); recovered != nil {
+				if err := store.Release(ctx, storeKey); err != nil {
+					c.Error(err)
+				}
+				panic(recovered)
+			}
+		}()
+		c.Next()
+
+		if writer.Status() >= http.StatusInternalServerError {
+			err = store.Release(ctx, storeKey)
+		} else {
+			err = store.Complete(ctx, storeKey, IdempotencyRecord{
+				RequestHash: requestHash,
+				Completed:   true,
+				Status:      writer.Status(),
+				Header:      replayable(writer.Header()),
+				Body:        writer.body.Bytes(),
+			})
+		}
+		if err != nil {
+			c.Error(err)
+		}
+	}
+}
+
+func replayable(header http.Header) http.Header {
+	replayed := make(http.Header, len(replayedHeaders))
+	for _, name := range replayedHeaders {
+		if values := header.Values(name); len(values) > 0 {
+			replayed[name] = append([]string(nil), values...)
+		}
+	}
+	return replayed
+}
+
+type recordingWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *recordingWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *recordingWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+type idempotencyEntry struct {
+	record  IdempotencyRecord
+	expires time.Time
+}
+
+type memoryIdempotencyStore struct {
+	mu        sync.Mutex
+	ttl       time.Duration
+	entries   map[string]*idempotencyEntry
+	now       func() time.Time
+	lastSweep time.Time
+}
+
+// NewMemoryIdempotencyStore keeps responses in memory for ttl.
+func NewMemoryIdempotencyStore(ttl time.Duration) IdempotencyStore {
+	return &memoryIdempotencyStore{
+		ttl:     ttl,
+		entries: make(map[string]*idempotencyEntry),
+		now:     time.Now,
+	}
+}
+
+func (s *memoryIdempotencyStore) Start(ctx context.Context, key string, requestHash string) (*IdempotencyRecord, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	if entry, ok := s.entries[key]; ok && now.Before(entry.expires) {
+		record := entry.record
+		return &record, false, nil
+	}
+
+	s.entries[key] = &idempotencyEntry{
+		record:  IdempotencyRecord{RequestHash: requestHash},
+		expires: now.Add(s.ttl),
+	}
+	return nil, true, nil
+}
+
+func (s *memoryIdempotencyStore) Complete(ctx context.Context, key string, record IdempotencyRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries[key] = &idempotencyEntry{
+		record:  record,
+		expires: s.now().Add(s.ttl),
+	}
+	return nil
+}
+
+func (s *memoryIdempotencyStore) Release(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.entries, key)
+	return nil
+}
+
+// sweep drops expired entries once a minute.
+func (s *memoryIdempotencyStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < time.Minute {
+		return
+	}
+	s.lastSweep = now
+
+	for key, entry := range s.entries {
+		if !now.Before(entry.expires) {
+			delete(s.entries, key)
+		}
+	}
+}
@@ -0,0 +1,279 @@
+package middlewares
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestIdempotencyTTLFromEnv(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{name: "Should use the default", value: "", want: 24 * time.Hour},
+		{name: "Should read a duration", value: "90m", want: 90 * time.Minute},
+		{name: "Should not accept zero", value: "0s", wantErr: true},
+		{name: "Should not accept garbage", value: "soon", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("IDEMPOTENCY_TTL", tt.value)
+
+			got, err := IdempotencyTTLFromEnv()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("IdempotencyTTLFromEnv() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("IdempotencyTTLFromEnv() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIdempotency(t *testing.T) {
+	created := 0
+	failures := 1
+	router := gin.New()
+	router.Use(Idempotency(NewMemoryIdempotencyStore(time.Hour)))
+	router.POST("/cats", func(c *gin.Context) {
+		created++
+		c.Header("Location", fmt.Sprintf("/cats/%d", created))
+		c.JSON(http.StatusCreated, gin.H{
+			"message": fmt.Sprintf("id %d created", created),
+		})
+	})
+	router.POST("/dogs", func(c *gin.Context) {
+		if failures > 0 {
+			failures--
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+				"message": "there was an error",
+			})
+			return
+		}
+		c.JSON(http.StatusCreated, gin.H{
+			"message": "id 1 created",
+		})
+	})
+
+	type args struct {
+		endpoint string
+		key      string
+		body     string
+	}
+	tests := []struct {
+		name         string
+		args         args
+		wantCode     int
+		wantResponse string
+		wantReplayed bool
+	}{
+		{
+			name:         "Should handle a request without a key",
+			args:         args{endpoint: "/cats", key: "", body: `{"name":"Tom"}`},
+			wantCode:     http.StatusCreated,
+			wantResponse: `{"message":"id 1 created"}`,
+		},
+		{
+			name:         "Should handle the first request with a key",
+			args:         args{endpoint: "/cats", key: "abc", body: `{"name":"Tom"}`},
+			wantCode:     http.StatusCreated,
+			wantResponse: `{"message":"id 2 created"}`,
+		},
+		{
+			name:         "Should replay a repeated request",
+			args:         args{endpoint: "/cats", key: "abc", body: `{"name":"Tom"}`},
+			wantCode:     http.StatusCreated,
+			wantResponse: `{"message":"id 2 created"}`,
+			wantReplayed: true,
+		},
+		{
+			name:         "Should refuse a key reused for a different body",
+			args:         args{endpoint: "/cats", key: "abc", body: `{"name":"Felix"}`},
+			wantCode:     http.StatusUnprocessableEntity,
+			wantResponse: `"code":"idempotency_key_reused"`,
+		},
+		{
+			name:         "Should scope keys to the route",
+			args:         args{endpoint: "/dogs", key: "abc", body: `{"name":"Tom"}`},
+			wantCode:     http.StatusInternalServerError,
+			wantResponse: `{"message":"there was an error"}`,
+		},
+		{
+			name:         "Should allow a retry after a server error",
+			args:         args{endpoint: "/dogs", key: "abc", body: `{"name":"Tom"}`},
+			wantCode:     http.StatusCreated,
+			wantResponse: `{"message":"id 1 created"}`,
+		},
+		{
+			name:         "Should refuse a key that is too long",
+			args:         args{endpoint: "/cats", key: strings.Repeat("k", 256), body: `{}`},
+			wantCode:     http.StatusBadRequest,
+			wantResponse: `"code":"invalid_idempotency_key"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", tt.args.endpoint, strings.NewReader(tt.args.body))
+			if tt.args.key != "" {
+				req.Header.Set(IdempotencyKeyHeader, tt.args.key)
+			}
+			router.ServeHTTP(w, req)
+
+			if tt.wantCode != w.Code {
+				t.Errorf("Idempotency() error = %v, wantCode %v", w.Code, tt.wantCode)
+				return
+			}
+			if !strings.Contains(w.Body.String(), tt.wantResponse) {
+				t.Errorf("Idempotency() error = %v, wantResponse %v", w.Body.String(), tt.wantResponse)
+			}
+			if got := w.Header().Get(IdempotentReplayedHeader) == "true"; got != tt.wantReplayed {
+				t.Errorf("Idempotency() replayed = %v, want %v", got, tt.wantReplayed)
+			}
+			if tt.wantReplayed && w.Header().Get("Location") != "/cats/2" {
+				t.Errorf("Idempotency() Location = %v, want /cats/2", w.Header().Get("Location"))
+			}
+		})
+	}
+}
+
+func TestIdempotency_inFlight(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	router := gin.New()
+	router.Use(Idempotency(NewMemoryIdempotencyStore(time.Hour)))
+	router.POST("/cats", func(c *gin.Context) {
+		close(started)
+		<-release
+		c.JSON(http.StatusCreated, gin.H{
+			"message": "id 1 created",
+		})
+	})
+
+	post := func() *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/cats", strings.NewReader(`{}`))
+		req.Header.Set(IdempotencyKeyHeader, "abc")
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	first := make(chan *httptest.ResponseRecorder)
+	go func() {
+		first <- post()
+	}()
+	<-started
+
+	if w := post(); w.Code != http.StatusConflict {
+		t.Errorf("Idempotency() error = %v, wantCode %v", w.Code, http.StatusConflict)
+	}
+
+	close(release)
+	if w := <-first; w.Code != http.StatusCreated {
+		t.Errorf("Idempotency() error = %v, wantCode %v", w.Code, http.StatusCreated)
+	}
+}
+
+func TestIdempotency_replayHeaders(t *testing.T) {
+	router := gin.New()
+	router.Use(RequestID())
+	router.Use(Idempotency(NewMemoryIdempotencyStore(time.Hour)))
+	router.POST("/cats", func(c *gin.Context) {
+		c.Header("Location", "/cats/1")
+		c.Header("RateLimit-Remaining", "9")
+		c.JSON(http.StatusCreated, gin.H{
+			"message": "id 1 created",
+		})
+	})
+
+	post := func(requestID string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/cats", strings.NewReader(`{}`))
+		req.Header.Set(IdempotencyKeyHeader, "abc")
+		req.Header.Set(RequestIDHeader, requestID)
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	post("first")
+	w := post("second")
+
+	if w.Header().Get(IdempotentReplayedHeader) != "true" {
+		t.Fatalf("Idempotency() did not replay the response")
+	}
+	if got := w.Header().Get(RequestIDHeader); got != "second" {
+		t.Errorf("Idempotency() X-Request-ID = %v, want second", got)
+	}
+	if got := w.Header().Get("Location"); got != "/cats/1" {
+		t.Errorf("Idempotency() Location = %v, want /cats/1", got)
+	}
+	if got := w.Header().Get("RateLimit-Remaining"); got != "" {
+		t.Errorf("Idempotency() RateLimit-Remaining = %v, want none", got)
+	}
+}
+
+func TestIdempotency_panic(t *testing.T) {
+	panics := 1
+	router := gin.New()
+	router.Use(Recovery())
+	router.Use(Idempotency(NewMemoryIdempotencyStore(time.Hour)))
+	router.POST("/cats", func(c *gin.Context) {
+		if panics > 0 {
+			panics--
+			panic("boom")
+		}
+		c.JSON(http.StatusCreated, gin.H{
+			"message": "id 1 created",
+		})
+	})
+
+	post := func() *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/cats", strings.NewReader(`{}`))
+		req.Header.Set(IdempotencyKeyHeader, "abc")
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	if w := post(); w.Code != http.StatusInternalServerError {
+		t.Errorf("Idempotency() error = %v, wantCode %v", w.Code, http.StatusInternalServerError)
+	}
+	if w := post(); w.Code != http.StatusCreated {
+		t.Errorf("Idempotency() after a panic error = %v, wantCode %v", w.Code, http.StatusCreated)
+	}
+}
+
+func Test_memoryIdempotencyStore_expiry(t *testing.T) {
+	now := time.Date(2022, 2, 10, 0, 0, 0, 0, time.UTC)
+	store := &memoryIdempotencyStore{
+		ttl:     time.Minute,
+		entries: make(map[string]*idempotencyEntry),
+		now:     func() time.Time { return now },
+	}
+	ctx := context.Background()
+
+	if _, started, _ := store.Start(ctx, "key", "hash"); !started {
+		t.Errorf("memoryIdempotencyStore.Start() started = false, want true")
+	}
+	if _, started, _ := store.Start(ctx, "key", "hash"); started {
+		t.Errorf("memoryIdempotencyStore.Start() started = true, want false")
+	}
+
+	now = now.Add(2 * time.Minute)
+	if _, started, _ := store.Start(ctx, "key", "hash"); !started {
+		t.Errorf("memoryIdempotencyStore.Start() after expiry started = false, want true")
+	}
+	if len(store.entries) != 1 {
+		t.Errorf("memoryIdempotencyStore.entries = %v, want 1", len(store.entries))
+	}
+}
@@ -0,0 +1,51 @@
+package middlewares
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/one-byte-data/go-api-sample/internal/logging"
+)
+
+// Logger writes one structured line per request. Errors attached to the
+// context with c.Error are included, and request headers are logged with
+// sensitive values redacted when debug logging is enabled.
+func Logger() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		ctx := c.Request.Context()
+		status := c.Writer.Status()
+		attrs := []any{
+			"method", c.Request.Method,
+			"route", c.FullPath(),
+			"path", c.Request.URL.Path,
+			"status", status,
+			"duration", time.Since(start),
+			"client_ip", c.ClientIP(),
+			"bytes", c.Writer.Size(),
+		}
+		if len(c.Errors) > 0 {
+			attrs = append(attrs, "errors", c.Errors.Errors())
+		}
+		if slog.Default().Enabled(ctx, slog.LevelDebug) {
+			attrs = append(attrs, "headers", logging.RedactHeaders(c.Request.Header))
+		}
+
+		slog.Log(ctx, levelFor(status), "request", attrs...)
+	}
+}
+
+func levelFor(status int) slog.Level {
+	switch {
+	case status >= http.StatusInternalServerError:
+		return slog.LevelError
+	case status >= http.StatusBadRequest:
+		return slog.LevelWarn
+	default:
+		return slog.LevelInfo
+	}
+}
@@ -0,0 +1,91 @@
+package middlewares
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/one-byte-data/go-api-sample/internal/logging"
+)
+
+func TestLogger(t *testing.T) {
+	var buffer bytes.Buffer
+	defaultLogger := slog.Default()
+	logging.Setup(&buffer, slog.LevelDebug)
+	defer slog.SetDefault(defaultLogger)
+
+	router := gin.New()
+	router.Use(RequestID())
+	router.Use(Logger())
+	router.GET("/cats/:id", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{
+			"message": "ok",
+		})
+	})
+	router.GET("/broken", func(c *gin.Context) {
+		c.Error(errors.New("connection refused"))
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+			"message": "there was an error",
+		})
+	})
+
+	tests := []struct {
+		name      string
+		endpoint  string
+		wantLevel string
+		wantRoute string
+		wantError bool
+	}{
+		{
+			name:      "Should log a request",
+			endpoint:  "/cats/1234",
+			wantLevel: "INFO",
+			wantRoute: "/cats/:id",
+		},
+		{
+			name:      "Should log a failed request with its errors",
+			endpoint:  "/broken",
+			wantLevel: "ERROR",
+			wantRoute: "/broken",
+			wantError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buffer.Reset()
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", tt.endpoint, nil)
+			req.Header.Add(RequestIDHeader, "abc-123")
+			req.Header.Add("Authorization", "Bearer secret")
+			router.ServeHTTP(w, req)
+
+			line := make(map[string]interface{})
+			if err := json.Unmarshal(buffer.Bytes(), &line); err != nil {
+				t.Fatalf("Logger() wrote %q, want a JSON line", buffer.String())
+			}
+
+			if line["level"] != tt.wantLevel {
+				t.Errorf("Logger() level = %v, want %v", line["level"], tt.wantLevel)
+			}
+			if line["route"] != tt.wantRoute {
+				t.Errorf("Logger() route = %v, want %v", line["route"], tt.wantRoute)
+			}
+			if line["request_id"] != "abc-123" {
+				t.Errorf("Logger() request_id = %v, want abc-123", line["request_id"])
+			}
+			if _, ok := line["errors"]; ok != tt.wantError {
+				t.Errorf("Logger() errors = %v, wantError %v", line["errors"], tt.wantError)
+			}
+			headers, _ := line["headers"].(map[string]interface{})
+			if headers["Authorization"] != logging.Redacted {
+				t.Errorf("Logger() Authorization = %v, want %v", headers["Authorization"], logging.Redacted)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/one-byte-data/go-api-sample/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMetrics(t *testing.T) {
+	router := gin.New()
+	router.Use(Metrics())
+	router.GET("/cats/:id", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{
+			"message": "ok",
+		})
+	})
+
+	type args struct {
+		method   string
+		endpoint string
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantLabels []string
+	}{
+		{
+			name:       "Should count requests by route template",
+			args:       args{method: "GET", endpoint: "/cats/1234"},
+			wantLabels: []string{"GET", "/cats/:id", "200"},
+		},
+		{
+			name:       "Should count unmatched requests",
+			args:       args{method: "GET", endpoint: "/not/a/route"},
+			wantLabels: []string{"GET", UnmatchedRoute, "404"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			counter := metrics.HTTPRequests.WithLabelValues(tt.wantLabels...)
+			before := testutil.ToFloat64(counter)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(tt.args.method, tt.args.endpoint, nil)
+			router.ServeHTTP(w, req)
+
+			if got := testutil.ToFloat64(counter) - before; got != 1 {
+				t.Errorf("Metrics() counted %v requests, want 1", got)
+			}
+		})
+	}
+}
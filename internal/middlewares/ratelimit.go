@@ -0,0 +1,263 @@
+package middlewares
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/one-byte-data/go-api-sample/internal/problems"
+)
+
+const APIKeyHeader = "X-Api-Key"
+
+// IdentityKey is the gin context key an authentication middleware sets to
+// the authenticated identity of the caller.
+const IdentityKey = "identity"
+
+// RateLimit is a token bucket refilled at Rate tokens per second that holds
+// at most Burst tokens. A zero Rate disables the limit.
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+type RateLimitResult struct {
+	Allowed    bool
+	Remaining  int
+	RetryAfter time.Duration
+	ResetAfter time.Duration
+}
+
+// RateLimitStore keeps the token buckets of every client. The in-memory
+// store only limits a single instance; a shared store can implement this
+// interface to limit across instances.
+type RateLimitStore interface {
+	Take(ctx context.Context, key string, limit RateLimit) (RateLimitResult, error)
+}
+
+// RateLimitConfig holds the default limits for reads (GET, HEAD and
+// OPTIONS) and writes, and overrides keyed by "METHOD /route/template".
+type RateLimitConfig struct {
+	Read   RateLimit
+	Write  RateLimit
+	Routes map[string]RateLimit
+}
+
+func DefaultRateLimitConfig() RateLimitConfig {
+	return RateLimitConfig{
+		Read:   RateLimit{Rate: 50, Burst: 100},
+		Write:  RateLimit{Rate: 10, Burst: 20},
+		Routes: map[string]RateLimit{},
+	}
+}
+
+// RateLimitConfigFromEnv reads RATE_LIMIT_READ and RATE_LIMIT_WRITE as
+// "rate:burst" and RATE_LIMIT_ROUTES as a semicolon separated list of
+// "METHOD /route=rate:burst".
+func RateLimitConfigFromEnv() (RateLimitConfig, error) {
+	config := DefaultRateLimitConfig()
+
+	if value := os.Getenv("RATE_LIMIT_READ"); value != "" {
+		limit, err := parseRateLimit(value)
+		if err != nil {
+			return config, fmt.Errorf("RATE_LIMIT_READ: %w", err)
+		}
+		config.Read = limit
+	}
+
+	if value := os.Getenv("RATE_LIMIT_WRITE"); value != "" {
+		limit, err := parseRateLimit(value)
+		if err != nil {
+			return config, fmt.Errorf("RATE_LIMIT_WRITE: %w", err)
+		}
+		config.Write = limit
+	}
+
+	if value := os.Getenv("RATE_LIMIT_ROUTES"); value != "" {
+		for _, entry := range strings.Split(value, ";") {
+			route, value, ok := strings.Cut(strings.TrimSpace(entry), "=")
+			if !ok || len(strings.Fields(route)) != 2 {
+				return config, fmt.Errorf("RATE_LIMIT_ROUTES: %q is not METHOD /route=rate:burst", entry)
+			}
+			limit, err := parseRateLimit(value)
+			if err != nil {
+				return config, fmt.Errorf("RATE_LIMIT_ROUTES: %w", err)
+			}
+			config.Routes[strings.Join(strings.Fields(route), " ")] = limit
+		}
+	}
+
+	return config, nil
+}
+
+func parseRateLimit(value string) (RateLimit, error) {
+	rate, burst, ok := strings.Cut(value, ":")
+	if !ok {
+		return RateLimit{}, fmt.Errorf("%q is not rate:burst", value)
+	}
+
+	limit := RateLimit{}
+	var err error
+	if limit.Rate, err = strconv.ParseFloat(rate, 64); err != nil || limit.Rate < 0 {
+		return RateLimit{}, fmt.Errorf("%q is not a valid rate", rate)
+	}
+	if limit.Burst, err = strconv.Atoi(burst); err != nil || limit.Burst < 1 {
+		return RateLimit{}, fmt.Errorf("%q is not a valid burst", burst)
+	}
+	return limit, nil
+}
+
+// TrustedProxiesFromEnv reads TRUSTED_PROXIES as a comma separated list of
+// the addresses or CIDRs of the proxies whose X-Forwarded-For header is
+// believed. No proxy is trusted by default.
+func TrustedProxiesFromEnv() []string {
+	var proxies []string
+	for _, proxy := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			proxies = append(proxies, proxy)
+		}
+	}
+	return proxies
+}
+
+// RateLimiter rejects clients that exceed their quota with 429 Too Many
+// Requests. Clients are identified by their authenticated identity, or else
+// by the IP address gin resolves through the trusted proxies. Unverified
+// headers such as the API key are never used, as a client could send a new
+// one with every request. Every response carries the RateLimit-Limit,
+// RateLimit-Remaining and RateLimit-Reset headers.
+func RateLimiter(store RateLimitStore, config RateLimitConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		class, limit := config.limitFor(c.Request.Method, c.FullPath())
+		if limit.Rate <= 0 {
+			c.Next()
+			return
+		}
+
+		result, err := store.Take(c.Request.Context(), class+"|"+clientKey(c), limit)
+		if err != nil {
+			slog.WarnContext(c.Request.Context(), "rate limit store failed, allowing request", "error", err)
+			c.Next()
+			return
+		}
+
+		c.Header("RateLimit-Limit", strconv.Itoa(limit.Burst))
+		c.Header("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		c.Header("RateLimit-Reset", strconv.Itoa(ceilSeconds(result.ResetAfter)))
+
+		if !result.Allowed {
+			c.Header("Retry-After", strconv.Itoa(ceilSeconds(result.RetryAfter)))
+			problems.Abort(c, problems.New(http.StatusTooManyRequests, problems.CodeRateLimited, "too many requests"))
+			return
+		}
+		c.Next()
+	}
+}
+
+func (config RateLimitConfig) limitFor(method, route string) (string, RateLimit) {
+	key := method + " " + route
+	if limit, ok := config.Routes[key]; ok {
+		return key, limit
+	}
+
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return "read", config.Read
+	default:
+		return "write", config.Write
+	}
+}
+
+func clientKey(c *gin.Context) string {
+	if identity := c.GetString(IdentityKey); identity != "" {
+		return "identity:" + identity
+	}
+	return "ip:" + c.ClientIP()
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+	limit  RateLimit
+}
+
+// maxBuckets is the number of buckets above which the in-memory store
+// sweeps on every request rather than once a minute.
+const maxBuckets = 100000
+
+type memoryRateLimitStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	now       func() time.Time
+	lastSweep time.Time
+}
+
+// NewMemoryRateLimitStore keeps token buckets in memory. Buckets that have
+// refilled completely are dropped periodically, and as soon as there are
+// more than maxBuckets.
+func NewMemoryRateLimitStore() RateLimitStore {
+	return &memoryRateLimitStore{
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}
+}
+
+func (s *memoryRateLimitStore) Take(ctx context.Context, key string, limit RateLimit) (RateLimitResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Burst), last: now, limit: limit}
+		s.buckets[key] = b
+	}
+
+	b.tokens = math.Min(float64(limit.Burst), b.tokens+now.Sub(b.last).Seconds()*limit.Rate)
+	b.last = now
+
+	result := RateLimitResult{}
+	if b.tokens >= 1 {
+		b.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = seconds((1 - b.tokens) / limit.Rate)
+	}
+	result.Remaining = int(math.Floor(b.tokens))
+	result.ResetAfter = seconds((float64(limit.Burst) - b.tokens) / limit.Rate)
+
+	return result, nil
+}
+
+// sweep drops buckets idle long enough to be full again once a minute, or
+// right away when there are too many.
+func (s *memoryRateLimitStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < time.Minute && len(s.buckets) < maxBuckets {
+		return
+	}
+	s.lastSweep = now
+
+	for key, b := range s.buckets {
+		if b.tokens+now.Sub(b.last).Seconds()*b.limit.Rate >= float64(b.limit.Burst) {
+			delete(s.buckets, key)
+		}
+	}
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
@@ -0,0 +1,217 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRateLimitConfigFromEnv(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		want    RateLimitConfig
+		wantErr bool
+	}{
+		{
+			name: "Should use the defaults",
+			env:  map[string]string{"RATE_LIMIT_READ": "", "RATE_LIMIT_WRITE": "", "RATE_LIMIT_ROUTES": ""},
+			want: DefaultRateLimitConfig(),
+		},
+		{
+			name: "Should read limits and route overrides",
+			env: map[string]string{
+				"RATE_LIMIT_READ":   "5:10",
+				"RATE_LIMIT_WRITE":  "0.5:2",
+				"RATE_LIMIT_ROUTES": "POST /cats=1:1; GET  /audit=2:4",
+			},
+			want: RateLimitConfig{
+				Read:  RateLimit{Rate: 5, Burst: 10},
+				Write: RateLimit{Rate: 0.5, Burst: 2},
+				Routes: map[string]RateLimit{
+					"POST /cats": {Rate: 1, Burst: 1},
+					"GET /audit": {Rate: 2, Burst: 4},
+				},
+			},
+		},
+		{
+			name:    "Should not accept a limit without a burst",
+			env:     map[string]string{"RATE_LIMIT_READ": "5", "RATE_LIMIT_WRITE": "", "RATE_LIMIT_ROUTES": ""},
+			wantErr: true,
+		},
+		{
+			name:    "Should not accept a route without a method",
+			env:     map[string]string{"RATE_LIMIT_READ": "", "RATE_LIMIT_WRITE": "", "RATE_LIMIT_ROUTES": "/cats=1:1"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			got, err := RateLimitConfigFromEnv()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RateLimitConfigFromEnv() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RateLimitConfigFromEnv() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_memoryRateLimitStore_Take(t *testing.T) {
+	now := time.Date(2022, 2, 10, 0, 0, 0, 0, time.UTC)
+	store := &memoryRateLimitStore{
+		buckets: make(map[string]*bucket),
+		now:     func() time.Time { return now },
+	}
+	limit := RateLimit{Rate: 1, Burst: 2}
+
+	tests := []struct {
+		name    string
+		advance time.Duration
+		want    RateLimitResult
+	}{
+		{
+			name: "Should allow the first request",
+			want: RateLimitResult{Allowed: true, Remaining: 1, ResetAfter: time.Second},
+		},
+		{
+			name: "Should allow a burst",
+			want: RateLimitResult{Allowed: true, Remaining: 0, ResetAfter: 2 * time.Second},
+		},
+		{
+			name: "Should deny an empty bucket",
+			want: RateLimitResult{Allowed: false, Remaining: 0, RetryAfter: time.Second, ResetAfter: 2 * time.Second},
+		},
+		{
+			name:    "Should refill over time",
+			advance: time.Second,
+			want:    RateLimitResult{Allowed: true, Remaining: 0, ResetAfter: 2 * time.Second},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now = now.Add(tt.advance)
+			got, err := store.Take(context.Background(), "client", limit)
+			if err != nil {
+				t.Errorf("memoryRateLimitStore.Take() error = %v", err)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("memoryRateLimitStore.Take() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	router := gin.New()
+	router.SetTrustedProxies(nil)
+	router.Use(func(c *gin.Context) {
+		if identity := c.Request.Header.Get("X-Test-Identity"); identity != "" {
+			c.Set(IdentityKey, identity)
+		}
+	})
+	router.Use(RateLimiter(NewMemoryRateLimitStore(), RateLimitConfig{
+		Read:  RateLimit{Rate: 0.001, Burst: 2},
+		Write: RateLimit{Rate: 0.001, Burst: 1},
+		Routes: map[string]RateLimit{
+			"GET /unlimited": {Rate: 0, Burst: 1},
+		},
+	}))
+	ok := func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{
+			"message": "ok",
+		})
+	}
+	router.GET("/cats", ok)
+	router.POST("/cats", ok)
+	router.GET("/unlimited", ok)
+
+	type args struct {
+		method   string
+		endpoint string
+		header   map[string]string
+	}
+	tests := []struct {
+		name          string
+		args          args
+		wantCode      int
+		wantRemaining string
+	}{
+		{
+			name:          "Should allow a read",
+			args:          args{method: "GET", endpoint: "/cats", header: map[string]string{}},
+			wantCode:      http.StatusOK,
+			wantRemaining: "1",
+		},
+		{
+			name:          "Should allow a write from the same client with its own quota",
+			args:          args{method: "POST", endpoint: "/cats", header: map[string]string{}},
+			wantCode:      http.StatusOK,
+			wantRemaining: "0",
+		},
+		{
+			name:          "Should reject a second write",
+			args:          args{method: "POST", endpoint: "/cats", header: map[string]string{}},
+			wantCode:      http.StatusTooManyRequests,
+			wantRemaining: "0",
+		},
+		{
+			name:          "Should not track an unverified API key separately",
+			args:          args{method: "POST", endpoint: "/cats", header: map[string]string{APIKeyHeader: "random"}},
+			wantCode:      http.StatusTooManyRequests,
+			wantRemaining: "0",
+		},
+		{
+			name:          "Should not believe X-Forwarded-For from an untrusted proxy",
+			args:          args{method: "POST", endpoint: "/cats", header: map[string]string{"X-Forwarded-For": "203.0.113.7"}},
+			wantCode:      http.StatusTooManyRequests,
+			wantRemaining: "0",
+		},
+		{
+			name:          "Should track an authenticated identity separately",
+			args:          args{method: "POST", endpoint: "/cats", header: map[string]string{"X-Test-Identity": "alice"}},
+			wantCode:      http.StatusOK,
+			wantRemaining: "0",
+		},
+		{
+			name:          "Should not limit a route with a zero rate",
+			args:          args{method: "GET", endpoint: "/unlimited", header: map[string]string{}},
+			wantCode:      http.StatusOK,
+			wantRemaining: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(tt.args.method, tt.args.endpoint, nil)
+			req.RemoteAddr = "192.0.2.1:1234"
+			for key, value := range tt.args.header {
+				req.Header.Add(key, value)
+			}
+			router.ServeHTTP(w, req)
+
+			if tt.wantCode != w.Code {
+				t.Errorf("RateLimiter() error = %v, wantCode %v", w.Code, tt.wantCode)
+				return
+			}
+			if got := w.Header().Get("RateLimit-Remaining"); got != tt.wantRemaining {
+				t.Errorf("RateLimiter() RateLimit-Remaining = %v, want %v", got, tt.wantRemaining)
+			}
+			if tt.wantCode == http.StatusTooManyRequests && w.Header().Get("Retry-After") == "" {
+				t.Errorf("RateLimiter() did not set Retry-After")
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package middlewares
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/gin-gonic/gin"
+	"github.com/one-byte-data/go-api-sample/internal/problems"
+)
+
+// Recovery turns a panic in a later handler into a 500 problem response
+// and logs the stack trace. http.ErrAbortHandler is re-raised so net/http
+// can abort the connection as intended.
+func Recovery() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+			if err, ok := recovered.(error); ok && errors.Is(err, http.ErrAbortHandler) {
+				panic(recovered)
+			}
+
+			slog.ErrorContext(c.Request.Context(), "recovered from panic",
+				"panic", fmt.Sprint(recovered),
+				"stack", string(debug.Stack()),
+			)
+			problems.Internal(c, fmt.Errorf("panic: %v", recovered))
+		}()
+		c.Next()
+	}
+}
@@ -0,0 +1,33 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/one-byte-data/go-api-sample/internal/problems"
+)
+
+func TestRecovery(t *testing.T) {
+	router := gin.New()
+	router.Use(Recovery())
+	router.GET("/panic", func(c *gin.Context) {
+		panic("boom")
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/panic", nil)
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("Recovery() error = %v, wantCode %v", w.Code, http.StatusInternalServerError)
+	}
+	if got := w.Header().Get("Content-Type"); got != problems.ContentType {
+		t.Errorf("Recovery() Content-Type = %v, want %v", got, problems.ContentType)
+	}
+	if !strings.Contains(w.Body.String(), `"code":"internal_error"`) || strings.Contains(w.Body.String(), "boom") {
+		t.Errorf("Recovery() body = %v, want an internal_error problem without the panic value", w.Body.String())
+	}
+}
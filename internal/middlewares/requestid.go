@@ -0,0 +1,34 @@
+package middlewares
+
+import (
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/one-byte-data/go-api-sample/internal/contexts"
+)
+
+const RequestIDHeader = "X-Request-ID"
+
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// RequestID accepts the caller's X-Request-ID or generates one, stores it on
+// the request context and echoes it back in the response.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.Request.Header.Get(RequestIDHeader)
+		if !ValidRequestID(requestID) {
+			requestID = uuid.New().String()
+		}
+
+		c.Request = c.Request.WithContext(contexts.WithRequestID(c.Request.Context(), requestID))
+		c.Header(RequestIDHeader, requestID)
+		c.Next()
+	}
+}
+
+// ValidRequestID reports whether a caller supplied request ID is short and
+// safe enough to log and echo back.
+func ValidRequestID(requestID string) bool {
+	return validRequestID.MatchString(requestID)
+}
@@ -0,0 +1,63 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/one-byte-data/go-api-sample/internal/contexts"
+)
+
+func TestRequestID(t *testing.T) {
+	router := gin.New()
+	router.Use(RequestID())
+	router.GET("/health", func(c *gin.Context) {
+		c.String(http.StatusOK, contexts.RequestID(c.Request.Context()))
+	})
+
+	tests := []struct {
+		name          string
+		requestID     string
+		wantGenerated bool
+	}{
+		{
+			name:          "Should accept the caller's request ID",
+			requestID:     "abc-123",
+			wantGenerated: false,
+		},
+		{
+			name:          "Should generate a request ID",
+			requestID:     "",
+			wantGenerated: true,
+		},
+		{
+			name:          "Should replace a request ID with invalid characters",
+			requestID:     "abc 123\n",
+			wantGenerated: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/health", nil)
+			if tt.requestID != "" {
+				req.Header.Add(RequestIDHeader, tt.requestID)
+			}
+			router.ServeHTTP(w, req)
+
+			got := w.Header().Get(RequestIDHeader)
+			if got != w.Body.String() {
+				t.Errorf("RequestID() echoed %v, context has %v", got, w.Body.String())
+			}
+			if tt.wantGenerated {
+				if _, err := uuid.Parse(got); err != nil {
+					t.Errorf("RequestID() = %v, want a generated UUID", got)
+				}
+			} else if got != tt.requestID {
+				t.Errorf("RequestID() = %v, want %v", got, tt.requestID)
+			}
+		})
+	}
+}
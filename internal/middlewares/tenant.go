@@ -0,0 +1,96 @@
+package middlewares
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/one-byte-data/go-api-sample/internal/contexts"
+)
+
+const TenantHeader = "X-Tenant-ID"
+
+// TenantKey is the gin context key an authentication middleware sets to
+// the tenant in the claims of the caller.
+const TenantKey = "tenant"
+
+// TenantCredentials are the API keys callers present in the APIKeyHeader to
+// act for a tenant. The admin key may act for any tenant.
+type TenantCredentials struct {
+	AdminKey string
+	// Keys maps each tenant to its API key.
+	Keys map[string]string
+}
+
+// TenantCredentialsFromEnv reads TENANT_API_KEYS as a comma separated list
+// of "tenant=key" and the admin key from ADMIN_API_KEY.
+func TenantCredentialsFromEnv() (TenantCredentials, error) {
+	credentials := TenantCredentials{AdminKey: AdminKeyFromEnv(), Keys: map[string]string{}}
+
+	if value := os.Getenv("TENANT_API_KEYS"); value != "" {
+		for _, entry := range strings.Split(value, ",") {
+			tenant, key, ok := strings.Cut(strings.TrimSpace(entry), "=")
+			if !ok || tenant == "" || key == "" {
+				return credentials, fmt.Errorf("TENANT_API_KEYS: %q is not tenant=key", entry)
+			}
+			credentials.Keys[tenant] = key
+		}
+	}
+
+	return credentials, nil
+}
+
+// Claim returns the tenant a caller presenting apiKey acts for: the tenant
+// of its key, the requested tenant for the admin key, or else an empty
+// string.
+func (t TenantCredentials) Claim(apiKey string, requested string) string {
+	if apiKey == "" {
+		return ""
+	}
+	if t.AdminKey != "" && subtle.ConstantTimeCompare([]byte(apiKey), []byte(t.AdminKey)) == 1 {
+		return requested
+	}
+	claimed := ""
+	for tenant, key := range t.Keys {
+		if subtle.ConstantTimeCompare([]byte(apiKey), []byte(key)) == 1 {
+			claimed = tenant
+		}
+	}
+	return claimed
+}
+
+// TenantAuth sets TenantKey to the tenant claimed by the API key of the
+// request, if any.
+func TenantAuth(credentials TenantCredentials) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claimed := credentials.Claim(c.Request.Header.Get(APIKeyHeader), c.Request.Header.Get(TenantHeader))
+		if claimed != "" {
+			c.Set(TenantKey, claimed)
+		}
+		c.Next()
+	}
+}
+
+// RequestedTenant returns the tenant a request is made for, see
+// ResolveTenant.
+func RequestedTenant(c *gin.Context) (tenant string, ok bool) {
+	return ResolveTenant(c.GetString(TenantKey), c.Request.Header.Get(TenantHeader))
+}
+
+// ResolveTenant returns the tenant a request is made for: the claimed
+// tenant, or else the default tenant, given as an empty string. ok is false
+// when the requested tenant is another one, since callers may only act for
+// the tenant of their credentials and callers without credentials only for
+// the default tenant.
+func ResolveTenant(claimed string, requested string) (tenant string, ok bool) {
+	switch {
+	case claimed == "":
+		return "", requested == "" || requested == contexts.DefaultTenant
+	case requested != "" && requested != claimed:
+		return "", false
+	default:
+		return claimed, true
+	}
+}
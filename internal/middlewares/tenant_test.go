@@ -0,0 +1,103 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRequestedTenant(t *testing.T) {
+	tests := []struct {
+		name    string
+		claimed string
+		header  string
+		want    string
+		wantOK  bool
+	}{
+		{name: "Should use the tenant of the claims", claimed: "shelter-a", want: "shelter-a", wantOK: true},
+		{name: "Should not accept a tenant header without claims", header: "shelter-b", want: "", wantOK: false},
+		{name: "Should accept the default tenant without claims", header: "default", want: "", wantOK: true},
+		{name: "Should accept a header matching the claims", claimed: "shelter-a", header: "shelter-a", want: "shelter-a", wantOK: true},
+		{name: "Should not accept a header naming another tenant", claimed: "shelter-a", header: "shelter-b", want: "", wantOK: false},
+		{name: "Should return nothing without claims or header", want: "", wantOK: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request, _ = http.NewRequest("GET", "/cats", nil)
+			if tt.header != "" {
+				c.Request.Header.Set(TenantHeader, tt.header)
+			}
+			if tt.claimed != "" {
+				c.Set(TenantKey, tt.claimed)
+			}
+
+			got, ok := RequestedTenant(c)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("RequestedTenant() = %q, %v, want %q, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestTenantAuth(t *testing.T) {
+	credentials := TenantCredentials{AdminKey: "admin", Keys: map[string]string{"shelter-a": "key-a"}}
+	router := gin.New()
+	router.Use(TenantAuth(credentials))
+	router.GET("/tenant", func(c *gin.Context) {
+		tenant, ok := RequestedTenant(c)
+		c.JSON(http.StatusOK, gin.H{"tenant": tenant, "ok": ok})
+	})
+
+	tests := []struct {
+		name         string
+		apiKey       string
+		header       string
+		wantResponse string
+	}{
+		{name: "Should use the tenant of the API key", apiKey: "key-a", wantResponse: `{"ok":true,"tenant":"shelter-a"}`},
+		{name: "Should not accept a header naming another tenant than the key", apiKey: "key-a", header: "shelter-b", wantResponse: `{"ok":false,"tenant":""}`},
+		{name: "Should let the admin key name a tenant", apiKey: "admin", header: "shelter-b", wantResponse: `{"ok":true,"tenant":"shelter-b"}`},
+		{name: "Should not let an unknown key name a tenant", apiKey: "guess", header: "shelter-a", wantResponse: `{"ok":false,"tenant":""}`},
+		{name: "Should use the default tenant without a key", wantResponse: `{"ok":true,"tenant":""}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/tenant", nil)
+			if tt.apiKey != "" {
+				req.Header.Set(APIKeyHeader, tt.apiKey)
+			}
+			if tt.header != "" {
+				req.Header.Set(TenantHeader, tt.header)
+			}
+			router.ServeHTTP(w, req)
+
+			if w.Body.String() != tt.wantResponse {
+				t.Errorf("TenantAuth() error = %v, wantResponse %v", w.Body.String(), tt.wantResponse)
+			}
+		})
+	}
+}
+
+func TestTenantCredentialsFromEnv(t *testing.T) {
+	t.Setenv("ADMIN_API_KEY", "admin")
+	t.Setenv("TENANT_API_KEYS", "shelter-a=key-a, shelter-b=key-b")
+
+	got, err := TenantCredentialsFromEnv()
+	if err != nil {
+		t.Fatalf("TenantCredentialsFromEnv() error = %v", err)
+	}
+	want := TenantCredentials{AdminKey: "admin", Keys: map[string]string{"shelter-a": "key-a", "shelter-b": "key-b"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("TenantCredentialsFromEnv() = %v, want %v", got, want)
+	}
+
+	t.Setenv("TENANT_API_KEYS", "shelter-a")
+	if _, err := TenantCredentialsFromEnv(); err == nil {
+		t.Errorf("TenantCredentialsFromEnv() error = nil, want an error for an entry without a key")
+	}
+}
@@ -0,0 +1,90 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	router := gin.New()
+	router.Use(Tracing())
+	router.GET("/cats/:id", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{
+			"message": "ok",
+		})
+	})
+	router.GET("/broken", func(c *gin.Context) {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"message": "there was an error",
+		})
+	})
+
+	type args struct {
+		endpoint string
+		header   map[string]string
+	}
+	tests := []struct {
+		name        string
+		args        args
+		wantName    string
+		wantTraceID string
+		wantStatus  codes.Code
+	}{
+		{
+			name:       "Should start a span named after the route template",
+			args:       args{endpoint: "/cats/1234", header: map[string]string{}},
+			wantName:   "GET /cats/:id",
+			wantStatus: codes.Unset,
+		},
+		{
+			name: "Should continue a trace from the traceparent header",
+			args: args{endpoint: "/cats/1234", header: map[string]string{
+				"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			}},
+			wantName:    "GET /cats/:id",
+			wantTraceID: "4bf92f3577b34da6a3ce929d0e0e4736",
+			wantStatus:  codes.Unset,
+		},
+		{
+			name:       "Should mark server errors",
+			args:       args{endpoint: "/broken", header: map[string]string{}},
+			wantName:   "GET /broken",
+			wantStatus: codes.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", tt.args.endpoint, nil)
+			for key, value := range tt.args.header {
+				req.Header.Add(key, value)
+			}
+			router.ServeHTTP(w, req)
+
+			spans := recorder.Ended()
+			span := spans[len(spans)-1]
+
+			if span.Name() != tt.wantName {
+				t.Errorf("Tracing() span name = %v, want %v", span.Name(), tt.wantName)
+			}
+			if tt.wantTraceID != "" && span.SpanContext().TraceID().String() != tt.wantTraceID {
+				t.Errorf("Tracing() trace ID = %v, want %v", span.SpanContext().TraceID(), tt.wantTraceID)
+			}
+			if span.Status().Code != tt.wantStatus {
+				t.Errorf("Tracing() span status = %v, want %v", span.Status().Code, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -4,14 +4,13 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/one-byte-data/go-api-sample/internal/problems"
 )
 
 func ValidateHeader() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		if c.Request.Header.Get("X-Not-Valid") != "" {
-			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
-				"message": "not allowed",
-			})
+			problems.Abort(c, problems.New(http.StatusForbidden, problems.CodeNotAllowed, "not allowed"))
 			return
 		}
 		c.Next()
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// The review statuses of an adoption application. Applications are
+// submitted, may be put under review, and end approved or rejected, see
+// services.CanReview.
+const (
+	ApplicationSubmitted   = "submitted"
+	ApplicationUnderReview = "under_review"
+	ApplicationApproved    = "approved"
+	ApplicationRejected    = "rejected"
+)
+
+// Application is a request to adopt a cat or dog, identified by its species
+// and ID.
+type Application struct {
+	ID             uuid.UUID `json:"id,omitempty"`
+	Species        string    `json:"species" binding:"required,oneof=cats dogs" gorm:"index:idx_applications_animal;not null"`
+	AnimalID       uuid.UUID `json:"animal_id" binding:"required" gorm:"index:idx_applications_animal;not null"`
+	ApplicantName  string    `json:"applicant_name" binding:"required,min=2,max=64" gorm:"check:applicant_name <> ''"`
+	ApplicantEmail string    `json:"applicant_email" binding:"required,email,max=254"`
+	ApplicantPhone string    `json:"applicant_phone,omitempty" binding:"max=32"`
+	Notes          string    `json:"notes,omitempty" binding:"max=1000"`
+	// Status, Reviewer and the timestamps are set by the services. The
+	// reviewer is the actor of the last review.
+	Status    string    `json:"status,omitempty" gorm:"index;not null;default:submitted"`
+	Reviewer  string    `json:"reviewer,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	// TenantID is set from the context by the repository, see
+	// contexts.WithTenant.
+	TenantID string `json:"-" gorm:"index;not null;default:default"`
+}
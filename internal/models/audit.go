@@ -0,0 +1,29 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	AuditActionCreate = "create"
+	AuditActionUpdate = "update"
+	AuditActionDelete = "delete"
+)
+
+// AuditEntry is an append-only record of a single mutation. Changes holds a
+// JSON object keyed by field name with the old and new value of every field
+// that changed.
+type AuditEntry struct {
+	ID         uuid.UUID       `json:"id"`
+	Resource   string          `json:"resource" gorm:"index;not null"`
+	ResourceID uuid.UUID       `json:"resource_id" gorm:"index;not null"`
+	Action     string          `json:"action" gorm:"not null"`
+	Actor      string          `json:"actor" gorm:"index;not null"`
+	RequestID  string          `json:"request_id"`
+	Timestamp  time.Time       `json:"timestamp" gorm:"index;not null"`
+	Changes    json.RawMessage `json:"changes" gorm:"type:jsonb" swaggertype:"object"`
+	TenantID   string          `json:"-" gorm:"index;not null;default:default"`
+}
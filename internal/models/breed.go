@@ -0,0 +1,29 @@
+package models
+
+import (
+	"github.com/google/uuid"
+)
+
+// The breed statuses of a cat or dog, set by the services from the breed
+// catalogue.
+const (
+	// BreedKnown marks a breed found in the catalogue, stored by its
+	// canonical name.
+	BreedKnown = "known"
+	// BreedUnknown flags a breed the catalogue does not know, accepted
+	// because strict mode is off.
+	BreedUnknown = "unknown"
+)
+
+// Breed is an entry of the breed catalogue of a species, cats or dogs.
+// Breeds of cats and dogs are matched against the name and aliases
+// regardless of case and stored by the name. Adults must weigh between
+// MinWeight and MaxWeight, where zero is no bound.
+type Breed struct {
+	ID        uuid.UUID `json:"id,omitempty"`
+	Species   string    `json:"species" gorm:"uniqueIndex:idx_breeds_species_name;not null"`
+	Name      string    `json:"name" binding:"required,min=2,max=24" gorm:"uniqueIndex:idx_breeds_species_name;check:name <> ''"`
+	Aliases   []string  `json:"aliases" binding:"max=20,dive,min=2,max=24" gorm:"serializer:json"`
+	MinWeight int       `json:"min_weight" binding:"gte=0"`
+	MaxWeight int       `json:"max_weight" binding:"omitempty,gtefield=MinWeight"`
+}
@@ -13,4 +13,13 @@ type Dog struct {
 	Color     string    `json:"color" binding:"required,min=2,max=24" gorm:"check:color <> ''"`
 	Birthdate time.Time `json:"birthdate" binding:"required"`
 	Weight    int       `json:"weight" binding:"required,gte=1,lt=300" gorm:"check:weight > 0"`
+	// BreedStatus tells whether the breed is in the breed catalogue, see
+	// BreedKnown and BreedUnknown. It is set by the services.
+	BreedStatus string `json:"breed_status,omitempty"`
+	// Status is the shelter status, see StatusIntake. It is set by the
+	// services and changed through transitions.
+	Status string `json:"status,omitempty" gorm:"index;not null;default:intake"`
+	// TenantID is set from the context by the repository, see
+	// contexts.WithTenant.
+	TenantID string `json:"-" gorm:"index;not null;default:default"`
 }
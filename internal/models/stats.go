@@ -0,0 +1,40 @@
+package models
+
+// AnimalStats summarizes the cats or dogs matching a filter.
+type AnimalStats struct {
+	Total int64 `json:"total"`
+	// Breeds and Colors are ordered by count, largest first, then by value.
+	Breeds []GroupCount `json:"breeds"`
+	Colors []GroupCount `json:"colors"`
+	// Ages lists every age bucket, youngest first, including empty ones.
+	Ages []AgeBucket `json:"ages"`
+	// Weights is ordered by breed.
+	Weights []BreedWeight `json:"weights"`
+}
+
+// GroupCount is the number of animals sharing a breed or color.
+type GroupCount struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+// AgeBucket counts the animals at least MinYears and less than MaxYears
+// old, derived from their birthdate. The oldest bucket has no MaxYears.
+type AgeBucket struct {
+	Label    string `json:"label"`
+	MinYears int    `json:"min_years"`
+	MaxYears int    `json:"max_years,omitempty"`
+	Count    int64  `json:"count"`
+}
+
+// BreedWeight summarizes the weights of a breed. The median averages the
+// two middle weights of an even count; the percentiles are nearest-rank.
+type BreedWeight struct {
+	Breed  string  `json:"breed"`
+	Count  int64   `json:"count"`
+	Mean   float64 `json:"mean"`
+	Median float64 `json:"median"`
+	P25    float64 `json:"p25"`
+	P75    float64 `json:"p75"`
+	P90    float64 `json:"p90"`
+}
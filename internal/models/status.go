@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// The shelter statuses of a cat or dog. New animals start at intake and
+// only change status through transitions, see services.CanTransition.
+const (
+	StatusIntake    = "intake"
+	StatusAvailable = "available"
+	StatusOnHold    = "on_hold"
+	StatusAdopted   = "adopted"
+	StatusReturned  = "returned"
+)
+
+// Transition is an append-only record of a change of the status of a cat
+// or dog, by whom and when.
+type Transition struct {
+	ID         uuid.UUID `json:"id"`
+	Resource   string    `json:"resource" gorm:"index;not null"`
+	ResourceID uuid.UUID `json:"resource_id" gorm:"index;not null"`
+	From       string    `json:"from" gorm:"column:from_status;not null"`
+	To         string    `json:"to" gorm:"column:to_status;not null"`
+	Actor      string    `json:"actor" gorm:"not null"`
+	RequestID  string    `json:"request_id"`
+	Timestamp  time.Time `json:"timestamp" gorm:"index;not null"`
+	TenantID   string    `json:"-" gorm:"index;not null;default:default"`
+}
@@ -0,0 +1,23 @@
+package models
+
+import (
+	"regexp"
+	"time"
+)
+
+// tenantIDPattern allows DNS labels: 1 to 63 lowercase letters, digits and
+// hyphens, not starting or ending with a hyphen.
+var tenantIDPattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+// Tenant is a shelter sharing the deployment. Cats, dogs and audit entries
+// belong to exactly one tenant and are only visible to it.
+type Tenant struct {
+	ID        string    `json:"id" binding:"required,max=63" gorm:"primaryKey"`
+	Name      string    `json:"name" binding:"required,min=2,max=64" gorm:"check:name <> ''"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ValidTenantID reports whether id can identify a tenant.
+func ValidTenantID(id string) bool {
+	return tenantIDPattern.MatchString(id)
+}
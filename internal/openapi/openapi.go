@@ -0,0 +1,172 @@
+// Package openapi models the parts of an OpenAPI 3.1 document this API
+// uses and derives JSON Schemas for Go types by reflection.
+package openapi
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const Version = "3.1.0"
+
+type Document struct {
+	OpenAPI    string                `json:"openapi"`
+	Info       Info                  `json:"info"`
+	Servers    []Server              `json:"servers,omitempty"`
+	Tags       []Tag                 `json:"tags,omitempty"`
+	Security   []SecurityRequirement `json:"security,omitempty"`
+	Paths      map[string]PathItem   `json:"paths"`
+	Components Components            `json:"components"`
+}
+
+type Info struct {
+	Title       string   `json:"title"`
+	Version     string   `json:"version"`
+	Description string   `json:"description,omitempty"`
+	License     *License `json:"license,omitempty"`
+}
+
+type License struct {
+	Name string `json:"name"`
+	URL  string `json:"url,omitempty"`
+}
+
+type Server struct {
+	URL         string `json:"url"`
+	Description string `json:"description,omitempty"`
+}
+
+type Tag struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// PathItem maps lower case HTTP methods to operations.
+type PathItem map[string]*Operation
+
+type Operation struct {
+	OperationID string                `json:"operationId"`
+	Summary     string                `json:"summary"`
+	Description string                `json:"description,omitempty"`
+	Tags        []string              `json:"tags,omitempty"`
+	Parameters  []*Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]*Response  `json:"responses"`
+	Security    []SecurityRequirement `json:"security,omitempty"`
+}
+
+type Parameter struct {
+	Ref         string  `json:"$ref,omitempty"`
+	Name        string  `json:"name,omitempty"`
+	In          string  `json:"in,omitempty"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema,omitempty"`
+}
+
+type RequestBody struct {
+	Description string               `json:"description,omitempty"`
+	Required    bool                 `json:"required,omitempty"`
+	Content     map[string]MediaType `json:"content"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema,omitempty"`
+}
+
+type Response struct {
+	Ref         string               `json:"$ref,omitempty"`
+	Description string               `json:"description,omitempty"`
+	Headers     map[string]*Header   `json:"headers,omitempty"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+type Header struct {
+	Ref         string  `json:"$ref,omitempty"`
+	Description string  `json:"description,omitempty"`
+	Schema      *Schema `json:"schema,omitempty"`
+}
+
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas"`
+	Parameters      map[string]*Parameter      `json:"parameters,omitempty"`
+	Headers         map[string]*Header         `json:"headers,omitempty"`
+	Responses       map[string]*Response       `json:"responses,omitempty"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+type SecurityScheme struct {
+	Type        string `json:"type"`
+	Name        string `json:"name,omitempty"`
+	In          string `json:"in,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// SecurityRequirement maps scheme names to scopes. An empty requirement
+// makes the security of an operation optional.
+type SecurityRequirement map[string][]string
+
+func New(info Info) *Document {
+	return &Document{
+		OpenAPI: Version,
+		Info:    info,
+		Paths:   make(map[string]PathItem),
+		Components: Components{
+			Schemas:         make(map[string]*Schema),
+			Parameters:      make(map[string]*Parameter),
+			Headers:         make(map[string]*Header),
+			Responses:       make(map[string]*Response),
+			SecuritySchemes: make(map[string]*SecurityScheme),
+		},
+	}
+}
+
+// Add registers op under a gin route, so /cats/:id becomes /cats/{id}.
+func (d *Document) Add(method string, route string, op *Operation) {
+	path := Path(route)
+	if d.Paths[path] == nil {
+		d.Paths[path] = make(PathItem)
+	}
+	d.Paths[path][strings.ToLower(method)] = op
+}
+
+// Path converts gin path parameters to OpenAPI path templates.
+func Path(route string) string {
+	segments := strings.Split(route, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// JSON describes a body of the given media type.
+func JSON(contentType string, schema *Schema) map[string]MediaType {
+	return map[string]MediaType{
+		contentType: {Schema: schema},
+	}
+}
+
+func ParameterRef(name string) *Parameter {
+	return &Parameter{Ref: "#/components/parameters/" + name}
+}
+
+func HeaderRef(name string) *Header {
+	return &Header{Ref: "#/components/headers/" + name}
+}
+
+func ResponseRef(name string) *Response {
+	return &Response{Ref: "#/components/responses/" + name}
+}
+
+// StatusKey is the key of a response in Operation.Responses.
+func StatusKey(status int) string {
+	return strconv.Itoa(status)
+}
+
+// StatusText is used as the description of responses that need no other.
+func StatusText(status int) string {
+	return http.StatusText(status)
+}
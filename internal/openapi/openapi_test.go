@@ -0,0 +1,75 @@
+package openapi
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestPath(t *testing.T) {
+	tests := []struct {
+		name  string
+		route string
+		want  string
+	}{
+		{name: "Should keep a static route", route: "/cats", want: "/cats"},
+		{name: "Should convert a parameter", route: "/cats/:id/history", want: "/cats/{id}/history"},
+		{name: "Should convert a wildcard", route: "/swagger/*any", want: "/swagger/{any}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Path(tt.route); got != tt.want {
+				t.Errorf("Path() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+type owner struct {
+	Name string `json:"name"`
+}
+
+type pet struct {
+	ID       uuid.UUID `json:"id,omitempty"`
+	Name     string    `json:"name" binding:"required,min=2,max=24"`
+	Weight   int       `json:"weight" binding:"required,gte=1,lt=100"`
+	Born     time.Time `json:"born"`
+	Nickname *string   `json:"nickname"`
+	Owner    owner     `json:"owner"`
+	Tags     []string  `json:"tags"`
+	Secret   string    `json:"-"`
+	internal string
+}
+
+func TestDocument_Schema(t *testing.T) {
+	doc := New(Info{Title: "test", Version: "1"})
+
+	ref := doc.Schema("Pet", pet{})
+	if ref.Ref != "#/components/schemas/Pet" {
+		t.Errorf("Document.Schema() = %v, want a reference to Pet", ref.Ref)
+	}
+
+	two, one, hundred := 2, 1.0, 100.0
+	twentyFour := 24
+	want := &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"id":       {Type: "string", Format: "uuid"},
+			"name":     {Type: "string", MinLength: &two, MaxLength: &twentyFour},
+			"weight":   {Type: "integer", Format: "int64", Minimum: &one, ExclusiveMaximum: &hundred},
+			"born":     {Type: "string", Format: "date-time"},
+			"nickname": {Type: []string{"string", "null"}},
+			"owner":    {Ref: "#/components/schemas/owner"},
+			"tags":     {Type: "array", Items: &Schema{Type: "string"}},
+		},
+		Required: []string{"name", "weight"},
+	}
+	if got := doc.Components.Schemas["Pet"]; !reflect.DeepEqual(got, want) {
+		t.Errorf("Document.Schema() = %+v, want %+v", got, want)
+	}
+	if _, ok := doc.Components.Schemas["owner"]; !ok {
+		t.Errorf("Document.Schema() did not register the nested owner schema")
+	}
+}
@@ -0,0 +1,178 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Schema is the subset of JSON Schema 2020-12 used by OpenAPI 3.1. Type is
+// either a string or a list of strings.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 interface{}        `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	ExclusiveMinimum     *float64           `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum     *float64           `json:"exclusiveMaximum,omitempty"`
+}
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	uuidType       = reflect.TypeOf(uuid.UUID{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+// Schema registers the schema of v's type under name and returns a
+// reference to it. Named struct types reached from its fields are
+// registered under their type names.
+func (d *Document) Schema(name string, v interface{}) *Schema {
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if _, ok := d.Components.Schemas[name]; !ok {
+		d.Components.Schemas[name] = &Schema{}
+		*d.Components.Schemas[name] = *d.structSchema(t)
+	}
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+// ArrayOf describes a list of items.
+func ArrayOf(items *Schema) *Schema {
+	return &Schema{Type: "array", Items: items}
+}
+
+func (d *Document) schemaFor(t reflect.Type) *Schema {
+	switch t {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case uuidType:
+		return &Schema{Type: "string", Format: "uuid"}
+	case rawMessageType:
+		return &Schema{Type: "object"}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		schema := d.schemaFor(t.Elem())
+		if typ, ok := schema.Type.(string); ok {
+			schema.Type = []string{typ, "null"}
+		}
+		return schema
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return ArrayOf(d.schemaFor(t.Elem()))
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: d.schemaFor(t.Elem())}
+	case reflect.Struct:
+		if t.Name() != "" {
+			return d.Schema(t.Name(), reflect.New(t).Interface())
+		}
+		return d.structSchema(t)
+	}
+	return &Schema{}
+}
+
+func (d *Document) structSchema(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		var property *Schema
+		if swaggerType := field.Tag.Get("swaggertype"); swaggerType != "" {
+			property = &Schema{Type: swaggerType}
+		} else {
+			property = d.schemaFor(field.Type)
+		}
+		if applyBinding(property, field.Tag.Get("binding")) {
+			schema.Required = append(schema.Required, name)
+		}
+		schema.Properties[name] = property
+	}
+	return schema
+}
+
+// applyBinding translates validator tags into schema keywords and reports
+// whether the field is required.
+func applyBinding(schema *Schema, tag string) bool {
+	required := false
+	for _, rule := range strings.Split(tag, ",") {
+		name, param, _ := strings.Cut(rule, "=")
+		if name == "required" {
+			required = true
+			continue
+		}
+		if name == "oneof" {
+			schema.Enum = strings.Fields(param)
+			continue
+		}
+
+		value, err := strconv.ParseFloat(param, 64)
+		if err != nil {
+			continue
+		}
+		isString := schema.Type == "string"
+		switch name {
+		case "min":
+			if isString {
+				length := int(value)
+				schema.MinLength = &length
+			} else {
+				schema.Minimum = &value
+			}
+		case "max":
+			if isString {
+				length := int(value)
+				schema.MaxLength = &length
+			} else {
+				schema.Maximum = &value
+			}
+		case "gte":
+			schema.Minimum = &value
+		case "gt":
+			schema.ExclusiveMinimum = &value
+		case "lte":
+			schema.Maximum = &value
+		case "lt":
+			schema.ExclusiveMaximum = &value
+		}
+	}
+	return required
+}
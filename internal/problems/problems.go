@@ -0,0 +1,209 @@
+// Package problems renders every error the API returns as an RFC 7807
+// application/problem+json document.
+package problems
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/one-byte-data/go-api-sample/internal/contexts"
+)
+
+const ContentType = "application/problem+json"
+
+// typePrefix is prepended to the code to build the problem type URI.
+const typePrefix = "urn:go-api-sample:problem:"
+
+// Codes identify the kind of problem. Clients should branch on the code
+// rather than on the title or detail, which are meant for people.
+const (
+	CodeInvalidID             = "invalid_id"
+	CodeInvalidBody           = "invalid_body"
+	CodeInvalidQuery          = "invalid_query"
+	CodeNotFound              = "not_found"
+	CodeNotAllowed            = "not_allowed"
+	CodeOriginNotAllowed      = "origin_not_allowed"
+	CodeRateLimited           = "rate_limited"
+	CodeInvalidIdempotencyKey = "invalid_idempotency_key"
+	CodeIdempotencyKeyInUse   = "idempotency_key_in_use"
+	CodeIdempotencyKeyReused  = "idempotency_key_reused"
+	CodeInvalidTenant         = "invalid_tenant"
+	CodeConflict              = "conflict"
+	CodeInvalidTransition     = "invalid_transition"
+	CodeInternal              = "internal_error"
+	CodeUnavailable           = "unavailable"
+)
+
+var titles = map[string]string{
+	CodeInvalidID:             "Invalid ID",
+	CodeInvalidBody:           "Invalid request body",
+	CodeInvalidQuery:          "Invalid query parameter",
+	CodeNotFound:              "Not found",
+	CodeNotAllowed:            "Not allowed",
+	CodeOriginNotAllowed:      "Origin not allowed",
+	CodeRateLimited:           "Too many requests",
+	CodeInvalidIdempotencyKey: "Invalid idempotency key",
+	CodeIdempotencyKeyInUse:   "Idempotency key in use",
+	CodeIdempotencyKeyReused:  "Idempotency key reused",
+	CodeInvalidTenant:         "Invalid tenant",
+	CodeConflict:              "Conflict",
+	CodeInvalidTransition:     "Invalid status transition",
+	CodeInternal:              "Internal server error",
+	CodeUnavailable:           "Service unavailable",
+}
+
+// FieldError describes a single field of the request that failed
+// validation. Field is the JSON name of the field and Rule the validation
+// tag it broke, e.g. required or max.
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+type Problem struct {
+	Type      string       `json:"type"`
+	Title     string       `json:"title"`
+	Status    int          `json:"status"`
+	Detail    string       `json:"detail,omitempty"`
+	Code      string       `json:"code"`
+	Instance  string       `json:"instance,omitempty"`
+	RequestID string       `json:"request_id,omitempty"`
+	Errors    []FieldError `json:"errors,omitempty"`
+}
+
+func New(status int, code string, detail string) *Problem {
+	title, ok := titles[code]
+	if !ok {
+		title = http.StatusText(status)
+	}
+	return &Problem{
+		Type:   typePrefix + code,
+		Title:  title,
+		Status: status,
+		Detail: detail,
+		Code:   code,
+	}
+}
+
+// Abort writes the problem as the response and stops the handler chain.
+func Abort(c *gin.Context, p *Problem) {
+	p.Instance = c.Request.URL.Path
+	p.RequestID = contexts.RequestID(c.Request.Context())
+
+	c.Header("Content-Type", ContentType)
+	c.AbortWithStatusJSON(p.Status, p)
+}
+
+// Internal aborts with a 500 that hides err from the client. The error is
+// attached to the context so the request log still records it.
+func Internal(c *gin.Context, err error) {
+	c.Error(err)
+	Abort(c, New(http.StatusInternalServerError, CodeInternal, "there was an error"))
+}
+
+// Binding turns an error from binding the request body into a problem with
+// one entry per invalid field.
+func Binding(err error) *Problem {
+	p := New(http.StatusBadRequest, CodeInvalidBody, "the request body is not valid")
+
+	var validationErrors validator.ValidationErrors
+	var typeError *json.UnmarshalTypeError
+	var syntaxError *json.SyntaxError
+	switch {
+	case errors.As(err, &validationErrors):
+		p.Detail = "one or more fields are not valid"
+		for _, fieldError := range validationErrors {
+			p.Errors = append(p.Errors, FieldError{
+				Field:   fieldName(fieldError),
+				Rule:    fieldError.Tag(),
+				Message: message(fieldError),
+			})
+		}
+	case errors.As(err, &typeError):
+		p.Detail = "one or more fields are not valid"
+		p.Errors = []FieldError{{
+			Field:   typeError.Field,
+			Rule:    "type",
+			Message: fmt.Sprintf("must be a %s", typeError.Type.Kind()),
+		}}
+	case errors.As(err, &syntaxError):
+		p.Detail = "the request body is not valid JSON"
+	}
+	return p
+}
+
+// fieldName drops the name of the top-level struct from the namespace, so
+// Cat.name becomes name.
+func fieldName(fieldError validator.FieldError) string {
+	if _, name, ok := strings.Cut(fieldError.Namespace(), "."); ok {
+		return name
+	}
+	return fieldError.Field()
+}
+
+func message(fieldError validator.FieldError) string {
+	param := fieldError.Param()
+	isString := fieldError.Kind() == reflect.String
+	switch fieldError.Tag() {
+	case "required":
+		return "is required"
+	case "min":
+		if isString {
+			return fmt.Sprintf("must be at least %s characters long", param)
+		}
+		return fmt.Sprintf("must be at least %s", param)
+	case "max":
+		if isString {
+			return fmt.Sprintf("must be at most %s characters long", param)
+		}
+		return fmt.Sprintf("must be at most %s", param)
+	case "gte":
+		return fmt.Sprintf("must be at least %s", param)
+	case "gt":
+		return fmt.Sprintf("must be greater than %s", param)
+	case "lte":
+		return fmt.Sprintf("must be at most %s", param)
+	case "lt":
+		return fmt.Sprintf("must be less than %s", param)
+	case "oneof":
+		return fmt.Sprintf("must be one of %s", strings.Join(strings.Fields(param), ", "))
+	case "notfuture":
+		return "must not be in the future"
+	case "maxage":
+		return fmt.Sprintf("must be at most %s years ago", param)
+	case "breed":
+		return "must be a known breed"
+	case "color":
+		return "must be a known color or colors separated by a slash"
+	case "breedweight":
+		return fmt.Sprintf("must be between %s for the breed", strings.Replace(param, "-", " and ", 1))
+	case "email":
+		return "must be an email address"
+	}
+	return fmt.Sprintf("failed the %s rule", fieldError.Tag())
+}
+
+// init makes the validator report fields by their JSON names, which are
+// the names clients know them by.
+func init() {
+	if validate, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "-" {
+				return ""
+			}
+			if name == "" {
+				return field.Name
+			}
+			return name
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package problems
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/one-byte-data/go-api-sample/internal/contexts"
+)
+
+type pet struct {
+	Name   string `json:"name" binding:"required,min=2,max=24"`
+	Weight int    `json:"weight" binding:"required,gte=1,lt=100"`
+}
+
+func TestBinding(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		wantDetail string
+		wantErrors []FieldError
+	}{
+		{
+			name:       "Should report every invalid field by its JSON name",
+			body:       `{"name":"T","weight":200}`,
+			wantDetail: "one or more fields are not valid",
+			wantErrors: []FieldError{
+				{Field: "name", Rule: "min", Message: "must be at least 2 characters long"},
+				{Field: "weight", Rule: "lt", Message: "must be less than 100"},
+			},
+		},
+		{
+			name:       "Should report a missing field",
+			body:       `{"weight":5}`,
+			wantDetail: "one or more fields are not valid",
+			wantErrors: []FieldError{
+				{Field: "name", Rule: "required", Message: "is required"},
+			},
+		},
+		{
+			name:       "Should report a field of the wrong type",
+			body:       `{"name":"Tom","weight":"heavy"}`,
+			wantDetail: "one or more fields are not valid",
+			wantErrors: []FieldError{
+				{Field: "weight", Rule: "type", Message: "must be a int"},
+			},
+		},
+		{
+			name:       "Should report a truncated body",
+			body:       `{"name":`,
+			wantDetail: "the request body is not valid",
+		},
+		{
+			name:       "Should report malformed JSON",
+			body:       `{"name":"Tom",}`,
+			wantDetail: "the request body is not valid JSON",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request, _ = http.NewRequest("POST", "/pets", strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			err := c.ShouldBind(new(pet))
+			if err == nil {
+				t.Fatalf("ShouldBind() error = nil, want an error")
+			}
+
+			got := Binding(err)
+			if got.Status != http.StatusBadRequest || got.Code != CodeInvalidBody {
+				t.Errorf("Binding() = %v %v, want %v %v", got.Status, got.Code, http.StatusBadRequest, CodeInvalidBody)
+			}
+			if got.Detail != tt.wantDetail {
+				t.Errorf("Binding() detail = %v, want %v", got.Detail, tt.wantDetail)
+			}
+			if !reflect.DeepEqual(got.Errors, tt.wantErrors) {
+				t.Errorf("Binding() errors = %+v, want %+v", got.Errors, tt.wantErrors)
+			}
+		})
+	}
+}
+
+func TestAbort(t *testing.T) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/cats/abc", nil)
+	c.Request = c.Request.WithContext(contexts.WithRequestID(c.Request.Context(), "req-1"))
+
+	Abort(c, New(http.StatusBadRequest, CodeInvalidID, "invalid id"))
+
+	if !c.IsAborted() {
+		t.Errorf("Abort() did not abort the handler chain")
+	}
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Abort() error = %v, wantCode %v", w.Code, http.StatusBadRequest)
+	}
+	if got := w.Header().Get("Content-Type"); got != ContentType {
+		t.Errorf("Abort() Content-Type = %v, want %v", got, ContentType)
+	}
+
+	got := new(Problem)
+	if err := json.Unmarshal(w.Body.Bytes(), got); err != nil {
+		t.Fatalf("Abort() wrote invalid JSON: %v", err)
+	}
+	want := &Problem{
+		Type:      "urn:go-api-sample:problem:invalid_id",
+		Title:     "Invalid ID",
+		Status:    http.StatusBadRequest,
+		Detail:    "invalid id",
+		Code:      CodeInvalidID,
+		Instance:  "/cats/abc",
+		RequestID: "req-1",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Abort() = %+v, want %+v", got, want)
+	}
+}
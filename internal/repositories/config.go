@@ -0,0 +1,144 @@
+package repositories
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Backends a repository can be stored in.
+const (
+	BackendPostgres = "postgres"
+	BackendSQLite   = "sqlite"
+	BackendMemory   = "memory"
+)
+
+type Config struct {
+	Backend string
+	// SQLitePath is the database file of the sqlite backend.
+	SQLitePath string
+
+	// Connection pool settings of every database, see sql.DB. Zero means
+	// no limit, except for MaxIdleConns where it keeps no idle connection.
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+
+	// Replicas are the connection strings of read replicas of the postgres
+	// backend, checked every ReplicaCheckInterval.
+	Replicas             []string
+	ReplicaCheckInterval time.Duration
+
+	// StartupTimeout is how long the server waits for the database before
+	// it starts without it. The database is checked every
+	// HealthCheckInterval after that.
+	StartupTimeout      time.Duration
+	HealthCheckInterval time.Duration
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Backend:              BackendPostgres,
+		SQLitePath:           "animals.db",
+		MaxOpenConns:         0,
+		MaxIdleConns:         2,
+		ReplicaCheckInterval: 5 * time.Second,
+		StartupTimeout:       30 * time.Second,
+		HealthCheckInterval:  5 * time.Second,
+	}
+}
+
+// ConfigFromEnv reads STORAGE_BACKEND, SQLITE_PATH, DB_MAX_OPEN_CONNS,
+// DB_MAX_IDLE_CONNS, DB_CONN_MAX_LIFETIME, DB_CONN_MAX_IDLE_TIME,
+// DB_REPLICAS, DB_REPLICA_CHECK_INTERVAL, DB_STARTUP_TIMEOUT and
+// DB_HEALTH_CHECK_INTERVAL.
+func ConfigFromEnv() (Config, error) {
+	config := DefaultConfig()
+
+	if value := os.Getenv("STORAGE_BACKEND"); value != "" {
+		switch value {
+		case BackendPostgres, BackendSQLite, BackendMemory:
+			config.Backend = value
+		default:
+			return config, fmt.Errorf("STORAGE_BACKEND must be %s, %s or %s, got %q", BackendPostgres, BackendSQLite, BackendMemory, value)
+		}
+	}
+
+	if value := os.Getenv("SQLITE_PATH"); value != "" {
+		config.SQLitePath = value
+	}
+
+	for _, setting := range []struct {
+		name  string
+		value *int
+	}{
+		{"DB_MAX_OPEN_CONNS", &config.MaxOpenConns},
+		{"DB_MAX_IDLE_CONNS", &config.MaxIdleConns},
+	} {
+		if value := os.Getenv(setting.name); value != "" {
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 0 {
+				return config, fmt.Errorf("%s must be a number, got %q", setting.name, value)
+			}
+			*setting.value = n
+		}
+	}
+
+	for _, setting := range []struct {
+		name  string
+		value *time.Duration
+	}{
+		{"DB_CONN_MAX_LIFETIME", &config.ConnMaxLifetime},
+		{"DB_CONN_MAX_IDLE_TIME", &config.ConnMaxIdleTime},
+		{"DB_REPLICA_CHECK_INTERVAL", &config.ReplicaCheckInterval},
+		{"DB_STARTUP_TIMEOUT", &config.StartupTimeout},
+		{"DB_HEALTH_CHECK_INTERVAL", &config.HealthCheckInterval},
+	} {
+		if value := os.Getenv(setting.name); value != "" {
+			d, err := time.ParseDuration(value)
+			if err != nil || d < 0 {
+				return config, fmt.Errorf("%s must be a duration, got %q", setting.name, value)
+			}
+			*setting.value = d
+		}
+	}
+	for _, setting := range []struct {
+		name  string
+		value time.Duration
+	}{
+		{"DB_REPLICA_CHECK_INTERVAL", config.ReplicaCheckInterval},
+		{"DB_HEALTH_CHECK_INTERVAL", config.HealthCheckInterval},
+	} {
+		if setting.value == 0 {
+			return config, fmt.Errorf("%s must be a positive duration, got %q", setting.name, os.Getenv(setting.name))
+		}
+	}
+
+	if value := os.Getenv("DB_REPLICAS"); value != "" {
+		for _, replica := range strings.Split(value, ",") {
+			if replica = strings.TrimSpace(replica); replica != "" {
+				config.Replicas = append(config.Replicas, replica)
+			}
+		}
+	}
+
+	return config, nil
+}
+
+// ConfigurePool applies the connection pool settings to db.
+func (config Config) ConfigurePool(db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	sqlDB.SetMaxOpenConns(config.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(config.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(config.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(config.ConnMaxIdleTime)
+	return nil
+}
@@ -0,0 +1,117 @@
+package repositories
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestConfigFromEnv(t *testing.T) {
+	defaults := map[string]string{
+		"STORAGE_BACKEND":           "",
+		"SQLITE_PATH":               "",
+		"DB_MAX_OPEN_CONNS":         "",
+		"DB_MAX_IDLE_CONNS":         "",
+		"DB_CONN_MAX_LIFETIME":      "",
+		"DB_CONN_MAX_IDLE_TIME":     "",
+		"DB_REPLICAS":               "",
+		"DB_REPLICA_CHECK_INTERVAL": "",
+		"DB_STARTUP_TIMEOUT":        "",
+		"DB_HEALTH_CHECK_INTERVAL":  "",
+	}
+	tests := []struct {
+		name    string
+		env     map[string]string
+		want    func(config *Config)
+		wantErr bool
+	}{
+		{
+			name: "Should use the defaults",
+			env:  map[string]string{},
+			want: func(config *Config) {},
+		},
+		{
+			name: "Should read the backend",
+			env:  map[string]string{"STORAGE_BACKEND": "sqlite", "SQLITE_PATH": "/data/animals.db"},
+			want: func(config *Config) {
+				config.Backend = BackendSQLite
+				config.SQLitePath = "/data/animals.db"
+			},
+		},
+		{
+			name: "Should read the pool settings",
+			env:  map[string]string{"DB_MAX_OPEN_CONNS": "20", "DB_MAX_IDLE_CONNS": "10", "DB_CONN_MAX_LIFETIME": "30m", "DB_CONN_MAX_IDLE_TIME": "5m"},
+			want: func(config *Config) {
+				config.MaxOpenConns = 20
+				config.MaxIdleConns = 10
+				config.ConnMaxLifetime = 30 * time.Minute
+				config.ConnMaxIdleTime = 5 * time.Minute
+			},
+		},
+		{
+			name: "Should read the replicas",
+			env:  map[string]string{"DB_REPLICAS": "postgresql://replica-1/animals, postgresql://replica-2/animals", "DB_REPLICA_CHECK_INTERVAL": "1s"},
+			want: func(config *Config) {
+				config.Replicas = []string{"postgresql://replica-1/animals", "postgresql://replica-2/animals"}
+				config.ReplicaCheckInterval = time.Second
+			},
+		},
+		{
+			name: "Should read the startup timeout and health check interval",
+			env:  map[string]string{"DB_STARTUP_TIMEOUT": "0s", "DB_HEALTH_CHECK_INTERVAL": "1s"},
+			want: func(config *Config) {
+				config.StartupTimeout = 0
+				config.HealthCheckInterval = time.Second
+			},
+		},
+		{
+			name:    "Should not accept an unknown backend",
+			env:     map[string]string{"STORAGE_BACKEND": "mongodb"},
+			wantErr: true,
+		},
+		{
+			name:    "Should not accept a negative pool size",
+			env:     map[string]string{"DB_MAX_OPEN_CONNS": "-1"},
+			wantErr: true,
+		},
+		{
+			name:    "Should not accept a lifetime that is not a duration",
+			env:     map[string]string{"DB_CONN_MAX_LIFETIME": "forever"},
+			wantErr: true,
+		},
+		{
+			name:    "Should not accept a check interval of zero",
+			env:     map[string]string{"DB_REPLICA_CHECK_INTERVAL": "0s"},
+			wantErr: true,
+		},
+		{
+			name:    "Should not accept a health check interval of zero",
+			env:     map[string]string{"DB_HEALTH_CHECK_INTERVAL": "0s"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range defaults {
+				t.Setenv(key, value)
+			}
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			got, err := ConfigFromEnv()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ConfigFromEnv() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			want := DefaultConfig()
+			tt.want(&want)
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("ConfigFromEnv() = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
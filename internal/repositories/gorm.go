@@ -0,0 +1,555 @@
+package repositories
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/one-byte-data/go-api-sample/internal/contexts"
+	"github.com/one-byte-data/go-api-sample/internal/models"
+	"gorm.io/gorm"
+)
+
+// The error codes of a unique violation: the SQLSTATE of Postgres and
+// CockroachDB and the extended result codes of SQLite.
+const (
+	sqlStateUniqueViolation    = "23505"
+	sqliteConstraintPrimaryKey = 1555
+	sqliteConstraintUnique     = 2067
+)
+
+type gormRepository struct {
+	db *gorm.DB
+	// replicas is nil when every query goes to db, e.g. in a transaction.
+	replicas *Replicas
+	retry    RetryPolicy
+	// tx is set on the repository passed to a transaction.
+	tx bool
+}
+
+// NewGorm returns a repository storing rows with GORM, for Postgres,
+// CockroachDB or SQLite. The tables must exist, see Migrate. Transactions
+// failing with a retryable error are retried with DefaultRetryPolicy. The
+// TenancyPlugin is installed on db.
+func NewGorm(db *gorm.DB) Repository {
+	return &gormRepository{db: useTenancy(db), retry: DefaultRetryPolicy()}
+}
+
+// NewReplicatedGorm returns a GORM repository that writes to the primary of
+// replicas and spreads reads over its replicas.
+func NewReplicatedGorm(replicas *Replicas) Repository {
+	useTenancy(replicas.primary)
+	for _, replica := range replicas.replicas {
+		useTenancy(replica.db)
+	}
+	return &gormRepository{db: replicas.primary, replicas: replicas, retry: DefaultRetryPolicy()}
+}
+
+// Migrate creates or updates the tables of every model and creates the
+// default tenant, which owns the rows that existed before tenants did. An
+// empty breed catalogue is seeded with the breeds of breeds.json.
+func Migrate(db *gorm.DB) error {
+	if err := db.AutoMigrate(&models.Cat{}, &models.Dog{}, &models.AuditEntry{}, &models.Tenant{}, &models.Breed{}, &models.Transition{}, &models.Application{}); err != nil {
+		return err
+	}
+	tenant := defaultTenant()
+	if err := db.Where("id = ?", tenant.ID).Attrs(tenant).FirstOrCreate(&models.Tenant{}).Error; err != nil {
+		return err
+	}
+
+	var breeds int64
+	if err := db.Model(&models.Breed{}).Count(&breeds).Error; err != nil {
+		return err
+	}
+	if breeds > 0 {
+		return nil
+	}
+	return db.Create(defaultBreeds()).Error
+}
+
+func defaultTenant() models.Tenant {
+	return models.Tenant{ID: contexts.DefaultTenant, Name: "Default", CreatedAt: time.Now().UTC()}
+}
+
+//go:embed breeds.json
+var breedsData []byte
+
+// defaultBreeds returns the breeds of breeds.json with new IDs. The file is
+// bundled, so failing to read it is a programming error.
+func defaultBreeds() []models.Breed {
+	var species map[string][]models.Breed
+	if err := json.Unmarshal(breedsData, &species); err != nil {
+		panic(err)
+	}
+
+	breeds := make([]models.Breed, 0)
+	for name, speciesBreeds := range species {
+		for _, breed := range speciesBreeds {
+			breed.ID = uuid.New()
+			breed.Species = name
+			breeds = append(breeds, breed)
+		}
+	}
+	return breeds
+}
+
+// Transaction runs fn again from the start when the database aborts the
+// transaction with a retryable error, so fn must not have side effects
+// outside of tx. A transaction nested in another one is a savepoint and is
+// only retried as part of the outer one.
+func (r *gormRepository) Transaction(ctx context.Context, fn func(tx Repository) error) error {
+	transaction := func() error {
+		return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			return fn(&gormRepository{db: tx, tx: true})
+		})
+	}
+	if r.tx {
+		return transaction()
+	}
+	return r.retry.run(ctx, transaction)
+}
+
+func (r *gormRepository) Cats() AnimalRepository[models.Cat] {
+	return &gormAnimals[models.Cat]{db: r.db, replicas: r.replicas}
+}
+
+func (r *gormRepository) Dogs() AnimalRepository[models.Dog] {
+	return &gormAnimals[models.Dog]{db: r.db, replicas: r.replicas}
+}
+
+func (r *gormRepository) Audit() AuditRepository {
+	return &gormAudit{db: r.db, replicas: r.replicas}
+}
+
+func (r *gormRepository) Tenants() TenantRepository {
+	return &gormTenants{db: r.db, replicas: r.replicas}
+}
+
+func (r *gormRepository) Breeds() BreedRepository {
+	return &gormBreeds{db: r.db, replicas: r.replicas}
+}
+
+func (r *gormRepository) Transitions() TransitionRepository {
+	return &gormTransitions{db: r.db, replicas: r.replicas}
+}
+
+func (r *gormRepository) Applications() ApplicationRepository {
+	return &gormApplications{db: r.db, replicas: r.replicas}
+}
+
+func (r *gormRepository) Ping(ctx context.Context) error {
+	return ping(ctx, r.db)
+}
+
+// reader returns the database reads should use.
+func reader(ctx context.Context, db *gorm.DB, replicas *Replicas) *gorm.DB {
+	if replicas != nil {
+		db = replicas.Reader(ctx)
+	}
+	return db.WithContext(ctx)
+}
+
+type gormAnimals[T any] struct {
+	db       *gorm.DB
+	replicas *Replicas
+}
+
+func (r *gormAnimals[T]) Create(ctx context.Context, item *T) error {
+	return conflict(r.db.WithContext(ctx).Create(item).Error)
+}
+
+func (r *gormAnimals[T]) Get(ctx context.Context, id uuid.UUID) (*T, error) {
+	item := new(T)
+	if err := reader(ctx, r.db, r.replicas).First(item, id).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return item, nil
+}
+
+func (r *gormAnimals[T]) Find(ctx context.Context, filter *AnimalFilter) ([]T, error) {
+	items := make([]T, 0)
+	query := reader(ctx, r.db, r.replicas)
+	if filter != nil {
+		query = page(where(query, filter), filter)
+	}
+	if err := query.Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (r *gormAnimals[T]) Count(ctx context.Context, filter *AnimalFilter) (int64, error) {
+	var count int64
+	query := reader(ctx, r.db, r.replicas).Model(new(T))
+	if filter != nil {
+		query = where(query, filter)
+	}
+	if err := query.Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// Stats aggregates in SQL. The weights are ranked within their breed by a
+// window function so the median and percentiles need no sorting in Go.
+func (r *gormAnimals[T]) Stats(ctx context.Context, filter *AnimalFilter, now time.Time) (*models.AnimalStats, error) {
+	query := func() *gorm.DB {
+		query := reader(ctx, r.db, r.replicas).Model(new(T))
+		if filter != nil {
+			query = where(query, filter)
+		}
+		return query
+	}
+
+	stats := &models.AnimalStats{
+		Breeds:  make([]models.GroupCount, 0),
+		Colors:  make([]models.GroupCount, 0),
+		Weights: make([]models.BreedWeight, 0),
+	}
+	if err := query().Count(&stats.Total).Error; err != nil {
+		return nil, err
+	}
+	if err := query().Select("breed AS value, COUNT(*) AS count").Group("breed").Order("COUNT(*) DESC").Order("breed").Scan(&stats.Breeds).Error; err != nil {
+		return nil, err
+	}
+	if err := query().Select("color AS value, COUNT(*) AS count").Group("color").Order("COUNT(*) DESC").Order("color").Scan(&stats.Colors).Error; err != nil {
+		return nil, err
+	}
+
+	bucket, args := ageCase(now)
+	ages := make([]models.AgeBucket, 0, len(ageBuckets))
+	if err := query().Select(bucket+" AS label, COUNT(*) AS count", args...).Group("label").Scan(&ages).Error; err != nil {
+		return nil, err
+	}
+	counts := make(map[string]int64, len(ages))
+	for _, age := range ages {
+		counts[age.Label] = age.Count
+	}
+	for _, age := range ageBuckets {
+		age.Count = counts[age.Label]
+		stats.Ages = append(stats.Ages, age)
+	}
+
+	ranked := query().Select("breed, weight, ROW_NUMBER() OVER (PARTITION BY breed ORDER BY weight) AS position, COUNT(*) OVER (PARTITION BY breed) AS total")
+	if err := reader(ctx, r.db, r.replicas).Table("(?) AS ranked", ranked).Select(weightColumns()).Group("breed").Order("breed").Scan(&stats.Weights).Error; err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+func (r *gormAnimals[T]) Update(ctx context.Context, id uuid.UUID, item *T) error {
+	db := r.db.WithContext(ctx).Model(new(T)).Where("id = ?", id).Omit("id").Updates(item)
+	if err := db.Error; err != nil {
+		return err
+	}
+	if db.RowsAffected < 1 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *gormAnimals[T]) SetStatus(ctx context.Context, id uuid.UUID, from, to string) error {
+	db := r.db.WithContext(ctx).Model(new(T)).Where("id = ? AND status = ?", id, from).Update("status", to)
+	if err := db.Error; err != nil {
+		return err
+	}
+	if db.RowsAffected < 1 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *gormAnimals[T]) Delete(ctx context.Context, id uuid.UUID) error {
+	db := r.db.WithContext(ctx).Delete(new(T), id)
+	if err := db.Error; err != nil {
+		return err
+	}
+	if db.RowsAffected < 1 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func where(query *gorm.DB, f *AnimalFilter) *gorm.DB {
+	if f.IDs != nil {
+		query = query.Where("id IN ?", f.IDs)
+	}
+	if f.Name != "" {
+		query = query.Where("LOWER(name) LIKE ?", "%"+strings.ToLower(f.Name)+"%")
+	}
+	if f.Breed != "" {
+		query = query.Where("LOWER(breed) = ?", strings.ToLower(f.Breed))
+	}
+	if f.Color != "" {
+		query = query.Where("LOWER(color) = ?", strings.ToLower(f.Color))
+	}
+	if f.Status != "" {
+		query = query.Where("status = ?", f.Status)
+	}
+	if f.MinWeight > 0 {
+		query = query.Where("weight >= ?", f.MinWeight)
+	}
+	if f.MaxWeight > 0 {
+		query = query.Where("weight <= ?", f.MaxWeight)
+	}
+	if f.BornAfter != nil {
+		query = query.Where("birthdate >= ?", *f.BornAfter)
+	}
+	if f.BornBefore != nil {
+		query = query.Where("birthdate < ?", *f.BornBefore)
+	}
+	return query
+}
+
+func page(query *gorm.DB, f *AnimalFilter) *gorm.DB {
+	query = query.Order("id")
+	if f.After != nil {
+		query = query.Where("id > ?", *f.After)
+	}
+	if f.Limit > 0 {
+		query = query.Limit(f.Limit)
+	}
+	return query
+}
+
+type gormAudit struct {
+	db       *gorm.DB
+	replicas *Replicas
+}
+
+func (r *gormAudit) Create(ctx context.Context, entry *models.AuditEntry) error {
+	return r.db.WithContext(ctx).Create(entry).Error
+}
+
+func (r *gormAudit) Find(ctx context.Context, filter *AuditFilter) ([]models.AuditEntry, error) {
+	entries := make([]models.AuditEntry, 0)
+	query := reader(ctx, r.db, r.replicas).Order("timestamp")
+	if filter != nil {
+		if filter.Resource != "" {
+			query = query.Where("resource = ?", filter.Resource)
+		}
+		if filter.ResourceID != nil {
+			query = query.Where("resource_id = ?", *filter.ResourceID)
+		}
+		if filter.ResourceIDs != nil {
+			query = query.Where("resource_id IN ?", filter.ResourceIDs)
+		}
+		if filter.Actor != "" {
+			query = query.Where("actor = ?", filter.Actor)
+		}
+		if filter.Since != nil {
+			query = query.Where("timestamp >= ?", *filter.Since)
+		}
+		if filter.Until != nil {
+			query = query.Where("timestamp < ?", *filter.Until)
+		}
+	}
+	if err := query.Find(&entries).Error; err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+type gormTransitions struct {
+	db       *gorm.DB
+	replicas *Replicas
+}
+
+func (r *gormTransitions) Create(ctx context.Context, transition *models.Transition) error {
+	return r.db.WithContext(ctx).Create(transition).Error
+}
+
+func (r *gormTransitions) Find(ctx context.Context, resource string, id uuid.UUID) ([]models.Transition, error) {
+	transitions := make([]models.Transition, 0)
+	query := reader(ctx, r.db, r.replicas).Where("resource = ? AND resource_id = ?", resource, id).Order("timestamp")
+	if err := query.Find(&transitions).Error; err != nil {
+		return nil, err
+	}
+	return transitions, nil
+}
+
+type gormApplications struct {
+	db       *gorm.DB
+	replicas *Replicas
+}
+
+func (r *gormApplications) Create(ctx context.Context, application *models.Application) error {
+	return conflict(r.db.WithContext(ctx).Create(application).Error)
+}
+
+func (r *gormApplications) Get(ctx context.Context, id uuid.UUID) (*models.Application, error) {
+	application := new(models.Application)
+	if err := reader(ctx, r.db, r.replicas).First(application, id).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return application, nil
+}
+
+func (r *gormApplications) Find(ctx context.Context, filter *ApplicationFilter) ([]models.Application, error) {
+	applications := make([]models.Application, 0)
+	query := reader(ctx, r.db, r.replicas).Order("created_at").Order("id")
+	if filter != nil {
+		if filter.Species != "" {
+			query = query.Where("species = ?", filter.Species)
+		}
+		if filter.AnimalID != nil {
+			query = query.Where("animal_id = ?", *filter.AnimalID)
+		}
+		if filter.Status != "" {
+			query = query.Where("status = ?", filter.Status)
+		}
+	}
+	if err := query.Find(&applications).Error; err != nil {
+		return nil, err
+	}
+	return applications, nil
+}
+
+func (r *gormApplications) Update(ctx context.Context, id uuid.UUID, application *models.Application) error {
+	db := r.db.WithContext(ctx).Model(&models.Application{}).Where("id = ?", id).Omit("id").Updates(application)
+	if err := db.Error; err != nil {
+		return err
+	}
+	if db.RowsAffected < 1 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *gormApplications) SetStatus(ctx context.Context, id uuid.UUID, from string, application *models.Application) error {
+	db := r.db.WithContext(ctx).Model(&models.Application{}).Where("id = ? AND status = ?", id, from).
+		Select("status", "reviewer", "updated_at").Updates(application)
+	if err := db.Error; err != nil {
+		return err
+	}
+	if db.RowsAffected < 1 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *gormApplications) Delete(ctx context.Context, id uuid.UUID) error {
+	db := r.db.WithContext(ctx).Delete(&models.Application{}, id)
+	if err := db.Error; err != nil {
+		return err
+	}
+	if db.RowsAffected < 1 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+type gormTenants struct {
+	db       *gorm.DB
+	replicas *Replicas
+}
+
+func (r *gormTenants) Create(ctx context.Context, tenant *models.Tenant) error {
+	return conflict(r.db.WithContext(ctx).Create(tenant).Error)
+}
+
+func (r *gormTenants) Get(ctx context.Context, id string) (*models.Tenant, error) {
+	tenant := new(models.Tenant)
+	if err := reader(ctx, r.db, r.replicas).Where("id = ?", id).First(tenant).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return tenant, nil
+}
+
+func (r *gormTenants) Find(ctx context.Context) ([]models.Tenant, error) {
+	tenants := make([]models.Tenant, 0)
+	if err := reader(ctx, r.db, r.replicas).Order("id").Find(&tenants).Error; err != nil {
+		return nil, err
+	}
+	return tenants, nil
+}
+
+func (r *gormTenants) Delete(ctx context.Context, id string) error {
+	db := r.db.WithContext(ctx).Where("id = ?", id).Delete(&models.Tenant{})
+	if err := db.Error; err != nil {
+		return err
+	}
+	if db.RowsAffected < 1 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+type gormBreeds struct {
+	db       *gorm.DB
+	replicas *Replicas
+}
+
+func (r *gormBreeds) Create(ctx context.Context, breed *models.Breed) error {
+	return conflict(r.db.WithContext(ctx).Create(breed).Error)
+}
+
+func (r *gormBreeds) Get(ctx context.Context, id uuid.UUID) (*models.Breed, error) {
+	breed := new(models.Breed)
+	if err := reader(ctx, r.db, r.replicas).First(breed, id).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return breed, nil
+}
+
+func (r *gormBreeds) Find(ctx context.Context, species string) ([]models.Breed, error) {
+	breeds := make([]models.Breed, 0)
+	if err := reader(ctx, r.db, r.replicas).Where("species = ?", species).Order("name").Find(&breeds).Error; err != nil {
+		return nil, err
+	}
+	return breeds, nil
+}
+
+func (r *gormBreeds) Update(ctx context.Context, id uuid.UUID, breed *models.Breed) error {
+	db := r.db.WithContext(ctx).Model(&models.Breed{}).Where("id = ?", id).
+		Select("name", "aliases", "min_weight", "max_weight").Updates(breed)
+	if err := db.Error; err != nil {
+		return conflict(err)
+	}
+	if db.RowsAffected < 1 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *gormBreeds) Delete(ctx context.Context, id uuid.UUID) error {
+	db := r.db.WithContext(ctx).Delete(&models.Breed{}, id)
+	if err := db.Error; err != nil {
+		return err
+	}
+	if db.RowsAffected < 1 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// conflict translates the unique violations of Postgres, CockroachDB and
+// SQLite, e.g. for a client-supplied ID that is taken, even by another
+// tenant, so callers only check ErrConflict.
+func conflict(err error) error {
+	var sqlErr interface{ SQLState() string }
+	if errors.As(err, &sqlErr) && sqlErr.SQLState() == sqlStateUniqueViolation {
+		return fmt.Errorf("%w: %v", ErrConflict, err)
+	}
+	var sqliteErr interface{ Code() int }
+	if errors.As(err, &sqliteErr) {
+		switch sqliteErr.Code() {
+		case sqliteConstraintPrimaryKey, sqliteConstraintUnique:
+			return fmt.Errorf("%w: %v", ErrConflict, err)
+		}
+	}
+	return err
+}
+
+// notFound translates GORM's error so callers only check ErrNotFound.
+func notFound(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrNotFound
+	}
+	return err
+}
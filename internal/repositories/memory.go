@@ -0,0 +1,755 @@
+package repositories
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/one-byte-data/go-api-sample/internal/contexts"
+	"github.com/one-byte-data/go-api-sample/internal/models"
+)
+
+// memoryState is everything a memory repository stores.
+type memoryState struct {
+	cats         map[uuid.UUID]models.Cat
+	dogs         map[uuid.UUID]models.Dog
+	audit        []models.AuditEntry
+	tenants      map[string]models.Tenant
+	breeds       map[uuid.UUID]models.Breed
+	transitions  []models.Transition
+	applications map[uuid.UUID]models.Application
+}
+
+type memoryRepository struct {
+	mu    *sync.RWMutex
+	state *memoryState
+	// tx is set on the repository passed to a transaction, which already
+	// holds the write lock.
+	tx bool
+	// undo holds the functions reverting the writes of a transaction, in
+	// the order they were made.
+	undo []func()
+}
+
+// NewMemory returns a thread-safe repository keeping everything in memory.
+// It is meant for tests and local development; nothing is persisted.
+// Transactions are serialized. Like Migrate, it creates the default tenant
+// and seeds the breed catalogue.
+func NewMemory() Repository {
+	state := &memoryState{
+		cats:         make(map[uuid.UUID]models.Cat),
+		dogs:         make(map[uuid.UUID]models.Dog),
+		tenants:      map[string]models.Tenant{contexts.DefaultTenant: defaultTenant()},
+		breeds:       make(map[uuid.UUID]models.Breed),
+		applications: make(map[uuid.UUID]models.Application),
+	}
+	for _, breed := range defaultBreeds() {
+		state.breeds[breed.ID] = breed
+	}
+	return &memoryRepository{mu: new(sync.RWMutex), state: state}
+}
+
+// Transaction writes to the state directly and keeps an undo log, which is
+// replayed backwards when fn fails or panics, so a transaction costs as much
+// as the writes it makes.
+func (r *memoryRepository) Transaction(ctx context.Context, fn func(tx Repository) error) error {
+	if r.tx {
+		return fn(r)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tx := &memoryRepository{mu: r.mu, state: r.state, tx: true}
+	committed := false
+	defer func() {
+		if !committed {
+			for i := len(tx.undo) - 1; i >= 0; i-- {
+				tx.undo[i]()
+			}
+		}
+	}()
+	if err := fn(tx); err != nil {
+		return err
+	}
+	committed = true
+	return nil
+}
+
+func (r *memoryRepository) read(fn func(state *memoryState)) {
+	if !r.tx {
+		r.mu.RLock()
+		defer r.mu.RUnlock()
+	}
+	fn(r.state)
+}
+
+func (r *memoryRepository) write(fn func(state *memoryState) error) error {
+	if !r.tx {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+	}
+	return fn(r.state)
+}
+
+// onRollback records how to revert a write made in a transaction.
+func (r *memoryRepository) onRollback(undo func()) {
+	if r.tx {
+		r.undo = append(r.undo, undo)
+	}
+}
+
+// put stores item under key, restoring the previous value on rollback.
+func put[K comparable, V any](r *memoryRepository, items map[K]V, key K, item V) {
+	previous, ok := items[key]
+	r.onRollback(func() {
+		if ok {
+			items[key] = previous
+		} else {
+			delete(items, key)
+		}
+	})
+	items[key] = item
+}
+
+// remove deletes key, restoring its value on rollback.
+func remove[K comparable, V any](r *memoryRepository, items map[K]V, key K) {
+	if previous, ok := items[key]; ok {
+		r.onRollback(func() { items[key] = previous })
+	}
+	delete(items, key)
+}
+
+func (r *memoryRepository) Cats() AnimalRepository[models.Cat] {
+	return &memoryAnimals[models.Cat]{
+		repo:  r,
+		items: func(state *memoryState) map[uuid.UUID]models.Cat { return state.cats },
+		attrs: func(cat models.Cat) attributes { return attributes(cat) },
+		from:  func(a attributes) models.Cat { return models.Cat(a) },
+	}
+}
+
+func (r *memoryRepository) Dogs() AnimalRepository[models.Dog] {
+	return &memoryAnimals[models.Dog]{
+		repo:  r,
+		items: func(state *memoryState) map[uuid.UUID]models.Dog { return state.dogs },
+		attrs: func(dog models.Dog) attributes { return attributes(dog) },
+		from:  func(a attributes) models.Dog { return models.Dog(a) },
+	}
+}
+
+func (r *memoryRepository) Audit() AuditRepository {
+	return &memoryAudit{repo: r}
+}
+
+func (r *memoryRepository) Tenants() TenantRepository {
+	return &memoryTenants{repo: r}
+}
+
+func (r *memoryRepository) Breeds() BreedRepository {
+	return &memoryBreeds{repo: r}
+}
+
+func (r *memoryRepository) Transitions() TransitionRepository {
+	return &memoryTransitions{repo: r}
+}
+
+func (r *memoryRepository) Applications() ApplicationRepository {
+	return &memoryApplications{repo: r}
+}
+
+func (r *memoryRepository) Ping(ctx context.Context) error {
+	return nil
+}
+
+// attributes are the fields cats and dogs share. Both models convert to it.
+type attributes struct {
+	ID        uuid.UUID
+	Name      string
+	Breed     string
+	Color     string
+	Birthdate time.Time
+	Weight    int
+	// BreedStatus is a models.BreedKnown or models.BreedUnknown.
+	BreedStatus string
+	Status      string
+	TenantID    string
+}
+
+type memoryAnimals[T any] struct {
+	repo  *memoryRepository
+	items func(state *memoryState) map[uuid.UUID]T
+	attrs func(item T) attributes
+	from  func(a attributes) T
+}
+
+func (r *memoryAnimals[T]) Create(ctx context.Context, item *T) error {
+	return r.repo.write(func(state *memoryState) error {
+		a := r.attrs(*item)
+		if _, ok := r.items(state)[a.ID]; ok {
+			return fmt.Errorf("row with id=%v already exists: %w", a.ID, ErrConflict)
+		}
+		a.TenantID = contexts.Tenant(ctx)
+		if a.Status == "" {
+			// Like the default of the status column.
+			a.Status = models.StatusIntake
+		}
+		*item = r.from(a)
+		put(r.repo, r.items(state), a.ID, *item)
+		return nil
+	})
+}
+
+// lookup returns the item with the ID if it belongs to the tenant of ctx.
+func (r *memoryAnimals[T]) lookup(ctx context.Context, state *memoryState, id uuid.UUID) (T, bool) {
+	item, ok := r.items(state)[id]
+	if !ok || r.attrs(item).TenantID != contexts.Tenant(ctx) {
+		var zero T
+		return zero, false
+	}
+	return item, true
+}
+
+func (r *memoryAnimals[T]) Get(ctx context.Context, id uuid.UUID) (*T, error) {
+	var item T
+	var ok bool
+	r.repo.read(func(state *memoryState) {
+		item, ok = r.lookup(ctx, state, id)
+	})
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &item, nil
+}
+
+func (r *memoryAnimals[T]) Find(ctx context.Context, filter *AnimalFilter) ([]T, error) {
+	items := make([]T, 0)
+	r.repo.read(func(state *memoryState) {
+		tenant := contexts.Tenant(ctx)
+		for _, item := range r.items(state) {
+			a := r.attrs(item)
+			if a.TenantID == tenant && (filter == nil || matches(a, filter, true)) {
+				items = append(items, item)
+			}
+		}
+	})
+	if filter == nil {
+		return items, nil
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		a, b := r.attrs(items[i]).ID, r.attrs(items[j]).ID
+		return bytes.Compare(a[:], b[:]) < 0
+	})
+	if filter.Limit > 0 && len(items) > filter.Limit {
+		items = items[:filter.Limit]
+	}
+	return items, nil
+}
+
+func (r *memoryAnimals[T]) Count(ctx context.Context, filter *AnimalFilter) (int64, error) {
+	var count int64
+	r.repo.read(func(state *memoryState) {
+		tenant := contexts.Tenant(ctx)
+		for _, item := range r.items(state) {
+			a := r.attrs(item)
+			if a.TenantID == tenant && (filter == nil || matches(a, filter, false)) {
+				count++
+			}
+		}
+	})
+	return count, nil
+}
+
+func (r *memoryAnimals[T]) Stats(ctx context.Context, filter *AnimalFilter, now time.Time) (*models.AnimalStats, error) {
+	animals := make([]attributes, 0)
+	r.repo.read(func(state *memoryState) {
+		tenant := contexts.Tenant(ctx)
+		for _, item := range r.items(state) {
+			a := r.attrs(item)
+			if a.TenantID == tenant && (filter == nil || matches(a, filter, false)) {
+				animals = append(animals, a)
+			}
+		}
+	})
+	return summarize(animals, now), nil
+}
+
+func (r *memoryAnimals[T]) Update(ctx context.Context, id uuid.UUID, item *T) error {
+	return r.repo.write(func(state *memoryState) error {
+		current, ok := r.lookup(ctx, state, id)
+		if !ok {
+			return ErrNotFound
+		}
+		put(r.repo, r.items(state), id, r.merge(current, *item))
+		return nil
+	})
+}
+
+func (r *memoryAnimals[T]) SetStatus(ctx context.Context, id uuid.UUID, from, to string) error {
+	return r.repo.write(func(state *memoryState) error {
+		current, ok := r.lookup(ctx, state, id)
+		if !ok {
+			return ErrNotFound
+		}
+		a := r.attrs(current)
+		if a.Status != from {
+			return ErrNotFound
+		}
+		a.Status = to
+		put(r.repo, r.items(state), id, r.from(a))
+		return nil
+	})
+}
+
+func (r *memoryAnimals[T]) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.repo.write(func(state *memoryState) error {
+		if _, ok := r.lookup(ctx, state, id); !ok {
+			return ErrNotFound
+		}
+		remove(r.repo, r.items(state), id)
+		return nil
+	})
+}
+
+// merge copies the non-zero fields of changes other than the ID and the
+// tenant onto current, like GORM's Updates.
+func (r *memoryAnimals[T]) merge(current T, changes T) T {
+	result := r.attrs(current)
+	update := r.attrs(changes)
+	if update.Name != "" {
+		result.Name = update.Name
+	}
+	if update.Breed != "" {
+		result.Breed = update.Breed
+	}
+	if update.Color != "" {
+		result.Color = update.Color
+	}
+	if !update.Birthdate.IsZero() {
+		result.Birthdate = update.Birthdate
+	}
+	if update.Weight != 0 {
+		result.Weight = update.Weight
+	}
+	if update.BreedStatus != "" {
+		result.BreedStatus = update.BreedStatus
+	}
+	if update.Status != "" {
+		result.Status = update.Status
+	}
+	return r.from(result)
+}
+
+// matches applies the filter the way the SQL in gorm.go does.
+func matches(a attributes, f *AnimalFilter, page bool) bool {
+	if f.IDs != nil && !containsID(f.IDs, a.ID) {
+		return false
+	}
+	if f.Name != "" && !strings.Contains(strings.ToLower(a.Name), strings.ToLower(f.Name)) {
+		return false
+	}
+	if f.Breed != "" && !strings.EqualFold(a.Breed, f.Breed) {
+		return false
+	}
+	if f.Color != "" && !strings.EqualFold(a.Color, f.Color) {
+		return false
+	}
+	if f.Status != "" && a.Status != f.Status {
+		return false
+	}
+	if f.MinWeight > 0 && a.Weight < f.MinWeight {
+		return false
+	}
+	if f.MaxWeight > 0 && a.Weight > f.MaxWeight {
+		return false
+	}
+	if f.BornAfter != nil && a.Birthdate.Before(*f.BornAfter) {
+		return false
+	}
+	if f.BornBefore != nil && !a.Birthdate.Before(*f.BornBefore) {
+		return false
+	}
+	if page && f.After != nil && bytes.Compare(a.ID[:], f.After[:]) <= 0 {
+		return false
+	}
+	return true
+}
+
+func containsID(ids []uuid.UUID, id uuid.UUID) bool {
+	for _, candidate := range ids {
+		if candidate == id {
+			return true
+		}
+	}
+	return false
+}
+
+type memoryAudit struct {
+	repo *memoryRepository
+}
+
+func (r *memoryAudit) Create(ctx context.Context, entry *models.AuditEntry) error {
+	return r.repo.write(func(state *memoryState) error {
+		entry.TenantID = contexts.Tenant(ctx)
+		n := len(state.audit)
+		r.repo.onRollback(func() { state.audit = state.audit[:n] })
+		state.audit = append(state.audit, *entry)
+		return nil
+	})
+}
+
+func (r *memoryAudit) Find(ctx context.Context, filter *AuditFilter) ([]models.AuditEntry, error) {
+	entries := make([]models.AuditEntry, 0)
+	r.repo.read(func(state *memoryState) {
+		tenant := contexts.Tenant(ctx)
+		for _, entry := range state.audit {
+			if entry.TenantID == tenant && (filter == nil || matchesAudit(entry, filter)) {
+				entries = append(entries, entry)
+			}
+		}
+	})
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Timestamp.Before(entries[j].Timestamp)
+	})
+	return entries, nil
+}
+
+func matchesAudit(entry models.AuditEntry, f *AuditFilter) bool {
+	if f.Resource != "" && entry.Resource != f.Resource {
+		return false
+	}
+	if f.ResourceID != nil && entry.ResourceID != *f.ResourceID {
+		return false
+	}
+	if f.ResourceIDs != nil && !containsID(f.ResourceIDs, entry.ResourceID) {
+		return false
+	}
+	if f.Actor != "" && entry.Actor != f.Actor {
+		return false
+	}
+	if f.Since != nil && entry.Timestamp.Before(*f.Since) {
+		return false
+	}
+	if f.Until != nil && !entry.Timestamp.Before(*f.Until) {
+		return false
+	}
+	return true
+}
+
+type memoryTransitions struct {
+	repo *memoryRepository
+}
+
+func (r *memoryTransitions) Create(ctx context.Context, transition *models.Transition) error {
+	return r.repo.write(func(state *memoryState) error {
+		transition.TenantID = contexts.Tenant(ctx)
+		n := len(state.transitions)
+		r.repo.onRollback(func() { state.transitions = state.transitions[:n] })
+		state.transitions = append(state.transitions, *transition)
+		return nil
+	})
+}
+
+func (r *memoryTransitions) Find(ctx context.Context, resource string, id uuid.UUID) ([]models.Transition, error) {
+	transitions := make([]models.Transition, 0)
+	r.repo.read(func(state *memoryState) {
+		tenant := contexts.Tenant(ctx)
+		for _, transition := range state.transitions {
+			if transition.TenantID == tenant && transition.Resource == resource && transition.ResourceID == id {
+				transitions = append(transitions, transition)
+			}
+		}
+	})
+	sort.SliceStable(transitions, func(i, j int) bool {
+		return transitions[i].Timestamp.Before(transitions[j].Timestamp)
+	})
+	return transitions, nil
+}
+
+type memoryApplications struct {
+	repo *memoryRepository
+}
+
+func (r *memoryApplications) Create(ctx context.Context, application *models.Application) error {
+	return r.repo.write(func(state *memoryState) error {
+		if _, ok := state.applications[application.ID]; ok {
+			return fmt.Errorf("application with id=%v already exists: %w", application.ID, ErrConflict)
+		}
+		application.TenantID = contexts.Tenant(ctx)
+		if application.Status == "" {
+			// Like the default of the status column.
+			application.Status = models.ApplicationSubmitted
+		}
+		put(r.repo, state.applications, application.ID, *application)
+		return nil
+	})
+}
+
+// lookup returns the application with the ID if it belongs to the tenant
+// of ctx.
+func (r *memoryApplications) lookup(ctx context.Context, state *memoryState, id uuid.UUID) (models.Application, bool) {
+	application, ok := state.applications[id]
+	if !ok || application.TenantID != contexts.Tenant(ctx) {
+		return models.Application{}, false
+	}
+	return application, true
+}
+
+func (r *memoryApplications) Get(ctx context.Context, id uuid.UUID) (*models.Application, error) {
+	var application models.Application
+	var ok bool
+	r.repo.read(func(state *memoryState) {
+		application, ok = r.lookup(ctx, state, id)
+	})
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &application, nil
+}
+
+func (r *memoryApplications) Find(ctx context.Context, filter *ApplicationFilter) ([]models.Application, error) {
+	applications := make([]models.Application, 0)
+	r.repo.read(func(state *memoryState) {
+		tenant := contexts.Tenant(ctx)
+		for _, application := range state.applications {
+			if application.TenantID == tenant && (filter == nil || matchesApplication(application, filter)) {
+				applications = append(applications, application)
+			}
+		}
+	})
+	sort.Slice(applications, func(i, j int) bool {
+		a, b := applications[i], applications[j]
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.Before(b.CreatedAt)
+		}
+		return bytes.Compare(a.ID[:], b.ID[:]) < 0
+	})
+	return applications, nil
+}
+
+func matchesApplication(application models.Application, f *ApplicationFilter) bool {
+	if f.Species != "" && application.Species != f.Species {
+		return false
+	}
+	if f.AnimalID != nil && application.AnimalID != *f.AnimalID {
+		return false
+	}
+	if f.Status != "" && application.Status != f.Status {
+		return false
+	}
+	return true
+}
+
+// Update copies the non-zero fields like GORM's Updates.
+func (r *memoryApplications) Update(ctx context.Context, id uuid.UUID, application *models.Application) error {
+	return r.repo.write(func(state *memoryState) error {
+		current, ok := r.lookup(ctx, state, id)
+		if !ok {
+			return ErrNotFound
+		}
+		if application.Species != "" {
+			current.Species = application.Species
+		}
+		if application.ApplicantName != "" {
+			current.ApplicantName = application.ApplicantName
+		}
+		if application.ApplicantEmail != "" {
+			current.ApplicantEmail = application.ApplicantEmail
+		}
+		if application.ApplicantPhone != "" {
+			current.ApplicantPhone = application.ApplicantPhone
+		}
+		if application.Notes != "" {
+			current.Notes = application.Notes
+		}
+		if application.Status != "" {
+			current.Status = application.Status
+		}
+		if application.Reviewer != "" {
+			current.Reviewer = application.Reviewer
+		}
+		if application.AnimalID != uuid.Nil {
+			current.AnimalID = application.AnimalID
+		}
+		if !application.CreatedAt.IsZero() {
+			current.CreatedAt = application.CreatedAt
+		}
+		if !application.UpdatedAt.IsZero() {
+			current.UpdatedAt = application.UpdatedAt
+		}
+		put(r.repo, state.applications, id, current)
+		return nil
+	})
+}
+
+func (r *memoryApplications) SetStatus(ctx context.Context, id uuid.UUID, from string, application *models.Application) error {
+	return r.repo.write(func(state *memoryState) error {
+		current, ok := r.lookup(ctx, state, id)
+		if !ok || current.Status != from {
+			return ErrNotFound
+		}
+		current.Status = application.Status
+		current.Reviewer = application.Reviewer
+		current.UpdatedAt = application.UpdatedAt
+		put(r.repo, state.applications, id, current)
+		return nil
+	})
+}
+
+func (r *memoryApplications) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.repo.write(func(state *memoryState) error {
+		if _, ok := r.lookup(ctx, state, id); !ok {
+			return ErrNotFound
+		}
+		remove(r.repo, state.applications, id)
+		return nil
+	})
+}
+
+type memoryTenants struct {
+	repo *memoryRepository
+}
+
+func (r *memoryTenants) Create(ctx context.Context, tenant *models.Tenant) error {
+	return r.repo.write(func(state *memoryState) error {
+		if _, ok := state.tenants[tenant.ID]; ok {
+			return fmt.Errorf("tenant with id=%v already exists: %w", tenant.ID, ErrConflict)
+		}
+		put(r.repo, state.tenants, tenant.ID, *tenant)
+		return nil
+	})
+}
+
+func (r *memoryTenants) Get(ctx context.Context, id string) (*models.Tenant, error) {
+	var tenant models.Tenant
+	var ok bool
+	r.repo.read(func(state *memoryState) {
+		tenant, ok = state.tenants[id]
+	})
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &tenant, nil
+}
+
+func (r *memoryTenants) Find(ctx context.Context) ([]models.Tenant, error) {
+	tenants := make([]models.Tenant, 0)
+	r.repo.read(func(state *memoryState) {
+		for _, tenant := range state.tenants {
+			tenants = append(tenants, tenant)
+		}
+	})
+	sort.Slice(tenants, func(i, j int) bool {
+		return tenants[i].ID < tenants[j].ID
+	})
+	return tenants, nil
+}
+
+func (r *memoryTenants) Delete(ctx context.Context, id string) error {
+	return r.repo.write(func(state *memoryState) error {
+		if _, ok := state.tenants[id]; !ok {
+			return ErrNotFound
+		}
+		remove(r.repo, state.tenants, id)
+		return nil
+	})
+}
+
+// memoryBreeds copies the aliases in and out, so callers cannot change the
+// stored breeds.
+type memoryBreeds struct {
+	repo *memoryRepository
+}
+
+func (r *memoryBreeds) Create(ctx context.Context, breed *models.Breed) error {
+	return r.repo.write(func(state *memoryState) error {
+		if _, ok := state.breeds[breed.ID]; ok {
+			return fmt.Errorf("breed with id=%v already exists: %w", breed.ID, ErrConflict)
+		}
+		if err := r.uniqueName(state, breed.ID, breed.Species, breed.Name); err != nil {
+			return err
+		}
+		put(r.repo, state.breeds, breed.ID, copyBreed(*breed))
+		return nil
+	})
+}
+
+func (r *memoryBreeds) Get(ctx context.Context, id uuid.UUID) (*models.Breed, error) {
+	var breed models.Breed
+	var ok bool
+	r.repo.read(func(state *memoryState) {
+		breed, ok = state.breeds[id]
+	})
+	if !ok {
+		return nil, ErrNotFound
+	}
+	breed = copyBreed(breed)
+	return &breed, nil
+}
+
+func (r *memoryBreeds) Find(ctx context.Context, species string) ([]models.Breed, error) {
+	breeds := make([]models.Breed, 0)
+	r.repo.read(func(state *memoryState) {
+		for _, breed := range state.breeds {
+			if breed.Species == species {
+				breeds = append(breeds, copyBreed(breed))
+			}
+		}
+	})
+	sort.Slice(breeds, func(i, j int) bool {
+		return breeds[i].Name < breeds[j].Name
+	})
+	return breeds, nil
+}
+
+func (r *memoryBreeds) Update(ctx context.Context, id uuid.UUID, breed *models.Breed) error {
+	return r.repo.write(func(state *memoryState) error {
+		current, ok := state.breeds[id]
+		if !ok {
+			return ErrNotFound
+		}
+		if err := r.uniqueName(state, id, current.Species, breed.Name); err != nil {
+			return err
+		}
+		current.Name = breed.Name
+		current.Aliases = breed.Aliases
+		current.MinWeight = breed.MinWeight
+		current.MaxWeight = breed.MaxWeight
+		put(r.repo, state.breeds, id, copyBreed(current))
+		return nil
+	})
+}
+
+// uniqueName enforces the unique index on the species and name of the
+// breeds, ignoring the breed with the given ID.
+func (r *memoryBreeds) uniqueName(state *memoryState, id uuid.UUID, species string, name string) error {
+	for otherID, other := range state.breeds {
+		if otherID != id && other.Species == species && other.Name == name {
+			return fmt.Errorf("breed with name=%v already exists: %w", name, ErrConflict)
+		}
+	}
+	return nil
+}
+
+func (r *memoryBreeds) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.repo.write(func(state *memoryState) error {
+		if _, ok := state.breeds[id]; !ok {
+			return ErrNotFound
+		}
+		remove(r.repo, state.breeds, id)
+		return nil
+	})
+}
+
+func copyBreed(breed models.Breed) models.Breed {
+	if breed.Aliases != nil {
+		breed.Aliases = append([]string{}, breed.Aliases...)
+	}
+	return breed
+}
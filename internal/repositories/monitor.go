@@ -0,0 +1,292 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/one-byte-data/go-api-sample/internal/models"
+)
+
+// ErrUnavailable is returned, possibly wrapped, while the database cannot be
+// reached.
+var ErrUnavailable = errors.New("database unavailable")
+
+// Monitor guards a repository whose database may be down, e.g. because it
+// is still starting. Until connect has succeeded once, and whenever the
+// last health check failed, every call fails fast with ErrUnavailable
+// instead of waiting for a connection.
+type Monitor struct {
+	repo    Repository
+	connect func(ctx context.Context) error
+	// connected is set once connect has succeeded.
+	connected atomic.Bool
+	available atomic.Bool
+}
+
+// NewMonitor returns a monitor of repo. connect prepares the database, e.g.
+// checks it can be reached and migrates it, and is called until it
+// succeeds.
+func NewMonitor(repo Repository, connect func(ctx context.Context) error) *Monitor {
+	return &Monitor{repo: repo, connect: connect}
+}
+
+// Connect calls connect with backoff until it succeeds, ctx is done or
+// maxWait has passed.
+func (m *Monitor) Connect(ctx context.Context, maxWait time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, maxWait)
+	defer cancel()
+
+	backoff := RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: 5 * time.Second}
+	for attempt := 1; ; attempt++ {
+		err := m.check(ctx)
+		if err == nil {
+			return nil
+		}
+
+		wait := backoff.backoff(attempt)
+		slog.WarnContext(ctx, "unable to connect to the database, retrying", "attempt", attempt, "backoff", wait, "error", err)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("gave up connecting to the database after %d attempts: %w", attempt, err)
+		case <-timer.C:
+		}
+	}
+}
+
+// Check connects to the database if that has not succeeded yet and pings it
+// otherwise, then records whether it is available.
+func (m *Monitor) Check(ctx context.Context) {
+	_ = m.check(ctx)
+}
+
+func (m *Monitor) check(ctx context.Context) error {
+	var err error
+	if m.connected.Load() {
+		err = m.repo.Ping(ctx)
+	} else if err = m.connect(ctx); err == nil {
+		m.connected.Store(true)
+	}
+
+	available := err == nil
+	if m.available.Swap(available) != available {
+		if available {
+			slog.InfoContext(ctx, "database available")
+		} else {
+			slog.ErrorContext(ctx, "database unavailable", "error", err)
+		}
+	}
+	return err
+}
+
+// Watch checks the database every interval until ctx is done.
+func (m *Monitor) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			checkCtx, cancel := context.WithTimeout(ctx, interval)
+			m.Check(checkCtx)
+			cancel()
+		}
+	}
+}
+
+func (m *Monitor) Transaction(ctx context.Context, fn func(tx Repository) error) error {
+	if !m.available.Load() {
+		return ErrUnavailable
+	}
+	return m.repo.Transaction(ctx, fn)
+}
+
+func (m *Monitor) Cats() AnimalRepository[models.Cat] {
+	if !m.available.Load() {
+		return unavailableAnimals[models.Cat]{}
+	}
+	return m.repo.Cats()
+}
+
+func (m *Monitor) Dogs() AnimalRepository[models.Dog] {
+	if !m.available.Load() {
+		return unavailableAnimals[models.Dog]{}
+	}
+	return m.repo.Dogs()
+}
+
+func (m *Monitor) Audit() AuditRepository {
+	if !m.available.Load() {
+		return unavailableAudit{}
+	}
+	return m.repo.Audit()
+}
+
+func (m *Monitor) Tenants() TenantRepository {
+	if !m.available.Load() {
+		return unavailableTenants{}
+	}
+	return m.repo.Tenants()
+}
+
+func (m *Monitor) Breeds() BreedRepository {
+	if !m.available.Load() {
+		return unavailableBreeds{}
+	}
+	return m.repo.Breeds()
+}
+
+func (m *Monitor) Transitions() TransitionRepository {
+	if !m.available.Load() {
+		return unavailableTransitions{}
+	}
+	return m.repo.Transitions()
+}
+
+func (m *Monitor) Applications() ApplicationRepository {
+	if !m.available.Load() {
+		return unavailableApplications{}
+	}
+	return m.repo.Applications()
+}
+
+// Ping pings the database once it is connected, regardless of the last
+// health check, so readiness probes see it recover right away.
+func (m *Monitor) Ping(ctx context.Context) error {
+	if !m.connected.Load() {
+		return ErrUnavailable
+	}
+	if err := m.repo.Ping(ctx); err != nil {
+		return fmt.Errorf("%w: %v", ErrUnavailable, err)
+	}
+	return nil
+}
+
+type unavailableAnimals[T any] struct{}
+
+func (unavailableAnimals[T]) Create(ctx context.Context, item *T) error {
+	return ErrUnavailable
+}
+
+func (unavailableAnimals[T]) Get(ctx context.Context, id uuid.UUID) (*T, error) {
+	return nil, ErrUnavailable
+}
+
+func (unavailableAnimals[T]) Find(ctx context.Context, filter *AnimalFilter) ([]T, error) {
+	return nil, ErrUnavailable
+}
+
+func (unavailableAnimals[T]) Count(ctx context.Context, filter *AnimalFilter) (int64, error) {
+	return 0, ErrUnavailable
+}
+
+func (unavailableAnimals[T]) Stats(ctx context.Context, filter *AnimalFilter, now time.Time) (*models.AnimalStats, error) {
+	return nil, ErrUnavailable
+}
+
+func (unavailableAnimals[T]) Update(ctx context.Context, id uuid.UUID, item *T) error {
+	return ErrUnavailable
+}
+
+func (unavailableAnimals[T]) SetStatus(ctx context.Context, id uuid.UUID, from, to string) error {
+	return ErrUnavailable
+}
+
+func (unavailableAnimals[T]) Delete(ctx context.Context, id uuid.UUID) error {
+	return ErrUnavailable
+}
+
+type unavailableAudit struct{}
+
+func (unavailableAudit) Create(ctx context.Context, entry *models.AuditEntry) error {
+	return ErrUnavailable
+}
+
+func (unavailableAudit) Find(ctx context.Context, filter *AuditFilter) ([]models.AuditEntry, error) {
+	return nil, ErrUnavailable
+}
+
+type unavailableTransitions struct{}
+
+func (unavailableTransitions) Create(ctx context.Context, transition *models.Transition) error {
+	return ErrUnavailable
+}
+
+func (unavailableTransitions) Find(ctx context.Context, resource string, id uuid.UUID) ([]models.Transition, error) {
+	return nil, ErrUnavailable
+}
+
+type unavailableApplications struct{}
+
+func (unavailableApplications) Create(ctx context.Context, application *models.Application) error {
+	return ErrUnavailable
+}
+
+func (unavailableApplications) Get(ctx context.Context, id uuid.UUID) (*models.Application, error) {
+	return nil, ErrUnavailable
+}
+
+func (unavailableApplications) Find(ctx context.Context, filter *ApplicationFilter) ([]models.Application, error) {
+	return nil, ErrUnavailable
+}
+
+func (unavailableApplications) Update(ctx context.Context, id uuid.UUID, application *models.Application) error {
+	return ErrUnavailable
+}
+
+func (unavailableApplications) SetStatus(ctx context.Context, id uuid.UUID, from string, application *models.Application) error {
+	return ErrUnavailable
+}
+
+func (unavailableApplications) Delete(ctx context.Context, id uuid.UUID) error {
+	return ErrUnavailable
+}
+
+type unavailableTenants struct{}
+
+func (unavailableTenants) Create(ctx context.Context, tenant *models.Tenant) error {
+	return ErrUnavailable
+}
+
+func (unavailableTenants) Get(ctx context.Context, id string) (*models.Tenant, error) {
+	return nil, ErrUnavailable
+}
+
+func (unavailableTenants) Find(ctx context.Context) ([]models.Tenant, error) {
+	return nil, ErrUnavailable
+}
+
+func (unavailableTenants) Delete(ctx context.Context, id string) error {
+	return ErrUnavailable
+}
+
+type unavailableBreeds struct{}
+
+func (unavailableBreeds) Create(ctx context.Context, breed *models.Breed) error {
+	return ErrUnavailable
+}
+
+func (unavailableBreeds) Get(ctx context.Context, id uuid.UUID) (*models.Breed, error) {
+	return nil, ErrUnavailable
+}
+
+func (unavailableBreeds) Find(ctx context.Context, species string) ([]models.Breed, error) {
+	return nil, ErrUnavailable
+}
+
+func (unavailableBreeds) Update(ctx context.Context, id uuid.UUID, breed *models.Breed) error {
+	return ErrUnavailable
+}
+
+func (unavailableBreeds) Delete(ctx context.Context, id uuid.UUID) error {
+	return ErrUnavailable
+}
@@ -0,0 +1,92 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// flakyRepository fails to ping with err.
+type flakyRepository struct {
+	Repository
+	err error
+}
+
+func (r *flakyRepository) Ping(ctx context.Context) error {
+	return r.err
+}
+
+func TestMonitor(t *testing.T) {
+	ctx := context.Background()
+	down := errors.New("connection refused")
+	repo := &flakyRepository{Repository: NewMemory()}
+	connectErr := down
+	connects := 0
+	monitor := NewMonitor(repo, func(ctx context.Context) error {
+		connects++
+		return connectErr
+	})
+
+	wantAvailable := func(t *testing.T, want bool) {
+		t.Helper()
+		_, err := monitor.Cats().Get(ctx, uuid.New())
+		if got := !errors.Is(err, ErrUnavailable); got != want {
+			t.Errorf("Cats().Get() error = %v, want available %v", err, want)
+		}
+		err = monitor.Transaction(ctx, func(tx Repository) error { return nil })
+		if got := !errors.Is(err, ErrUnavailable); got != want {
+			t.Errorf("Transaction() error = %v, want available %v", err, want)
+		}
+	}
+
+	t.Run("Should be unavailable before connecting", func(t *testing.T) {
+		wantAvailable(t, false)
+		if err := monitor.Ping(ctx); !errors.Is(err, ErrUnavailable) {
+			t.Errorf("Ping() error = %v, want %v", err, ErrUnavailable)
+		}
+	})
+
+	t.Run("Should give up connecting after the maximum wait", func(t *testing.T) {
+		err := monitor.Connect(ctx, 250*time.Millisecond)
+		if !errors.Is(err, down) {
+			t.Errorf("Connect() error = %v, want %v", err, down)
+		}
+		if connects < 2 {
+			t.Errorf("Connect() tried %d times, want retries", connects)
+		}
+		wantAvailable(t, false)
+	})
+
+	t.Run("Should connect in the background", func(t *testing.T) {
+		connectErr = nil
+		monitor.Check(ctx)
+		wantAvailable(t, true)
+		if err := monitor.Ping(ctx); err != nil {
+			t.Errorf("Ping() error = %v", err)
+		}
+	})
+
+	t.Run("Should only connect once", func(t *testing.T) {
+		before := connects
+		monitor.Check(ctx)
+		if connects != before {
+			t.Errorf("Check() connected again")
+		}
+	})
+
+	t.Run("Should be unavailable while pings fail", func(t *testing.T) {
+		repo.err = down
+		if err := monitor.Ping(ctx); !errors.Is(err, ErrUnavailable) {
+			t.Errorf("Ping() error = %v, want %v", err, ErrUnavailable)
+		}
+		monitor.Check(ctx)
+		wantAvailable(t, false)
+
+		repo.err = nil
+		monitor.Check(ctx)
+		wantAvailable(t, true)
+	})
+}
@@ -0,0 +1,95 @@
+package repositories
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"github.com/one-byte-data/go-api-sample/internal/contexts"
+	"gorm.io/gorm"
+)
+
+// Replicas routes reads to read replicas of a primary database. Reads go
+// round-robin to the replicas that passed their last health check and fall
+// back to the primary when none did. Writes, transactions and reads asking
+// to read your writes always use the primary.
+type Replicas struct {
+	primary  *gorm.DB
+	replicas []*replica
+	next     atomic.Uint64
+}
+
+type replica struct {
+	db      *gorm.DB
+	healthy atomic.Bool
+}
+
+// NewReplicas returns the replicas of primary. They are considered healthy
+// until a health check fails, see Check and Watch.
+func NewReplicas(primary *gorm.DB, replicas ...*gorm.DB) *Replicas {
+	r := &Replicas{primary: primary}
+	for _, db := range replicas {
+		replica := &replica{db: db}
+		replica.healthy.Store(true)
+		r.replicas = append(r.replicas, replica)
+	}
+	return r
+}
+
+// Reader returns the database a read should use.
+func (r *Replicas) Reader(ctx context.Context) *gorm.DB {
+	if contexts.ReadYourWrites(ctx) {
+		return r.primary
+	}
+
+	n := len(r.replicas)
+	start := r.next.Add(1)
+	for i := 0; i < n; i++ {
+		replica := r.replicas[(start+uint64(i))%uint64(n)]
+		if replica.healthy.Load() {
+			return replica.db
+		}
+	}
+	return r.primary
+}
+
+// Check pings every replica and records whether it is healthy.
+func (r *Replicas) Check(ctx context.Context) {
+	for i, replica := range r.replicas {
+		err := ping(ctx, replica.db)
+		healthy := err == nil
+		if replica.healthy.Swap(healthy) != healthy {
+			if healthy {
+				slog.InfoContext(ctx, "database replica recovered", "replica", i)
+			} else {
+				slog.WarnContext(ctx, "database replica failed its health check, reading from the primary instead", "replica", i, "error", err)
+			}
+		}
+	}
+}
+
+// Watch checks the replicas every interval until ctx is done.
+func (r *Replicas) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			checkCtx, cancel := context.WithTimeout(ctx, interval)
+			r.Check(checkCtx)
+			cancel()
+		}
+	}
+}
+
+func ping(ctx context.Context, db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
@@ -0,0 +1,53 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/one-byte-data/go-api-sample/internal/contexts"
+	"github.com/one-byte-data/go-api-sample/internal/models"
+)
+
+func TestReplicas(t *testing.T) {
+	ctx := context.Background()
+	primary := openSQLite(t, "primary.db")
+	replica := openSQLite(t, "replica.db")
+	routing := NewReplicas(primary, replica)
+	repo := NewReplicatedGorm(routing)
+
+	// The replica has not caught up with the cat yet.
+	cat := models.Cat{ID: uuid.New(), Name: "Tom", Breed: "Siamese", Color: "Grey", Birthdate: time.Now().UTC(), Weight: 4}
+	if err := repo.Cats().Create(ctx, &cat); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if err := primary.First(&models.Cat{}, cat.ID).Error; err != nil {
+		t.Fatalf("Create() did not write to the primary: %v", err)
+	}
+
+	if _, err := repo.Cats().Get(ctx, cat.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get() error = %v, want the replica to be read", err)
+	}
+	if _, err := repo.Cats().Get(contexts.WithReadYourWrites(ctx), cat.ID); err != nil {
+		t.Errorf("Get() error = %v, want reading your writes to read the primary", err)
+	}
+	err := repo.Transaction(ctx, func(tx Repository) error {
+		_, err := tx.Cats().Get(ctx, cat.ID)
+		return err
+	})
+	if err != nil {
+		t.Errorf("Transaction() error = %v, want transactions to read the primary", err)
+	}
+
+	sqlDB, err := replica.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB.Close()
+	routing.Check(ctx)
+	if _, err := repo.Cats().Get(ctx, cat.ID); err != nil {
+		t.Errorf("Get() error = %v, want an unhealthy replica to fail over to the primary", err)
+	}
+}
@@ -0,0 +1,166 @@
+// Package repositories stores cats, dogs, their status transitions and
+// adoption applications, the audit log, the tenants owning them and the
+// breed catalogue. The services depend on the Repository interface so the
+// storage backend can be chosen by configuration: Postgres or CockroachDB
+// and SQLite through GORM, or memory.
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/one-byte-data/go-api-sample/internal/models"
+)
+
+// ErrNotFound is returned, possibly wrapped, when the requested row does
+// not exist.
+var ErrNotFound = errors.New("record not found")
+
+// ErrConflict is returned, possibly wrapped, when a row cannot be stored
+// because it conflicts with another, e.g. its ID is taken.
+var ErrConflict = errors.New("conflict")
+
+// Repository gives access to every table. Writes made through the
+// repository passed to fn by Transaction are committed together when fn
+// returns nil and discarded otherwise. Backends may call fn more than once
+// to retry a transaction the database aborted.
+type Repository interface {
+	Transaction(ctx context.Context, fn func(tx Repository) error) error
+	Cats() AnimalRepository[models.Cat]
+	Dogs() AnimalRepository[models.Dog]
+	Audit() AuditRepository
+	Tenants() TenantRepository
+	Breeds() BreedRepository
+	Transitions() TransitionRepository
+	Applications() ApplicationRepository
+	// Ping checks the backend can be reached.
+	Ping(ctx context.Context) error
+}
+
+// AnimalRepository stores cats or dogs. Every method only sees the items of
+// the tenant of the context, see contexts.WithTenant, and Create assigns the
+// item to that tenant.
+type AnimalRepository[T any] interface {
+	Create(ctx context.Context, item *T) error
+	// Get returns ErrNotFound when there is no item with the ID.
+	Get(ctx context.Context, id uuid.UUID) (*T, error)
+	// Find returns the items matching the filter, which may be nil.
+	Find(ctx context.Context, filter *AnimalFilter) ([]T, error)
+	// Count counts the items matching the filter, ignoring After and Limit.
+	Count(ctx context.Context, filter *AnimalFilter) (int64, error)
+	// Stats summarizes the items matching the filter, ignoring After and
+	// Limit, with their ages as of now.
+	Stats(ctx context.Context, filter *AnimalFilter, now time.Time) (*models.AnimalStats, error)
+	// Update stores the non-zero fields of item other than the ID and
+	// returns ErrNotFound when there is no item with the ID.
+	Update(ctx context.Context, id uuid.UUID, item *T) error
+	// SetStatus changes the status of the item from one status to another
+	// and returns ErrNotFound when there is no item with the ID and status
+	// from, e.g. because another transaction changed it first.
+	SetStatus(ctx context.Context, id uuid.UUID, from, to string) error
+	// Delete returns ErrNotFound when there is no item with the ID.
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// AuditRepository stores the audit log, scoped to the tenant of the context
+// like AnimalRepository.
+type AuditRepository interface {
+	Create(ctx context.Context, entry *models.AuditEntry) error
+	// Find returns the entries matching the filter ordered by timestamp.
+	Find(ctx context.Context, filter *AuditFilter) ([]models.AuditEntry, error)
+}
+
+// TransitionRepository stores the status transitions of cats and dogs,
+// scoped to the tenant of the context like AnimalRepository.
+type TransitionRepository interface {
+	Create(ctx context.Context, transition *models.Transition) error
+	// Find returns the transitions of the item of the resource ordered by
+	// timestamp.
+	Find(ctx context.Context, resource string, id uuid.UUID) ([]models.Transition, error)
+}
+
+// ApplicationRepository stores adoption applications, scoped to the tenant
+// of the context like AnimalRepository.
+type ApplicationRepository interface {
+	Create(ctx context.Context, application *models.Application) error
+	// Get returns ErrNotFound when there is no application with the ID.
+	Get(ctx context.Context, id uuid.UUID) (*models.Application, error)
+	// Find returns the applications matching the filter, which may be nil,
+	// ordered by creation time.
+	Find(ctx context.Context, filter *ApplicationFilter) ([]models.Application, error)
+	// Update stores the non-zero fields of application other than the ID
+	// and returns ErrNotFound when there is no application with the ID.
+	Update(ctx context.Context, id uuid.UUID, application *models.Application) error
+	// SetStatus stores the status, reviewer and update time of application
+	// and returns ErrNotFound when there is no application with the ID and
+	// status from, e.g. because another transaction reviewed it first.
+	SetStatus(ctx context.Context, id uuid.UUID, from string, application *models.Application) error
+	// Delete returns ErrNotFound when there is no application with the ID.
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// TenantRepository stores the tenants. It is not scoped to a tenant.
+type TenantRepository interface {
+	Create(ctx context.Context, tenant *models.Tenant) error
+	// Get returns ErrNotFound when there is no tenant with the ID.
+	Get(ctx context.Context, id string) (*models.Tenant, error)
+	// Find returns every tenant ordered by ID.
+	Find(ctx context.Context) ([]models.Tenant, error)
+	// Delete returns ErrNotFound when there is no tenant with the ID.
+	Delete(ctx context.Context, id string) error
+}
+
+// BreedRepository stores the breed catalogue, which every tenant shares.
+type BreedRepository interface {
+	Create(ctx context.Context, breed *models.Breed) error
+	// Get returns ErrNotFound when there is no breed with the ID.
+	Get(ctx context.Context, id uuid.UUID) (*models.Breed, error)
+	// Find returns the breeds of the species ordered by name.
+	Find(ctx context.Context, species string) ([]models.Breed, error)
+	// Update replaces the name, aliases and weights of the breed and
+	// returns ErrNotFound when there is no breed with the ID.
+	Update(ctx context.Context, id uuid.UUID, breed *models.Breed) error
+	// Delete returns ErrNotFound when there is no breed with the ID.
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// AnimalFilter narrows the cats or dogs returned by Find and counted by
+// Count. Zero fields do not filter. Name matches a substring, breed and
+// color match exactly, and all three ignore case. Status matches exactly.
+//
+// Filtered results are ordered by ID so a page can continue after the last
+// ID of the previous one.
+type AnimalFilter struct {
+	IDs        []uuid.UUID `json:"ids,omitempty"`
+	Name       string      `json:"name,omitempty"`
+	Breed      string      `json:"breed,omitempty"`
+	Color      string      `json:"color,omitempty"`
+	Status     string      `json:"status,omitempty"`
+	MinWeight  int         `json:"min_weight,omitempty"`
+	MaxWeight  int         `json:"max_weight,omitempty"`
+	BornAfter  *time.Time  `json:"born_after,omitempty"`
+	BornBefore *time.Time  `json:"born_before,omitempty"`
+	After      *uuid.UUID  `json:"after,omitempty"`
+	Limit      int         `json:"limit,omitempty"`
+}
+
+type AuditFilter struct {
+	Resource   string
+	ResourceID *uuid.UUID
+	// ResourceIDs matches entries of any of the resources, e.g. to load the
+	// history of a page of animals at once.
+	ResourceIDs []uuid.UUID
+	Actor       string
+	Since       *time.Time
+	Until       *time.Time
+}
+
+// ApplicationFilter narrows the applications returned by Find. Zero fields
+// do not filter.
+type ApplicationFilter struct {
+	Species  string
+	AnimalID *uuid.UUID
+	Status   string
+}
@@ -0,0 +1,648 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"github.com/google/uuid"
+	"github.com/one-byte-data/go-api-sample/cmd/tests"
+	"github.com/one-byte-data/go-api-sample/internal/contexts"
+	"github.com/one-byte-data/go-api-sample/internal/models"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestMemory(t *testing.T) {
+	testRepository(t, func(t *testing.T) Repository {
+		return NewMemory()
+	})
+}
+
+func TestSQLite(t *testing.T) {
+	testRepository(t, func(t *testing.T) Repository {
+		return NewGorm(openSQLite(t, "animals.db"))
+	})
+}
+
+func openSQLite(t *testing.T, name string) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), name)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Migrate(db); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func TestIntegrationPostgres(t *testing.T) {
+	if m := flag.Lookup("test.run").Value.String(); m == "" || !regexp.MustCompile(m).MatchString(t.Name()) {
+		t.Skip("skipping as execution was not requested explicitly using go test -run")
+	}
+
+	testRepository(t, func(t *testing.T) Repository {
+		db, err := gorm.Open(postgres.Open(tests.ConnectionString))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := Migrate(db); err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() {
+			db.Migrator().DropTable(&models.Cat{}, &models.Dog{}, &models.AuditEntry{}, &models.Tenant{}, &models.Breed{}, &models.Transition{}, &models.Application{})
+		})
+		return NewGorm(db)
+	})
+}
+
+// testRepository runs the same checks against every backend so they behave
+// alike. newRepo must return an empty repository.
+func testRepository(t *testing.T, newRepo func(t *testing.T) Repository) {
+	ctx := context.Background()
+	day := func(d int) time.Time {
+		return time.Date(2020, time.January, d, 0, 0, 0, 0, time.UTC)
+	}
+	cats := []models.Cat{
+		{ID: uuid.MustParse("10000000-0000-0000-0000-000000000000"), Name: "Tom", Breed: "Siamese", Color: "Grey", Birthdate: day(1), Weight: 4},
+		{ID: uuid.MustParse("20000000-0000-0000-0000-000000000000"), Name: "Tommy", Breed: "Persian", Color: "White", Birthdate: day(2), Weight: 6},
+		{ID: uuid.MustParse("30000000-0000-0000-0000-000000000000"), Name: "Felix", Breed: "siamese", Color: "Black", Birthdate: day(3), Weight: 8},
+	}
+	seed := func(t *testing.T) Repository {
+		repo := newRepo(t)
+		for i := range cats {
+			cat := cats[i]
+			if err := repo.Cats().Create(ctx, &cat); err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+		}
+		return repo
+	}
+	ids := func(items []models.Cat) []uuid.UUID {
+		result := make([]uuid.UUID, 0, len(items))
+		for _, item := range items {
+			result = append(result, item.ID)
+		}
+		return result
+	}
+	equalIDs := func(t *testing.T, got []models.Cat, want ...models.Cat) {
+		t.Helper()
+		if len(got) != len(want) {
+			t.Fatalf("got IDs %v, want %v", ids(got), ids(want))
+		}
+		for i := range got {
+			if got[i].ID != want[i].ID {
+				t.Fatalf("got IDs %v, want %v", ids(got), ids(want))
+			}
+		}
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		repo := seed(t)
+
+		duplicate := cats[0]
+		if err := repo.Cats().Create(ctx, &duplicate); !errors.Is(err, ErrConflict) {
+			t.Errorf("Create() error = %v, want %v", err, ErrConflict)
+		}
+		if got, err := repo.Cats().Count(ctx, nil); err != nil || got != int64(len(cats)) {
+			t.Errorf("Count() = %v, %v, want %v", got, err, len(cats))
+		}
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		repo := seed(t)
+
+		got, err := repo.Cats().Get(ctx, cats[1].ID)
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		if got.Name != cats[1].Name || got.Weight != cats[1].Weight || !got.Birthdate.Equal(cats[1].Birthdate) {
+			t.Errorf("Get() = %+v, want %+v", got, cats[1])
+		}
+
+		if _, err := repo.Cats().Get(ctx, uuid.New()); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Get() error = %v, want %v", err, ErrNotFound)
+		}
+	})
+
+	t.Run("Find", func(t *testing.T) {
+		repo := seed(t)
+		tests := []struct {
+			name   string
+			filter *AnimalFilter
+			want   []models.Cat
+		}{
+			{name: "Should find everything with an empty filter", filter: &AnimalFilter{}, want: cats},
+			{name: "Should filter by ID", filter: &AnimalFilter{IDs: []uuid.UUID{cats[2].ID, cats[0].ID}}, want: []models.Cat{cats[0], cats[2]}},
+			{name: "Should match a part of the name ignoring case", filter: &AnimalFilter{Name: "TOM"}, want: cats[:2]},
+			{name: "Should match the breed ignoring case", filter: &AnimalFilter{Breed: "Siamese"}, want: []models.Cat{cats[0], cats[2]}},
+			{name: "Should match the color ignoring case", filter: &AnimalFilter{Color: "white"}, want: cats[1:2]},
+			{name: "Should filter by weight", filter: &AnimalFilter{MinWeight: 5, MaxWeight: 8}, want: cats[1:]},
+			{name: "Should filter by birthdate", filter: &AnimalFilter{BornAfter: &cats[1].Birthdate, BornBefore: &cats[2].Birthdate}, want: cats[1:2]},
+			{name: "Should page after an ID", filter: &AnimalFilter{After: &cats[0].ID, Limit: 1}, want: cats[1:2]},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				got, err := repo.Cats().Find(ctx, tt.filter)
+				if err != nil {
+					t.Fatalf("Find() error = %v", err)
+				}
+				equalIDs(t, got, tt.want...)
+			})
+		}
+	})
+
+	t.Run("Count", func(t *testing.T) {
+		repo := seed(t)
+
+		got, err := repo.Cats().Count(ctx, &AnimalFilter{Breed: "siamese", After: &cats[0].ID, Limit: 1})
+		if err != nil {
+			t.Fatalf("Count() error = %v", err)
+		}
+		if got != 2 {
+			t.Errorf("Count() = %v, want 2", got)
+		}
+	})
+
+	t.Run("Status", func(t *testing.T) {
+		repo := seed(t)
+
+		got, err := repo.Cats().Get(ctx, cats[0].ID)
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		if got.Status != models.StatusIntake {
+			t.Errorf("Get() status = %q, want %q", got.Status, models.StatusIntake)
+		}
+
+		if err := repo.Cats().SetStatus(ctx, cats[0].ID, models.StatusIntake, models.StatusAvailable); err != nil {
+			t.Fatalf("SetStatus() error = %v", err)
+		}
+		if err := repo.Cats().SetStatus(ctx, cats[0].ID, models.StatusIntake, models.StatusOnHold); !errors.Is(err, ErrNotFound) {
+			t.Errorf("SetStatus() from a stale status error = %v, want %v", err, ErrNotFound)
+		}
+		if err := repo.Cats().SetStatus(contexts.WithTenant(ctx, "shelter-b"), cats[1].ID, models.StatusIntake, models.StatusAvailable); !errors.Is(err, ErrNotFound) {
+			t.Errorf("SetStatus() for another tenant error = %v, want %v", err, ErrNotFound)
+		}
+		if err := repo.Cats().SetStatus(ctx, uuid.New(), models.StatusIntake, models.StatusAvailable); !errors.Is(err, ErrNotFound) {
+			t.Errorf("SetStatus() error = %v, want %v", err, ErrNotFound)
+		}
+
+		available, err := repo.Cats().Find(ctx, &AnimalFilter{Status: models.StatusAvailable})
+		if err != nil {
+			t.Fatalf("Find() error = %v", err)
+		}
+		equalIDs(t, available, cats[0])
+		if got, err := repo.Cats().Count(ctx, &AnimalFilter{Status: models.StatusIntake}); err != nil || got != 2 {
+			t.Errorf("Count() = %v, %v, want 2", got, err)
+		}
+	})
+
+	t.Run("Transitions", func(t *testing.T) {
+		repo := newRepo(t)
+		transitions := []models.Transition{
+			{ID: uuid.New(), Resource: "cats", ResourceID: cats[0].ID, From: models.StatusAvailable, To: models.StatusAdopted, Actor: "alice", Timestamp: day(2)},
+			{ID: uuid.New(), Resource: "cats", ResourceID: cats[0].ID, From: models.StatusIntake, To: models.StatusAvailable, Actor: "bob", Timestamp: day(1)},
+			{ID: uuid.New(), Resource: "dogs", ResourceID: cats[0].ID, From: models.StatusIntake, To: models.StatusOnHold, Actor: "alice", Timestamp: day(3)},
+		}
+		for i := range transitions {
+			if err := repo.Transitions().Create(ctx, &transitions[i]); err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+		}
+
+		got, err := repo.Transitions().Find(ctx, "cats", cats[0].ID)
+		if err != nil {
+			t.Fatalf("Find() error = %v", err)
+		}
+		if len(got) != 2 || got[0].ID != transitions[1].ID || got[1].ID != transitions[0].ID {
+			t.Fatalf("Find() = %+v, want the transitions of the cat oldest first", got)
+		}
+		if got[1].From != models.StatusAvailable || got[1].To != models.StatusAdopted || got[1].Actor != "alice" || !got[1].Timestamp.Equal(day(2)) {
+			t.Errorf("Find()[1] = %+v, want %+v", got[1], transitions[0])
+		}
+
+		if got, err := repo.Transitions().Find(contexts.WithTenant(ctx, "shelter-b"), "cats", cats[0].ID); err != nil || len(got) != 0 {
+			t.Errorf("Find() for another tenant = %d transitions, %v, want none", len(got), err)
+		}
+	})
+
+	t.Run("Stats", func(t *testing.T) {
+		repo := newRepo(t)
+		now := time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)
+		animals := []models.Cat{
+			{ID: uuid.New(), Name: "Kitten", Breed: "Siamese", Color: "Grey", Birthdate: now.AddDate(0, -5, 0), Weight: 4},
+			{ID: uuid.New(), Name: "Young", Breed: "Siamese", Color: "Grey", Birthdate: now.AddDate(-2, 0, 0), Weight: 5},
+			{ID: uuid.New(), Name: "Adult", Breed: "Siamese", Color: "White", Birthdate: now.AddDate(-4, -5, 0), Weight: 7},
+			{ID: uuid.New(), Name: "Four", Breed: "Siamese", Color: "Black", Birthdate: now.AddDate(-4, 0, 0), Weight: 10},
+			{ID: uuid.New(), Name: "Senior", Breed: "Persian", Color: "White", Birthdate: now.AddDate(-14, 0, 0), Weight: 6, Status: models.StatusAvailable},
+		}
+		for i := range animals {
+			if err := repo.Cats().Create(ctx, &animals[i]); err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+		}
+
+		got, err := repo.Cats().Stats(ctx, nil, now)
+		if err != nil {
+			t.Fatalf("Stats() error = %v", err)
+		}
+		want := &models.AnimalStats{
+			Total:  5,
+			Breeds: []models.GroupCount{{Value: "Siamese", Count: 4}, {Value: "Persian", Count: 1}},
+			Colors: []models.GroupCount{{Value: "Grey", Count: 2}, {Value: "White", Count: 2}, {Value: "Black", Count: 1}},
+			Ages: []models.AgeBucket{
+				{Label: "0-1", MinYears: 0, MaxYears: 1, Count: 1},
+				{Label: "1-3", MinYears: 1, MaxYears: 3, Count: 1},
+				{Label: "3-7", MinYears: 3, MaxYears: 7, Count: 2},
+				{Label: "7-10", MinYears: 7, MaxYears: 10, Count: 0},
+				{Label: "10+", MinYears: 10, Count: 1},
+			},
+			Weights: []models.BreedWeight{
+				{Breed: "Persian", Count: 1, Mean: 6, Median: 6, P25: 6, P75: 6, P90: 6},
+				{Breed: "Siamese", Count: 4, Mean: 6.5, Median: 6, P25: 4, P75: 7, P90: 10},
+			},
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Stats() = %+v, want %+v", got, want)
+		}
+
+		got, err = repo.Cats().Stats(ctx, &AnimalFilter{Status: models.StatusAvailable}, now)
+		if err != nil {
+			t.Fatalf("Stats() error = %v", err)
+		}
+		if got.Total != 1 || len(got.Breeds) != 1 || got.Breeds[0].Value != "Persian" || len(got.Weights) != 1 || got.Ages[4].Count != 1 {
+			t.Errorf("Stats() filtered = %+v, want only the available cat", got)
+		}
+
+		got, err = repo.Cats().Stats(contexts.WithTenant(ctx, "shelter-b"), nil, now)
+		if err != nil {
+			t.Fatalf("Stats() error = %v", err)
+		}
+		if got.Total != 0 || len(got.Breeds) != 0 || len(got.Weights) != 0 || got.Ages[0].Count != 0 {
+			t.Errorf("Stats() for another tenant = %+v, want no cats", got)
+		}
+	})
+
+	t.Run("Applications", func(t *testing.T) {
+		repo := newRepo(t)
+		applications := []models.Application{
+			{ID: uuid.New(), Species: "cats", AnimalID: cats[0].ID, ApplicantName: "Alice", ApplicantEmail: "alice@example.com", CreatedAt: day(2)},
+			{ID: uuid.New(), Species: "cats", AnimalID: cats[0].ID, ApplicantName: "Bob", ApplicantEmail: "bob@example.com", CreatedAt: day(1)},
+			{ID: uuid.New(), Species: "dogs", AnimalID: cats[0].ID, ApplicantName: "Carol", ApplicantEmail: "carol@example.com", CreatedAt: day(3)},
+		}
+		for i := range applications {
+			if err := repo.Applications().Create(ctx, &applications[i]); err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+		}
+		duplicate := applications[0]
+		if err := repo.Applications().Create(contexts.WithTenant(ctx, "shelter-b"), &duplicate); !errors.Is(err, ErrConflict) {
+			t.Errorf("Create() with the ID of an application of another tenant error = %v, want %v", err, ErrConflict)
+		}
+
+		got, err := repo.Applications().Find(ctx, &ApplicationFilter{Species: "cats", AnimalID: &cats[0].ID})
+		if err != nil {
+			t.Fatalf("Find() error = %v", err)
+		}
+		if len(got) != 2 || got[0].ID != applications[1].ID || got[1].ID != applications[0].ID {
+			t.Fatalf("Find() = %+v, want the applications for the cat oldest first", got)
+		}
+		if got[0].Status != models.ApplicationSubmitted {
+			t.Errorf("Find()[0] status = %q, want %q", got[0].Status, models.ApplicationSubmitted)
+		}
+
+		if err := repo.Applications().Update(ctx, applications[0].ID, &models.Application{Notes: "Has a garden"}); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+		if got, err := repo.Applications().Get(ctx, applications[0].ID); err != nil || got.Notes != "Has a garden" || got.ApplicantName != "Alice" {
+			t.Errorf("Get() = %+v, %v, want only the notes changed", got, err)
+		}
+
+		reviewed := &models.Application{Status: models.ApplicationUnderReview, Reviewer: "dana", UpdatedAt: day(4)}
+		if err := repo.Applications().SetStatus(ctx, applications[0].ID, models.ApplicationSubmitted, reviewed); err != nil {
+			t.Fatalf("SetStatus() error = %v", err)
+		}
+		if err := repo.Applications().SetStatus(ctx, applications[0].ID, models.ApplicationSubmitted, reviewed); !errors.Is(err, ErrNotFound) {
+			t.Errorf("SetStatus() from a stale status error = %v, want %v", err, ErrNotFound)
+		}
+		got, err = repo.Applications().Find(ctx, &ApplicationFilter{Status: models.ApplicationUnderReview})
+		if err != nil {
+			t.Fatalf("Find() error = %v", err)
+		}
+		if len(got) != 1 || got[0].ID != applications[0].ID || got[0].Reviewer != "dana" {
+			t.Errorf("Find() by status = %+v, want the reviewed application", got)
+		}
+
+		if got, err := repo.Applications().Find(contexts.WithTenant(ctx, "shelter-b"), nil); err != nil || len(got) != 0 {
+			t.Errorf("Find() for another tenant = %d applications, %v, want none", len(got), err)
+		}
+		if _, err := repo.Applications().Get(contexts.WithTenant(ctx, "shelter-b"), applications[0].ID); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Get() for another tenant error = %v, want %v", err, ErrNotFound)
+		}
+
+		if err := repo.Applications().Delete(ctx, applications[0].ID); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+		if err := repo.Applications().Delete(ctx, applications[0].ID); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Delete() error = %v, want %v", err, ErrNotFound)
+		}
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		repo := seed(t)
+
+		if err := repo.Cats().Update(ctx, cats[0].ID, &models.Cat{Name: "Thomas"}); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+		got, err := repo.Cats().Get(ctx, cats[0].ID)
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		if got.Name != "Thomas" || got.Breed != cats[0].Breed || got.Weight != cats[0].Weight {
+			t.Errorf("Update() stored %+v, want only the name changed", got)
+		}
+
+		if err := repo.Cats().Update(ctx, uuid.New(), &models.Cat{Name: "Thomas"}); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Update() error = %v, want %v", err, ErrNotFound)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		repo := seed(t)
+
+		if err := repo.Cats().Delete(ctx, cats[0].ID); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+		if _, err := repo.Cats().Get(ctx, cats[0].ID); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Get() error = %v, want %v", err, ErrNotFound)
+		}
+		if err := repo.Cats().Delete(ctx, cats[0].ID); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Delete() error = %v, want %v", err, ErrNotFound)
+		}
+	})
+
+	t.Run("Transaction", func(t *testing.T) {
+		repo := seed(t)
+		dog := models.Dog{ID: uuid.New(), Name: "Rex", Breed: "Boxer", Color: "Brown", Birthdate: day(1), Weight: 30}
+		failed := errors.New("failed")
+
+		err := repo.Transaction(ctx, func(tx Repository) error {
+			if err := tx.Dogs().Create(ctx, &dog); err != nil {
+				return err
+			}
+			if err := tx.Cats().Delete(ctx, cats[0].ID); err != nil {
+				return err
+			}
+			if err := tx.Cats().Update(ctx, cats[1].ID, &models.Cat{Name: "Renamed"}); err != nil {
+				return err
+			}
+			if err := tx.Audit().Create(ctx, &models.AuditEntry{ID: uuid.New(), Resource: "dogs", ResourceID: dog.ID, Action: "create", Timestamp: day(1)}); err != nil {
+				return err
+			}
+			return failed
+		})
+		if !errors.Is(err, failed) {
+			t.Fatalf("Transaction() error = %v, want %v", err, failed)
+		}
+		if _, err := repo.Dogs().Get(ctx, dog.ID); !errors.Is(err, ErrNotFound) {
+			t.Errorf("rolled back create: Get() error = %v, want %v", err, ErrNotFound)
+		}
+		if _, err := repo.Cats().Get(ctx, cats[0].ID); err != nil {
+			t.Errorf("rolled back delete: Get() error = %v", err)
+		}
+		if got, err := repo.Cats().Get(ctx, cats[1].ID); err != nil || got.Name != cats[1].Name {
+			t.Errorf("rolled back update: Get() = %v, %v, want name %v", got, err, cats[1].Name)
+		}
+		if entries, err := repo.Audit().Find(ctx, nil); err != nil || len(entries) != 0 {
+			t.Errorf("rolled back audit: Find() = %v, %v, want none", entries, err)
+		}
+
+		err = repo.Transaction(ctx, func(tx Repository) error {
+			return tx.Dogs().Create(ctx, &dog)
+		})
+		if err != nil {
+			t.Fatalf("Transaction() error = %v", err)
+		}
+		if _, err := repo.Dogs().Get(ctx, dog.ID); err != nil {
+			t.Errorf("committed create: Get() error = %v", err)
+		}
+	})
+
+	t.Run("Audit", func(t *testing.T) {
+		repo := newRepo(t)
+		entries := []models.AuditEntry{
+			{ID: uuid.New(), Resource: "cats", ResourceID: cats[0].ID, Action: "update", Actor: "alice", Timestamp: day(2)},
+			{ID: uuid.New(), Resource: "cats", ResourceID: cats[0].ID, Action: "create", Actor: "bob", Timestamp: day(1)},
+			{ID: uuid.New(), Resource: "dogs", ResourceID: cats[1].ID, Action: "create", Actor: "alice", Timestamp: day(3)},
+		}
+		for i := range entries {
+			if err := repo.Audit().Create(ctx, &entries[i]); err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+		}
+
+		tests := []struct {
+			name   string
+			filter *AuditFilter
+			want   []models.AuditEntry
+		}{
+			{name: "Should order everything by timestamp", filter: nil, want: []models.AuditEntry{entries[1], entries[0], entries[2]}},
+			{name: "Should filter by resource", filter: &AuditFilter{Resource: "cats", ResourceID: &cats[0].ID}, want: []models.AuditEntry{entries[1], entries[0]}},
+			{name: "Should filter by resource IDs", filter: &AuditFilter{ResourceIDs: []uuid.UUID{cats[1].ID}}, want: entries[2:]},
+			{name: "Should filter by actor", filter: &AuditFilter{Actor: "alice"}, want: []models.AuditEntry{entries[0], entries[2]}},
+			{name: "Should filter by time", filter: &AuditFilter{Since: &entries[0].Timestamp, Until: &entries[2].Timestamp}, want: entries[:1]},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				got, err := repo.Audit().Find(ctx, tt.filter)
+				if err != nil {
+					t.Fatalf("Find() error = %v", err)
+				}
+				if len(got) != len(tt.want) {
+					t.Fatalf("Find() returned %d entries, want %d", len(got), len(tt.want))
+				}
+				for i := range got {
+					if got[i].ID != tt.want[i].ID {
+						t.Errorf("Find()[%d] = %v, want %v", i, got[i].ID, tt.want[i].ID)
+					}
+				}
+			})
+		}
+	})
+
+	t.Run("Tenant isolation", func(t *testing.T) {
+		repo := seed(t)
+		other := contexts.WithTenant(ctx, "shelter-b")
+
+		if _, err := repo.Cats().Get(other, cats[0].ID); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Get() error = %v, want %v", err, ErrNotFound)
+		}
+		if got, err := repo.Cats().Find(other, nil); err != nil || len(got) != 0 {
+			t.Errorf("Find() = %v, %v, want no cats", ids(got), err)
+		}
+		if got, err := repo.Cats().Find(other, &AnimalFilter{Breed: "siamese"}); err != nil || len(got) != 0 {
+			t.Errorf("Find() filtered = %v, %v, want no cats", ids(got), err)
+		}
+		if got, err := repo.Cats().Count(other, nil); err != nil || got != 0 {
+			t.Errorf("Count() = %v, %v, want 0", got, err)
+		}
+		if err := repo.Cats().Update(other, cats[0].ID, &models.Cat{Name: "Stolen"}); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Update() error = %v, want %v", err, ErrNotFound)
+		}
+		if err := repo.Cats().Delete(other, cats[0].ID); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Delete() error = %v, want %v", err, ErrNotFound)
+		}
+		if got, err := repo.Cats().Get(ctx, cats[0].ID); err != nil || got.Name != cats[0].Name {
+			t.Errorf("Get() = %+v, %v, want the cat unchanged for its tenant", got, err)
+		}
+		taken := cats[0]
+		if err := repo.Cats().Create(other, &taken); !errors.Is(err, ErrConflict) {
+			t.Errorf("Create() with the ID of a cat of another tenant error = %v, want %v", err, ErrConflict)
+		}
+
+		cat := models.Cat{ID: uuid.New(), Name: "Garfield", Breed: "Persian", Color: "Orange", Birthdate: day(4), Weight: 9, TenantID: contexts.DefaultTenant}
+		if err := repo.Cats().Create(other, &cat); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		if cat.TenantID != "shelter-b" {
+			t.Errorf("Create() tenant = %q, want the tenant of the context", cat.TenantID)
+		}
+		if got, err := repo.Cats().Count(other, nil); err != nil || got != 1 {
+			t.Errorf("Count() = %v, %v, want 1", got, err)
+		}
+		if got, err := repo.Cats().Count(ctx, nil); err != nil || got != int64(len(cats)) {
+			t.Errorf("Count() = %v, %v, want %v", got, err, len(cats))
+		}
+		if _, err := repo.Cats().Get(ctx, cat.ID); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Get() error = %v, want %v", err, ErrNotFound)
+		}
+
+		err := repo.Transaction(ctx, func(tx Repository) error {
+			if _, err := tx.Cats().Get(ctx, cat.ID); !errors.Is(err, ErrNotFound) {
+				t.Errorf("Get() in a transaction error = %v, want %v", err, ErrNotFound)
+			}
+			return tx.Audit().Create(ctx, &models.AuditEntry{ID: uuid.New(), Resource: "cats", ResourceID: cats[0].ID, Action: "update", Actor: "alice", Timestamp: day(5)})
+		})
+		if err != nil {
+			t.Fatalf("Transaction() error = %v", err)
+		}
+		if got, err := repo.Audit().Find(other, nil); err != nil || len(got) != 0 {
+			t.Errorf("Audit().Find() = %d entries, %v, want none", len(got), err)
+		}
+	})
+
+	t.Run("Tenants", func(t *testing.T) {
+		repo := newRepo(t)
+		tenant := models.Tenant{ID: "shelter-b", Name: "Shelter B", CreatedAt: day(1)}
+
+		if err := repo.Tenants().Create(ctx, &tenant); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		if err := repo.Tenants().Create(ctx, &tenant); !errors.Is(err, ErrConflict) {
+			t.Errorf("Create() error = %v, want %v", err, ErrConflict)
+		}
+		got, err := repo.Tenants().Get(ctx, tenant.ID)
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		if got.Name != tenant.Name || !got.CreatedAt.Equal(tenant.CreatedAt) {
+			t.Errorf("Get() = %+v, want %+v", got, tenant)
+		}
+
+		all, err := repo.Tenants().Find(ctx)
+		if err != nil {
+			t.Fatalf("Find() error = %v", err)
+		}
+		if len(all) != 2 || all[0].ID != contexts.DefaultTenant || all[1].ID != tenant.ID {
+			t.Errorf("Find() = %+v, want the default tenant and %v", all, tenant.ID)
+		}
+
+		if err := repo.Tenants().Delete(ctx, tenant.ID); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+		if _, err := repo.Tenants().Get(ctx, tenant.ID); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Get() error = %v, want %v", err, ErrNotFound)
+		}
+		if err := repo.Tenants().Delete(ctx, tenant.ID); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Delete() error = %v, want %v", err, ErrNotFound)
+		}
+	})
+	t.Run("Breeds", func(t *testing.T) {
+		repo := newRepo(t)
+
+		seeded, err := repo.Breeds().Find(ctx, "cats")
+		if err != nil {
+			t.Fatalf("Find() error = %v", err)
+		}
+		if len(seeded) == 0 || seeded[0].Name != "Abyssinian" {
+			t.Fatalf("Find() = %+v, want the bundled breeds ordered by name", seeded)
+		}
+
+		breed := models.Breed{ID: uuid.New(), Species: "cats", Name: "Savannah", Aliases: []string{"Savanna"}, MinWeight: 12, MaxWeight: 25}
+		if err := repo.Breeds().Create(ctx, &breed); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		duplicate := models.Breed{ID: uuid.New(), Species: "cats", Name: "Savannah"}
+		if err := repo.Breeds().Create(ctx, &duplicate); !errors.Is(err, ErrConflict) {
+			t.Errorf("Create() error = %v, want %v for a second breed named %v", err, ErrConflict, duplicate.Name)
+		}
+		if err := repo.Breeds().Create(ctx, &breed); !errors.Is(err, ErrConflict) {
+			t.Errorf("Create() error = %v, want %v", err, ErrConflict)
+		}
+		got, err := repo.Breeds().Get(ctx, breed.ID)
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		if got.Name != breed.Name || len(got.Aliases) != 1 || got.Aliases[0] != "Savanna" || got.MaxWeight != 25 {
+			t.Errorf("Get() = %+v, want %+v", got, breed)
+		}
+
+		err = repo.Breeds().Update(ctx, breed.ID, &models.Breed{Name: "Savannah Cat", Aliases: []string{}, MinWeight: 0, MaxWeight: 30})
+		if err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+		got, err = repo.Breeds().Get(ctx, breed.ID)
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		if got.Name != "Savannah Cat" || got.Species != "cats" || len(got.Aliases) != 0 || got.MinWeight != 0 || got.MaxWeight != 30 {
+			t.Errorf("Update() stored %+v, want every field replaced", got)
+		}
+		if err := repo.Breeds().Update(ctx, uuid.New(), &models.Breed{Name: "Savannah"}); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Update() error = %v, want %v", err, ErrNotFound)
+		}
+		if err := repo.Breeds().Update(ctx, breed.ID, &models.Breed{Name: "Abyssinian", Aliases: []string{}}); !errors.Is(err, ErrConflict) {
+			t.Errorf("Update() error = %v, want %v for the name of another breed", err, ErrConflict)
+		}
+		if err := repo.Breeds().Update(ctx, breed.ID, &models.Breed{Name: "Savannah Cat", Aliases: []string{}, MaxWeight: 30}); err != nil {
+			t.Errorf("Update() error = %v, want the breed to keep its own name", err)
+		}
+
+		dogs, err := repo.Breeds().Find(ctx, "dogs")
+		if err != nil {
+			t.Fatalf("Find() error = %v", err)
+		}
+		for _, dog := range dogs {
+			if dog.ID == breed.ID {
+				t.Errorf("Find() returned the cat breed %v for dogs", breed.Name)
+			}
+		}
+
+		if err := repo.Breeds().Delete(ctx, breed.ID); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+		if _, err := repo.Breeds().Get(ctx, breed.ID); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Get() error = %v, want %v", err, ErrNotFound)
+		}
+		if err := repo.Breeds().Delete(ctx, breed.ID); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Delete() error = %v, want %v", err, ErrNotFound)
+		}
+	})
+}
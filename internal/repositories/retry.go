@@ -0,0 +1,94 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math/rand"
+	"time"
+)
+
+// SQLSTATEs after which a transaction can be retried from the start.
+// CockroachDB reports every retry error as a serialization failure.
+const (
+	sqlStateSerializationFailure = "40001"
+	sqlStateDeadlockDetected     = "40P01"
+)
+
+// RetryPolicy bounds how often and how quickly a transaction that failed
+// with a retryable error is run again.
+type RetryPolicy struct {
+	// MaxAttempts counts the first attempt, so 1 disables retries.
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    5,
+		InitialBackoff: 10 * time.Millisecond,
+		MaxBackoff:     time.Second,
+	}
+}
+
+// IsRetryable reports whether err means the transaction was aborted by the
+// database and running it again may succeed, e.g. SQLSTATE 40001 from
+// CockroachDB under contention.
+func IsRetryable(err error) bool {
+	var sqlErr interface{ SQLState() string }
+	if !errors.As(err, &sqlErr) {
+		return false
+	}
+	switch sqlErr.SQLState() {
+	case sqlStateSerializationFailure, sqlStateDeadlockDetected:
+		return true
+	}
+	return false
+}
+
+// run calls fn until it succeeds, fails with an error that is not
+// retryable, the attempts run out or ctx is done.
+func (p RetryPolicy) run(ctx context.Context, fn func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil {
+			if attempt > 1 {
+				slog.InfoContext(ctx, "transaction committed after retrying", "attempts", attempt)
+			}
+			return nil
+		}
+		if !IsRetryable(err) {
+			return err
+		}
+		if attempt >= p.MaxAttempts {
+			slog.ErrorContext(ctx, "transaction failed after retrying", "attempts", attempt, "error", err)
+			return err
+		}
+
+		backoff := p.backoff(attempt)
+		slog.WarnContext(ctx, "retrying transaction", "attempt", attempt, "backoff", backoff, "error", err)
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// backoff doubles the wait after every attempt up to MaxBackoff and picks a
+// random duration in its upper half so contending transactions spread out.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	backoff := p.MaxBackoff
+	if attempt < 32 && p.InitialBackoff<<(attempt-1) < p.MaxBackoff {
+		backoff = p.InitialBackoff << (attempt - 1)
+	}
+	if backoff <= 1 {
+		return backoff
+	}
+	half := backoff / 2
+	return half + time.Duration(rand.Int63n(int64(backoff-half)+1))
+}
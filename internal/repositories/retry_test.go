@@ -0,0 +1,166 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jackc/pgconn"
+	"github.com/one-byte-data/go-api-sample/internal/models"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{
+			name: "Should retry a serialization failure",
+			err:  &pgconn.PgError{Code: "40001", Message: "restart transaction: TransactionRetryWithProtoRefreshError"},
+			want: true,
+		},
+		{
+			name: "Should retry a wrapped deadlock",
+			err:  errors.Join(errors.New("deleting cat"), &pgconn.PgError{Code: "40P01"}),
+			want: true,
+		},
+		{
+			name: "Should not retry a constraint violation",
+			err:  &pgconn.PgError{Code: "23505"},
+			want: false,
+		},
+		{
+			name: "Should not retry other errors",
+			err:  gorm.ErrInvalidTransaction,
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsRetryable(tt.err); got != tt.want {
+				t.Errorf("IsRetryable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	p := RetryPolicy{MaxAttempts: 10, InitialBackoff: 10 * time.Millisecond, MaxBackoff: 50 * time.Millisecond}
+	tests := []struct {
+		attempt int
+		min     time.Duration
+		max     time.Duration
+	}{
+		{attempt: 1, min: 5 * time.Millisecond, max: 10 * time.Millisecond},
+		{attempt: 2, min: 10 * time.Millisecond, max: 20 * time.Millisecond},
+		{attempt: 3, min: 20 * time.Millisecond, max: 40 * time.Millisecond},
+		{attempt: 4, min: 25 * time.Millisecond, max: 50 * time.Millisecond},
+		{attempt: 64, min: 25 * time.Millisecond, max: 50 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		for i := 0; i < 100; i++ {
+			if got := p.backoff(tt.attempt); got < tt.min || got > tt.max {
+				t.Fatalf("backoff(%d) = %v, want between %v and %v", tt.attempt, got, tt.min, tt.max)
+			}
+		}
+	}
+}
+
+func TestGormTransactionRetries(t *testing.T) {
+	serializationFailure := &pgconn.PgError{Code: "40001", Message: "restart transaction"}
+	cat := &models.Cat{ID: uuid.New(), Name: "Tom", Breed: "Siamese", Color: "Grey", Birthdate: time.Now(), Weight: 4}
+
+	tests := []struct {
+		name         string
+		expect       func(mock sqlmock.Sqlmock)
+		wantErr      bool
+		wantAttempts int
+	}{
+		{
+			name: "Should retry a serialization failure",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`INSERT INTO "cats"`).WillReturnError(serializationFailure)
+				mock.ExpectRollback()
+				mock.ExpectBegin()
+				mock.ExpectExec(`INSERT INTO "cats"`).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			wantAttempts: 2,
+		},
+		{
+			name: "Should retry a failed commit",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`INSERT INTO "cats"`).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit().WillReturnError(serializationFailure)
+				mock.ExpectBegin()
+				mock.ExpectExec(`INSERT INTO "cats"`).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			wantAttempts: 2,
+		},
+		{
+			name: "Should give up after the last attempt",
+			expect: func(mock sqlmock.Sqlmock) {
+				for i := 0; i < 3; i++ {
+					mock.ExpectBegin()
+					mock.ExpectExec(`INSERT INTO "cats"`).WillReturnError(serializationFailure)
+					mock.ExpectRollback()
+				}
+			},
+			wantErr:      true,
+			wantAttempts: 3,
+		},
+		{
+			name: "Should not retry other errors",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`INSERT INTO "cats"`).WillReturnError(&pgconn.PgError{Code: "23505"})
+				mock.ExpectRollback()
+			},
+			wantErr:      true,
+			wantAttempts: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			gdb, err := gorm.Open(postgres.Dialector{
+				Config: &postgres.Config{Conn: db},
+			})
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+
+			tt.expect(mock)
+
+			repo := &gormRepository{db: gdb, retry: RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}}
+			attempts := 0
+			err = repo.Transaction(context.Background(), func(tx Repository) error {
+				attempts++
+				return tx.Cats().Create(context.Background(), cat)
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Transaction() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if attempts != tt.wantAttempts {
+				t.Errorf("Transaction() attempts = %v, want %v", attempts, tt.wantAttempts)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package repositories
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/one-byte-data/go-api-sample/internal/models"
+)
+
+// ageBuckets are the age buckets of AnimalRepository.Stats, youngest first.
+var ageBuckets = []models.AgeBucket{
+	{Label: "0-1", MinYears: 0, MaxYears: 1},
+	{Label: "1-3", MinYears: 1, MaxYears: 3},
+	{Label: "3-7", MinYears: 3, MaxYears: 7},
+	{Label: "7-10", MinYears: 7, MaxYears: 10},
+	{Label: "10+", MinYears: 10},
+}
+
+// percentiles are the weight percentiles of BreedWeight.
+var percentiles = []struct {
+	column  string
+	percent int
+	value   func(weight *models.BreedWeight) *float64
+}{
+	{"p25", 25, func(weight *models.BreedWeight) *float64 { return &weight.P25 }},
+	{"p75", 75, func(weight *models.BreedWeight) *float64 { return &weight.P75 }},
+	{"p90", 90, func(weight *models.BreedWeight) *float64 { return &weight.P90 }},
+}
+
+// ageBucket returns the label of the bucket of an animal born at birthdate.
+func ageBucket(birthdate time.Time, now time.Time) string {
+	for _, bucket := range ageBuckets[:len(ageBuckets)-1] {
+		if birthdate.After(now.AddDate(-bucket.MaxYears, 0, 0)) {
+			return bucket.Label
+		}
+	}
+	return ageBuckets[len(ageBuckets)-1].Label
+}
+
+// ageCase is the SQL equivalent of ageBucket.
+func ageCase(now time.Time) (string, []interface{}) {
+	var sql strings.Builder
+	args := make([]interface{}, 0, 2*len(ageBuckets))
+	sql.WriteString("CASE")
+	for _, bucket := range ageBuckets[:len(ageBuckets)-1] {
+		sql.WriteString(" WHEN birthdate > ? THEN ?")
+		args = append(args, now.AddDate(-bucket.MaxYears, 0, 0), bucket.Label)
+	}
+	sql.WriteString(" ELSE ? END")
+	args = append(args, ageBuckets[len(ageBuckets)-1].Label)
+	return sql.String(), args
+}
+
+// weightColumns selects the BreedWeight of each breed from rows ranked by
+// weight within their breed, see the gorm implementation of Stats. The
+// median rows are found without dividing, as CockroachDB returns a DECIMAL
+// rather than an integer for INT / INT.
+func weightColumns() string {
+	columns := []string{
+		"breed",
+		"COUNT(*) AS count",
+		"AVG(weight) AS mean",
+		"AVG(CASE WHEN position * 2 BETWEEN total AND total + 2 THEN weight END) AS median",
+	}
+	for _, percentile := range percentiles {
+		columns = append(columns, fmt.Sprintf("MIN(CASE WHEN position * 100 >= total * %d THEN weight END) AS %s", percentile.percent, percentile.column))
+	}
+	return strings.Join(columns, ", ")
+}
+
+// summarize computes the stats of the animals in Go, the way the SQL of the
+// gorm implementation does.
+func summarize(animals []attributes, now time.Time) *models.AnimalStats {
+	stats := &models.AnimalStats{Total: int64(len(animals))}
+
+	breeds := make(map[string]int64)
+	colors := make(map[string]int64)
+	ages := make(map[string]int64)
+	weights := make(map[string][]int)
+	for _, a := range animals {
+		breeds[a.Breed]++
+		colors[a.Color]++
+		ages[ageBucket(a.Birthdate, now)]++
+		weights[a.Breed] = append(weights[a.Breed], a.Weight)
+	}
+
+	stats.Breeds = groupCounts(breeds)
+	stats.Colors = groupCounts(colors)
+	for _, bucket := range ageBuckets {
+		bucket.Count = ages[bucket.Label]
+		stats.Ages = append(stats.Ages, bucket)
+	}
+
+	stats.Weights = make([]models.BreedWeight, 0, len(weights))
+	for breed, values := range weights {
+		sort.Ints(values)
+		n := len(values)
+		weight := models.BreedWeight{Breed: breed, Count: int64(n)}
+		sum := 0
+		for _, value := range values {
+			sum += value
+		}
+		weight.Mean = float64(sum) / float64(n)
+		weight.Median = float64(values[(n+1)/2-1]+values[(n+2)/2-1]) / 2
+		for _, percentile := range percentiles {
+			// The nearest rank is the first position, counting from 1,
+			// with position*100 >= n*percent.
+			rank := (n*percentile.percent + 99) / 100
+			if rank < 1 {
+				rank = 1
+			}
+			*percentile.value(&weight) = float64(values[rank-1])
+		}
+		stats.Weights = append(stats.Weights, weight)
+	}
+	sort.Slice(stats.Weights, func(i, j int) bool { return stats.Weights[i].Breed < stats.Weights[j].Breed })
+	return stats
+}
+
+func groupCounts(counts map[string]int64) []models.GroupCount {
+	groups := make([]models.GroupCount, 0, len(counts))
+	for value, count := range counts {
+		groups = append(groups, models.GroupCount{Value: value, Count: count})
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].Count != groups[j].Count {
+			return groups[i].Count > groups[j].Count
+		}
+		return groups[i].Value < groups[j].Value
+	})
+	return groups
+}
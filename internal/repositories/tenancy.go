@@ -0,0 +1,109 @@
+package repositories
+
+import (
+	"errors"
+	"reflect"
+
+	"github.com/one-byte-data/go-api-sample/internal/contexts"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// tenantField is the field of the models owned by a tenant.
+const tenantField = "TenantID"
+
+// TenancyPlugin scopes every statement on a model with a TenantID field to
+// the tenant of the statement's context: creates assign the rows to it and
+// queries, updates and deletes only match its rows, so a tenant can neither
+// read nor change the rows of another one. Raw SQL is not scoped.
+type TenancyPlugin struct{}
+
+func (p *TenancyPlugin) Name() string {
+	return "tenancy"
+}
+
+func (p *TenancyPlugin) Initialize(db *gorm.DB) error {
+	callbacks := []struct {
+		operation string
+		before    registerer
+		fn        func(*gorm.DB)
+	}{
+		{"create", db.Callback().Create().Before("gorm:create"), assignTenant},
+		{"query", db.Callback().Query().Before("gorm:query"), scopeTenant},
+		{"update", db.Callback().Update().Before("gorm:update"), scopeTenantUpdate},
+		{"delete", db.Callback().Delete().Before("gorm:delete"), scopeTenant},
+		{"row", db.Callback().Row().Before("gorm:row"), scopeTenant},
+	}
+
+	for _, callback := range callbacks {
+		if err := callback.before.Register("tenancy:"+callback.operation, callback.fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type registerer interface {
+	Register(name string, fn func(*gorm.DB)) error
+}
+
+// useTenancy installs the TenancyPlugin on db unless it already is. It
+// only fails when GORM cannot order the callbacks, which is a programming
+// error.
+func useTenancy(db *gorm.DB) *gorm.DB {
+	if err := db.Use(&TenancyPlugin{}); err != nil && !errors.Is(err, gorm.ErrRegistered) {
+		panic(err)
+	}
+	return db
+}
+
+// tenantOf returns the tenant field of the statement's model, or nil when
+// the model is not owned by a tenant.
+func tenantOf(db *gorm.DB) *schema.Field {
+	if db.Statement.Schema == nil {
+		return nil
+	}
+	return db.Statement.Schema.LookUpField(tenantField)
+}
+
+func assignTenant(db *gorm.DB) {
+	field := tenantOf(db)
+	if field == nil {
+		return
+	}
+
+	ctx := db.Statement.Context
+	tenant := contexts.Tenant(ctx)
+	value := db.Statement.ReflectValue
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			db.AddError(field.Set(ctx, reflect.Indirect(value.Index(i)), tenant))
+		}
+	case reflect.Struct:
+		db.AddError(field.Set(ctx, value, tenant))
+	}
+}
+
+func scopeTenant(db *gorm.DB) {
+	field := tenantOf(db)
+	if field == nil {
+		return
+	}
+
+	db.Statement.AddClause(clause.Where{Exprs: []clause.Expression{
+		clause.Eq{
+			Column: clause.Column{Table: clause.CurrentTable, Name: field.DBName},
+			Value:  contexts.Tenant(db.Statement.Context),
+		},
+	}})
+}
+
+// scopeTenantUpdate also keeps updates from moving rows to another tenant.
+func scopeTenantUpdate(db *gorm.DB) {
+	if field := tenantOf(db); field != nil {
+		db.Statement.Omits = append(db.Statement.Omits, field.DBName)
+	}
+	scopeTenant(db)
+}
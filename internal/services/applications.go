@@ -0,0 +1,279 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/one-byte-data/go-api-sample/internal/contexts"
+	"github.com/one-byte-data/go-api-sample/internal/models"
+	"github.com/one-byte-data/go-api-sample/internal/repositories"
+)
+
+// ApplicationsResource is the resource name adoption applications are
+// recorded under in the audit log.
+const ApplicationsResource = "applications"
+
+// ApplicationFilter narrows the applications returned by Get.
+type ApplicationFilter = repositories.ApplicationFilter
+
+// reviews is the review state machine: the statuses an application can
+// move to from each status. Approved and rejected applications are final.
+var reviews = map[string][]string{
+	models.ApplicationSubmitted:   {models.ApplicationUnderReview, models.ApplicationRejected},
+	models.ApplicationUnderReview: {models.ApplicationApproved, models.ApplicationRejected},
+}
+
+// CanReview reports whether an application can move from one status to
+// another.
+func CanReview(from, to string) bool {
+	for _, next := range reviews[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// openApplication reports whether an application with the status can still
+// be reviewed.
+func openApplication(status string) bool {
+	return len(reviews[status]) > 0
+}
+
+type ApplicationsService interface {
+	// Add submits an application. It returns ErrNotFound when the cat or
+	// dog does not exist and ErrConflict when it is already adopted.
+	Add(ctx context.Context, application *models.Application) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	Get(ctx context.Context, filter *ApplicationFilter) ([]models.Application, error)
+	GetOne(ctx context.Context, id uuid.UUID) (*models.Application, error)
+	// Update stores the applicant details and fills application with the
+	// updated row. It returns ErrConflict once the application is approved
+	// or rejected.
+	Update(ctx context.Context, id uuid.UUID, application *models.Application) error
+	// Review moves the application to the status, see CanReview, and
+	// returns the updated row. Approving it also marks the cat or dog
+	// adopted and rejects the other open applications for it, all in one
+	// transaction.
+	Review(ctx context.Context, id uuid.UUID, status string) (*models.Application, error)
+}
+
+type applicationsService struct {
+	repo repositories.Repository
+}
+
+func NewApplicationsService(repo repositories.Repository) ApplicationsService {
+	return &applicationsService{
+		repo: repo,
+	}
+}
+
+func (s *applicationsService) Add(ctx context.Context, application *models.Application) error {
+	if err := validate(ctx, application); err != nil {
+		return err
+	}
+	if application.ID == uuid.Nil {
+		application.ID = uuid.New()
+	}
+	application.Status = models.ApplicationSubmitted
+	application.Reviewer = ""
+	application.CreatedAt = time.Now().UTC()
+	application.UpdatedAt = application.CreatedAt
+
+	return s.repo.Transaction(ctx, func(tx repositories.Repository) error {
+		_, err := tx.Applications().Get(ctx, application.ID)
+		switch {
+		case err == nil:
+			return fmt.Errorf("application with id=%v already exists: %w", application.ID, ErrConflict)
+		case !errors.Is(err, ErrNotFound):
+			return err
+		}
+
+		status, err := animalStatus(ctx, tx, application.Species, application.AnimalID)
+		if err != nil {
+			return err
+		}
+		if status == models.StatusAdopted {
+			return fmt.Errorf("%s with id=%v is already adopted: %w", application.Species, application.AnimalID, ErrConflict)
+		}
+
+		if err := tx.Applications().Create(ctx, application); err != nil {
+			return err
+		}
+		return recordAudit(ctx, tx.Audit(), ApplicationsResource, application.ID, models.AuditActionCreate, nil, application)
+	})
+}
+
+func (s *applicationsService) Delete(ctx context.Context, id uuid.UUID) error {
+	return s.repo.Transaction(ctx, func(tx repositories.Repository) error {
+		before, err := tx.Applications().Get(ctx, id)
+		if err != nil {
+			if errors.Is(err, ErrNotFound) {
+				return fmt.Errorf("application with id=%v cannot be deleted because it doesn't exist: %w", id, ErrNotFound)
+			}
+			return err
+		}
+
+		if err := tx.Applications().Delete(ctx, id); err != nil {
+			return err
+		}
+		return recordAudit(ctx, tx.Audit(), ApplicationsResource, id, models.AuditActionDelete, before, nil)
+	})
+}
+
+func (s *applicationsService) Get(ctx context.Context, filter *ApplicationFilter) ([]models.Application, error) {
+	return s.repo.Applications().Find(ctx, filter)
+}
+
+func (s *applicationsService) GetOne(ctx context.Context, id uuid.UUID) (*models.Application, error) {
+	return s.repo.Applications().Get(ctx, id)
+}
+
+func (s *applicationsService) Update(ctx context.Context, id uuid.UUID, application *models.Application) error {
+	if err := validate(ctx, application); err != nil {
+		return err
+	}
+
+	return s.repo.Transaction(ctx, func(tx repositories.Repository) error {
+		before, err := tx.Applications().Get(ctx, id)
+		if err != nil {
+			if errors.Is(err, ErrNotFound) {
+				return fmt.Errorf("application with id=%v cannot be updated because it doesn't exist: %w", id, ErrNotFound)
+			}
+			return err
+		}
+		if !openApplication(before.Status) {
+			return fmt.Errorf("application with id=%v cannot be updated because it is %s: %w", id, before.Status, ErrConflict)
+		}
+
+		err = tx.Applications().Update(ctx, id, &models.Application{
+			ApplicantName:  application.ApplicantName,
+			ApplicantEmail: application.ApplicantEmail,
+			ApplicantPhone: application.ApplicantPhone,
+			Notes:          application.Notes,
+			UpdatedAt:      time.Now().UTC(),
+		})
+		if err != nil {
+			return err
+		}
+
+		after, err := tx.Applications().Get(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := recordAudit(ctx, tx.Audit(), ApplicationsResource, id, models.AuditActionUpdate, before, after); err != nil {
+			return err
+		}
+		*application = *after
+		return nil
+	})
+}
+
+func (s *applicationsService) Review(ctx context.Context, id uuid.UUID, status string) (*models.Application, error) {
+	var reviewed *models.Application
+	err := s.repo.Transaction(ctx, func(tx repositories.Repository) error {
+		before, err := tx.Applications().Get(ctx, id)
+		if err != nil {
+			if errors.Is(err, ErrNotFound) {
+				return fmt.Errorf("application with id=%v cannot be reviewed because it doesn't exist: %w", id, ErrNotFound)
+			}
+			return err
+		}
+		if !CanReview(before.Status, status) {
+			return fmt.Errorf("application with id=%v cannot change status from %s to %s: %w", id, before.Status, status, ErrInvalidTransition)
+		}
+
+		if reviewed, err = setApplicationStatus(ctx, tx, before, status); err != nil {
+			return err
+		}
+		if status != models.ApplicationApproved {
+			return nil
+		}
+
+		if err := adopt(ctx, tx, before.Species, before.AnimalID); err != nil {
+			return err
+		}
+		competing, err := tx.Applications().Find(ctx, &ApplicationFilter{Species: before.Species, AnimalID: &before.AnimalID})
+		if err != nil {
+			return err
+		}
+		for i := range competing {
+			other := &competing[i]
+			if other.ID == id || !openApplication(other.Status) {
+				continue
+			}
+			if _, err := setApplicationStatus(ctx, tx, other, models.ApplicationRejected); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reviewed, nil
+}
+
+// setApplicationStatus stores the status of the application reviewed by the
+// actor of ctx and records an audit entry. It must be called with a
+// transaction and returns ErrConflict when another transaction reviewed the
+// application first.
+func setApplicationStatus(ctx context.Context, tx repositories.Repository, before *models.Application, status string) (*models.Application, error) {
+	err := tx.Applications().SetStatus(ctx, before.ID, before.Status, &models.Application{
+		Status:    status,
+		Reviewer:  contexts.Actor(ctx),
+		UpdatedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, fmt.Errorf("application with id=%v was reviewed concurrently: %w", before.ID, ErrConflict)
+		}
+		return nil, err
+	}
+
+	after, err := tx.Applications().Get(ctx, before.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := recordAudit(ctx, tx.Audit(), ApplicationsResource, before.ID, models.AuditActionUpdate, before, after); err != nil {
+		return nil, err
+	}
+	return after, nil
+}
+
+// animalStatus returns the status of the cat or dog an application is for.
+func animalStatus(ctx context.Context, tx repositories.Repository, species string, id uuid.UUID) (string, error) {
+	switch species {
+	case CatsResource:
+		cat, err := tx.Cats().Get(ctx, id)
+		if err != nil {
+			return "", err
+		}
+		return cat.Status, nil
+	case DogsResource:
+		dog, err := tx.Dogs().Get(ctx, id)
+		if err != nil {
+			return "", err
+		}
+		return dog.Status, nil
+	}
+	return "", fmt.Errorf("species %q does not exist: %w", species, ErrNotFound)
+}
+
+// adopt moves the cat or dog an application is for to adopted. It must be
+// called with a transaction.
+func adopt(ctx context.Context, tx repositories.Repository, species string, id uuid.UUID) error {
+	var err error
+	switch species {
+	case CatsResource:
+		_, err = changeStatus(ctx, tx, tx.Cats(), CatsResource, id, func(cat models.Cat) string { return cat.Status }, models.StatusAdopted)
+	case DogsResource:
+		_, err = changeStatus(ctx, tx, tx.Dogs(), DogsResource, id, func(dog models.Dog) string { return dog.Status }, models.StatusAdopted)
+	default:
+		err = fmt.Errorf("species %q does not exist: %w", species, ErrNotFound)
+	}
+	return err
+}
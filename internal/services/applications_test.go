@@ -0,0 +1,199 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/one-byte-data/go-api-sample/internal/contexts"
+	"github.com/one-byte-data/go-api-sample/internal/models"
+	"github.com/one-byte-data/go-api-sample/internal/repositories"
+)
+
+func TestCanReview(t *testing.T) {
+	tests := []struct {
+		from string
+		to   string
+		want bool
+	}{
+		{from: models.ApplicationSubmitted, to: models.ApplicationUnderReview, want: true},
+		{from: models.ApplicationSubmitted, to: models.ApplicationRejected, want: true},
+		{from: models.ApplicationSubmitted, to: models.ApplicationApproved, want: false},
+		{from: models.ApplicationUnderReview, to: models.ApplicationApproved, want: true},
+		{from: models.ApplicationApproved, to: models.ApplicationRejected, want: false},
+		{from: models.ApplicationRejected, to: models.ApplicationUnderReview, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.from+" to "+tt.to, func(t *testing.T) {
+			if got := CanReview(tt.from, tt.to); got != tt.want {
+				t.Errorf("CanReview() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_applicationsService_Review(t *testing.T) {
+	ctx := contexts.WithActor(context.Background(), "alice")
+	repo := repositories.NewMemory()
+	cats := NewCatsService(repo)
+	s := NewApplicationsService(repo)
+
+	cat := &models.Cat{ID: uuid.New(), Name: "Nacho", Breed: "Tabby", Color: "Orange", Birthdate: time.Now().AddDate(-3, 0, 0), Weight: 10}
+	if _, err := cats.Add(ctx, cat); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if _, err := cats.Transition(ctx, cat.ID, models.StatusAvailable); err != nil {
+		t.Fatalf("Transition() error = %v", err)
+	}
+
+	apply := func(name string) *models.Application {
+		application := &models.Application{Species: CatsResource, AnimalID: cat.ID, ApplicantName: name, ApplicantEmail: "adopter@example.com"}
+		if err := s.Add(ctx, application); err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+		return application
+	}
+	first, second, third := apply("Bob"), apply("Carol"), apply("Dave")
+	if first.Status != models.ApplicationSubmitted {
+		t.Fatalf("Add() status = %q, want %q", first.Status, models.ApplicationSubmitted)
+	}
+	if _, err := s.Review(ctx, third.ID, models.ApplicationRejected); err != nil {
+		t.Fatalf("Review() error = %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		id         uuid.UUID
+		status     string
+		wantErr    error
+		wantStatus string
+	}{
+		{
+			name:    "Should not approve an application before it is reviewed",
+			id:      first.ID,
+			status:  models.ApplicationApproved,
+			wantErr: ErrInvalidTransition,
+		},
+		{
+			name:       "Should put the application under review",
+			id:         first.ID,
+			status:     models.ApplicationUnderReview,
+			wantStatus: models.ApplicationUnderReview,
+		},
+		{
+			name:       "Should approve the application",
+			id:         first.ID,
+			status:     models.ApplicationApproved,
+			wantStatus: models.ApplicationApproved,
+		},
+		{
+			name:    "Should not review an approved application",
+			id:      first.ID,
+			status:  models.ApplicationRejected,
+			wantErr: ErrInvalidTransition,
+		},
+		{
+			name:    "Should not review an application that does not exist",
+			id:      uuid.New(),
+			status:  models.ApplicationUnderReview,
+			wantErr: ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.Review(ctx, tt.id, tt.status)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Review() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && (got.Status != tt.wantStatus || got.Reviewer != "alice") {
+				t.Errorf("Review() = %+v, want status %s reviewed by alice", got, tt.wantStatus)
+			}
+		})
+	}
+
+	adopted, err := cats.GetOne(ctx, cat.ID)
+	if err != nil {
+		t.Fatalf("GetOne() error = %v", err)
+	}
+	if adopted.Status != models.StatusAdopted {
+		t.Errorf("GetOne() status = %q, want the approval to adopt the cat", adopted.Status)
+	}
+	if got, err := s.GetOne(ctx, second.ID); err != nil || got.Status != models.ApplicationRejected {
+		t.Errorf("GetOne() = %+v, %v, want the competing application rejected", got, err)
+	}
+	if history, err := NewAuditService(repo).History(ctx, ApplicationsResource, third.ID); err != nil || len(history) != 2 {
+		t.Errorf("History() = %+v, %v, want the already rejected application left alone", history, err)
+	}
+
+	if err := s.Add(ctx, &models.Application{Species: CatsResource, AnimalID: cat.ID, ApplicantName: "Erin", ApplicantEmail: "erin@example.com"}); !errors.Is(err, ErrConflict) {
+		t.Errorf("Add() for an adopted cat error = %v, want %v", err, ErrConflict)
+	}
+	if err := s.Update(ctx, second.ID, &models.Application{Species: CatsResource, AnimalID: cat.ID, ApplicantName: "Carol", ApplicantEmail: "carol@example.com"}); !errors.Is(err, ErrConflict) {
+		t.Errorf("Update() of a rejected application error = %v, want %v", err, ErrConflict)
+	}
+}
+
+func Test_applicationsService_Review_rollback(t *testing.T) {
+	ctx := context.Background()
+	repo := repositories.NewMemory()
+	s := NewApplicationsService(repo)
+
+	// The cat is still at intake, which cannot move to adopted.
+	cat := &models.Cat{ID: uuid.New(), Name: "Nacho", Breed: "Tabby", Color: "Orange", Birthdate: time.Now().AddDate(-3, 0, 0), Weight: 10}
+	if _, err := NewCatsService(repo).Add(ctx, cat); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	application := &models.Application{Species: CatsResource, AnimalID: cat.ID, ApplicantName: "Bob", ApplicantEmail: "bob@example.com"}
+	if err := s.Add(ctx, application); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if _, err := s.Review(ctx, application.ID, models.ApplicationUnderReview); err != nil {
+		t.Fatalf("Review() error = %v", err)
+	}
+
+	if _, err := s.Review(ctx, application.ID, models.ApplicationApproved); !errors.Is(err, ErrInvalidTransition) {
+		t.Fatalf("Review() error = %v, want %v", err, ErrInvalidTransition)
+	}
+	got, err := s.GetOne(ctx, application.ID)
+	if err != nil {
+		t.Fatalf("GetOne() error = %v", err)
+	}
+	if got.Status != models.ApplicationUnderReview {
+		t.Errorf("GetOne() status = %q, want the approval rolled back", got.Status)
+	}
+}
+
+func Test_applicationsService_Add(t *testing.T) {
+	s := NewApplicationsService(repositories.NewMemory())
+
+	tests := []struct {
+		name        string
+		application *models.Application
+		wantErr     error
+	}{
+		{
+			name:        "Should not apply for a cat that does not exist",
+			application: &models.Application{Species: CatsResource, AnimalID: uuid.New(), ApplicantName: "Bob", ApplicantEmail: "bob@example.com"},
+			wantErr:     ErrNotFound,
+		},
+		{
+			name:        "Should reject an invalid email address",
+			application: &models.Application{Species: CatsResource, AnimalID: uuid.New(), ApplicantName: "Bob", ApplicantEmail: "bob"},
+			wantErr:     ErrInvalid,
+		},
+		{
+			name:        "Should reject an unknown species",
+			application: &models.Application{Species: "birds", AnimalID: uuid.New(), ApplicantName: "Bob", ApplicantEmail: "bob@example.com"},
+			wantErr:     ErrInvalid,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := s.Add(context.Background(), tt.application); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Add() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"reflect"
+	"time"
+
+	"github.com/one-byte-data/go-api-sample/internal/contexts"
+	"github.com/one-byte-data/go-api-sample/internal/models"
+	"github.com/one-byte-data/go-api-sample/internal/repositories"
+
+	"github.com/google/uuid"
+)
+
+type AuditFilter = repositories.AuditFilter
+
+type AuditService interface {
+	Get(ctx context.Context, filter *AuditFilter) ([]models.AuditEntry, error)
+	History(ctx context.Context, resource string, id uuid.UUID) ([]models.AuditEntry, error)
+}
+
+type auditService struct {
+	repo repositories.Repository
+}
+
+func NewAuditService(repo repositories.Repository) AuditService {
+	return &auditService{
+		repo: repo,
+	}
+}
+
+func (s *auditService) Get(ctx context.Context, filter *AuditFilter) ([]models.AuditEntry, error) {
+	return s.repo.Audit().Find(ctx, filter)
+}
+
+func (s *auditService) History(ctx context.Context, resource string, id uuid.UUID) ([]models.AuditEntry, error) {
+	return s.Get(ctx, &AuditFilter{Resource: resource, ResourceID: &id})
+}
+
+// recordAudit appends an audit entry for a mutation. It must be called with
+// the transaction that performed the mutation so both commit or roll back
+// together. before is nil for creates and after is nil for deletes.
+func recordAudit(ctx context.Context, tx repositories.AuditRepository, resource string, id uuid.UUID, action string, before, after interface{}) error {
+	changes, err := diff(before, after)
+	if err != nil {
+		return err
+	}
+
+	entry := &models.AuditEntry{
+		ID:         uuid.New(),
+		Resource:   resource,
+		ResourceID: id,
+		Action:     action,
+		Actor:      contexts.Actor(ctx),
+		RequestID:  contexts.RequestID(ctx),
+		Timestamp:  time.Now().UTC(),
+		Changes:    changes,
+	}
+	if err := tx.Create(ctx, entry); err != nil {
+		return err
+	}
+
+	slog.DebugContext(ctx, "recorded audit entry", "resource", resource, "resource_id", id, "action", action, "actor", entry.Actor)
+	return nil
+}
+
+type fieldChange struct {
+	Old interface{} `json:"old"`
+	New interface{} `json:"new"`
+}
+
+// diff compares the JSON representation of two values and returns the fields
+// whose values differ.
+func diff(before, after interface{}) (json.RawMessage, error) {
+	oldFields, err := toFields(before)
+	if err != nil {
+		return nil, err
+	}
+	newFields, err := toFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(map[string]fieldChange)
+	for key, value := range oldFields {
+		if !reflect.DeepEqual(value, newFields[key]) {
+			changes[key] = fieldChange{Old: value, New: newFields[key]}
+		}
+	}
+	for key, value := range newFields {
+		if _, ok := oldFields[key]; !ok {
+			changes[key] = fieldChange{New: value}
+		}
+	}
+
+	return json.Marshal(changes)
+}
+
+func toFields(v interface{}) (map[string]interface{}, error) {
+	fields := make(map[string]interface{})
+	if v == nil {
+		return fields, nil
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
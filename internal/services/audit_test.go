@@ -0,0 +1,201 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/one-byte-data/go-api-sample/cmd/tests"
+	"github.com/one-byte-data/go-api-sample/internal/contexts"
+	"github.com/one-byte-data/go-api-sample/internal/models"
+	"github.com/one-byte-data/go-api-sample/internal/repositories"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func Test_diff(t *testing.T) {
+	type args struct {
+		before interface{}
+		after  interface{}
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    map[string]fieldChange
+		wantErr bool
+	}{
+		{
+			name: "Should record every field on create",
+			args: args{
+				before: nil,
+				after:  map[string]interface{}{"name": "Nacho"},
+			},
+			want: map[string]fieldChange{
+				"name": {Old: nil, New: "Nacho"},
+			},
+		},
+		{
+			name: "Should only record changed fields on update",
+			args: args{
+				before: map[string]interface{}{"name": "Nacho", "color": "Orange"},
+				after:  map[string]interface{}{"name": "Nacho", "color": "Black"},
+			},
+			want: map[string]fieldChange{
+				"color": {Old: "Orange", New: "Black"},
+			},
+		},
+		{
+			name: "Should record every field on delete",
+			args: args{
+				before: map[string]interface{}{"name": "Nacho"},
+				after:  nil,
+			},
+			want: map[string]fieldChange{
+				"name": {Old: "Nacho", New: nil},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := diff(tt.args.before, tt.args.after)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("diff() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			changes := make(map[string]fieldChange)
+			if err := json.Unmarshal(got, &changes); err != nil {
+				t.Errorf("diff() unable to unmarshal %s", got)
+				return
+			}
+			if !reflect.DeepEqual(changes, tt.want) {
+				t.Errorf("diff() = %v, want %v", changes, tt.want)
+			}
+		})
+	}
+}
+
+func Test_auditService_Get(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	gdb, err := gorm.Open(postgres.Dialector{
+		Config: &postgres.Config{Conn: db},
+	})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	id := uuid.New()
+
+	type args struct {
+		ctx    context.Context
+		filter *AuditFilter
+	}
+	tests := []struct {
+		name      string
+		args      args
+		wantQuery string
+		wantArgs  int
+		wantErr   bool
+	}{
+		{
+			name:      "Get all audit entries",
+			args:      args{ctx: context.Background(), filter: nil},
+			wantQuery: `SELECT \* FROM "audit_entries" WHERE "audit_entries"."tenant_id" = \$1 ORDER BY timestamp`,
+			wantArgs:  1,
+		},
+		{
+			name: "Get audit entries for a resource and actor",
+			args: args{ctx: context.Background(), filter: &AuditFilter{
+				Resource:   CatsResource,
+				ResourceID: &id,
+				Actor:      "jdoe",
+			}},
+			wantQuery: `SELECT \* FROM "audit_entries" WHERE resource = \$1 AND resource_id = \$2 AND actor = \$3 AND "audit_entries"."tenant_id" = \$4 ORDER BY timestamp`,
+			wantArgs:  4,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock.ExpectQuery(tt.wantQuery).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			s := &auditService{
+				repo: repositories.NewGorm(gdb),
+			}
+			got, err := s.Get(tt.args.ctx, tt.args.filter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("auditService.Get() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if len(got) != 0 {
+				t.Errorf("auditService.Get() = %v, want empty", got)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("auditService.Get() %v", err)
+			}
+		})
+	}
+}
+
+func TestIntegration_auditService_History(t *testing.T) {
+	if m := flag.Lookup("test.run").Value.String(); m == "" || !regexp.MustCompile(m).MatchString(t.Name()) {
+		t.Skip("skipping as execution was not requested explicitly using go test -run")
+	}
+
+	teardownTests := tests.SetupTests(t, postgres.Open(tests.ConnectionString))
+	defer teardownTests(t)
+
+	ctx := contexts.WithActor(context.Background(), "jdoe")
+	catsService := NewCatsService(repositories.NewGorm(tests.DB))
+	auditService := NewAuditService(repositories.NewGorm(tests.DB))
+
+	cat := &models.Cat{
+		ID:        uuid.New(),
+		Name:      "Nacho",
+		Breed:     "Tabby",
+		Color:     "Orange",
+		Birthdate: time.Date(2020, 2, 10, 0, 0, 0, 0, time.UTC),
+		Weight:    17,
+	}
+	if _, err := catsService.Add(ctx, cat); err != nil {
+		t.Fatalf("catsService.Add() error = %v", err)
+	}
+	if err := catsService.Update(ctx, cat.ID, &models.Cat{Color: "Black"}); err != nil {
+		t.Fatalf("catsService.Update() error = %v", err)
+	}
+	if err := catsService.Delete(ctx, cat.ID); err != nil {
+		t.Fatalf("catsService.Delete() error = %v", err)
+	}
+
+	got, err := auditService.History(ctx, CatsResource, cat.ID)
+	if err != nil {
+		t.Fatalf("auditService.History() error = %v", err)
+	}
+
+	wantActions := []string{models.AuditActionCreate, models.AuditActionUpdate, models.AuditActionDelete}
+	if len(got) != len(wantActions) {
+		t.Fatalf("auditService.History() = %v entries, want %v", len(got), len(wantActions))
+	}
+	for i, entry := range got {
+		if entry.Action != wantActions[i] || entry.Actor != "jdoe" {
+			t.Errorf("auditService.History()[%d] = %v/%v, want %v/jdoe", i, entry.Action, entry.Actor, wantActions[i])
+		}
+	}
+
+	changes := make(map[string]fieldChange)
+	if err := json.Unmarshal(got[1].Changes, &changes); err != nil {
+		t.Fatalf("auditService.History() unable to unmarshal %s", got[1].Changes)
+	}
+	if want := (fieldChange{Old: "Orange", New: "Black"}); !reflect.DeepEqual(changes["color"], want) || len(changes) != 1 {
+		t.Errorf("auditService.History() changes = %v, want color %v", changes, want)
+	}
+}
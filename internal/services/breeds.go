@@ -0,0 +1,213 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/one-byte-data/go-api-sample/internal/models"
+	"github.com/one-byte-data/go-api-sample/internal/repositories"
+	"github.com/one-byte-data/go-api-sample/internal/validation"
+)
+
+// mixSuffix marks a mix of a known breed, e.g. Pitbull Mix.
+const mixSuffix = " mix"
+
+// maxBreedLength is the longest breed the bindings of cats and dogs accept.
+const maxBreedLength = 24
+
+// BreedsService manages the breed catalogue of each species, cats or dogs.
+// Every method returns ErrNotFound for other species.
+type BreedsService interface {
+	// Add returns ErrConflict when the name or an alias already names
+	// another breed of the species.
+	Add(ctx context.Context, breed *models.Breed) error
+	Delete(ctx context.Context, species string, id uuid.UUID) error
+	Get(ctx context.Context, species string) ([]models.Breed, error)
+	GetOne(ctx context.Context, species string, id uuid.UUID) (*models.Breed, error)
+	// Update replaces the breed like Add and fills breed with the stored
+	// row. Cats and dogs keep the breed name they were stored with.
+	Update(ctx context.Context, species string, id uuid.UUID, breed *models.Breed) error
+}
+
+type breedsService struct {
+	repo repositories.Repository
+}
+
+func NewBreedsService(repo repositories.Repository) BreedsService {
+	return &breedsService{
+		repo: repo,
+	}
+}
+
+func (s *breedsService) Add(ctx context.Context, breed *models.Breed) error {
+	if err := checkSpecies(breed.Species); err != nil {
+		return err
+	}
+	if breed.ID == uuid.Nil {
+		breed.ID = uuid.New()
+	}
+	if breed.Aliases == nil {
+		breed.Aliases = []string{}
+	}
+
+	return s.repo.Transaction(ctx, func(tx repositories.Repository) error {
+		_, err := tx.Breeds().Get(ctx, breed.ID)
+		switch {
+		case err == nil:
+			return fmt.Errorf("breed with id=%v already exists: %w", breed.ID, ErrConflict)
+		case !errors.Is(err, ErrNotFound):
+			return err
+		}
+		if err := checkBreedNames(ctx, tx, breed, uuid.Nil); err != nil {
+			return err
+		}
+		return tx.Breeds().Create(ctx, breed)
+	})
+}
+
+func (s *breedsService) Delete(ctx context.Context, species string, id uuid.UUID) error {
+	return s.repo.Transaction(ctx, func(tx repositories.Repository) error {
+		if _, err := getBreed(ctx, tx, species, id); err != nil {
+			return err
+		}
+		return tx.Breeds().Delete(ctx, id)
+	})
+}
+
+func (s *breedsService) Get(ctx context.Context, species string) ([]models.Breed, error) {
+	if err := checkSpecies(species); err != nil {
+		return nil, err
+	}
+	return s.repo.Breeds().Find(ctx, species)
+}
+
+func (s *breedsService) GetOne(ctx context.Context, species string, id uuid.UUID) (*models.Breed, error) {
+	return getBreed(ctx, s.repo, species, id)
+}
+
+func (s *breedsService) Update(ctx context.Context, species string, id uuid.UUID, breed *models.Breed) error {
+	return s.repo.Transaction(ctx, func(tx repositories.Repository) error {
+		if _, err := getBreed(ctx, tx, species, id); err != nil {
+			return err
+		}
+		breed.ID = id
+		breed.Species = species
+		if breed.Aliases == nil {
+			breed.Aliases = []string{}
+		}
+		if err := checkBreedNames(ctx, tx, breed, id); err != nil {
+			return err
+		}
+		if err := tx.Breeds().Update(ctx, id, breed); err != nil {
+			return err
+		}
+
+		after, err := tx.Breeds().Get(ctx, id)
+		if err != nil {
+			return err
+		}
+		*breed = *after
+		return nil
+	})
+}
+
+func checkSpecies(species string) error {
+	if species != validation.Cats && species != validation.Dogs {
+		return fmt.Errorf("there is no breed catalogue for %q: %w", species, ErrNotFound)
+	}
+	return nil
+}
+
+// getBreed returns ErrNotFound for breeds of another species too.
+func getBreed(ctx context.Context, repo repositories.Repository, species string, id uuid.UUID) (*models.Breed, error) {
+	if err := checkSpecies(species); err != nil {
+		return nil, err
+	}
+	breed, err := repo.Breeds().Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if breed.Species != species {
+		return nil, fmt.Errorf("breed with id=%v is not a breed of %s: %w", id, species, ErrNotFound)
+	}
+	return breed, nil
+}
+
+// checkBreedNames returns ErrConflict when the name or an alias of breed
+// names a breed of its species other than the one with the ID self.
+func checkBreedNames(ctx context.Context, repo repositories.Repository, breed *models.Breed, self uuid.UUID) error {
+	breeds, err := repo.Breeds().Find(ctx, breed.Species)
+	if err != nil {
+		return err
+	}
+	for _, name := range append([]string{breed.Name}, breed.Aliases...) {
+		if other := findBreed(breeds, name); other != nil && other.ID != self {
+			return fmt.Errorf("%q already names the breed %q: %w", name, other.Name, ErrConflict)
+		}
+	}
+	return nil
+}
+
+// findBreed returns the breed named name or one of its aliases, ignoring
+// case, or nil.
+func findBreed(breeds []models.Breed, name string) *models.Breed {
+	key := strings.ToLower(strings.TrimSpace(name))
+	for i := range breeds {
+		if strings.ToLower(breeds[i].Name) == key {
+			return &breeds[i]
+		}
+		for _, alias := range breeds[i].Aliases {
+			if strings.ToLower(alias) == key {
+				return &breeds[i]
+			}
+		}
+	}
+	return nil
+}
+
+// matchBreed is findBreed that also matches a known breed followed by Mix,
+// returning a breed named after the canonical one without a weight range.
+// When the canonical name is too long to be followed by Mix, the mix keeps
+// the name it was given.
+func matchBreed(breeds []models.Breed, name string) *models.Breed {
+	if breed := findBreed(breeds, name); breed != nil {
+		return breed
+	}
+	base, ok := strings.CutSuffix(strings.ToLower(strings.TrimSpace(name)), mixSuffix)
+	if !ok {
+		return nil
+	}
+	if breed := findBreed(breeds, base); breed != nil {
+		mix := breed.Name + " Mix"
+		if len(mix) > maxBreedLength {
+			mix = strings.TrimSpace(name)
+		}
+		return &models.Breed{Species: breed.Species, Name: mix}
+	}
+	return nil
+}
+
+// normalize stores *breed by its canonical name in the catalogue of
+// species, sets *status to whether the catalogue knows it and validates
+// item. Unknown breeds are only rejected in strict mode.
+func normalize(ctx context.Context, repo repositories.Repository, species string, breed *string, status *string, item interface{}) error {
+	if *breed != "" {
+		breeds, err := repo.Breeds().Find(ctx, species)
+		if err != nil {
+			return err
+		}
+
+		known := matchBreed(breeds, *breed)
+		if known != nil {
+			*breed = known.Name
+			*status = models.BreedKnown
+		} else {
+			*status = models.BreedUnknown
+		}
+		ctx = validation.WithBreed(ctx, known)
+	}
+	return validate(ctx, item)
+}
@@ -0,0 +1,199 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/one-byte-data/go-api-sample/internal/models"
+	"github.com/one-byte-data/go-api-sample/internal/repositories"
+	"github.com/one-byte-data/go-api-sample/internal/validation"
+)
+
+// expectBreeds expects the services to look up the breed catalogue.
+func expectBreeds(mock sqlmock.Sqlmock, breeds ...models.Breed) {
+	rows := sqlmock.NewRows([]string{"id", "species", "name", "aliases", "min_weight", "max_weight"})
+	for _, breed := range breeds {
+		rows.AddRow(uuid.New(), validation.Cats, breed.Name, "[]", breed.MinWeight, breed.MaxWeight)
+	}
+	mock.ExpectQuery(`SELECT \* FROM "breeds"`).WillReturnRows(rows)
+}
+
+func Test_breedsService(t *testing.T) {
+	ctx := context.Background()
+	s := NewBreedsService(repositories.NewMemory())
+
+	savannah := &models.Breed{Species: validation.Cats, Name: "Savannah", Aliases: []string{"Savanna"}, MinWeight: 12, MaxWeight: 25}
+	if err := s.Add(ctx, savannah); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		call    func() error
+		wantErr error
+	}{
+		{
+			name:    "Should not add a breed to another species",
+			call:    func() error { return s.Add(ctx, &models.Breed{Species: "birds", Name: "Parrot"}) },
+			wantErr: ErrNotFound,
+		},
+		{
+			name:    "Should not add a breed twice regardless of case",
+			call:    func() error { return s.Add(ctx, &models.Breed{Species: validation.Cats, Name: "savannah"}) },
+			wantErr: ErrConflict,
+		},
+		{
+			name:    "Should not add a breed named like an alias",
+			call:    func() error { return s.Add(ctx, &models.Breed{Species: validation.Cats, Name: "Savanna"}) },
+			wantErr: ErrConflict,
+		},
+		{
+			name:    "Should add a breed of the same name to another species",
+			call:    func() error { return s.Add(ctx, &models.Breed{Species: validation.Dogs, Name: "Savannah"}) },
+			wantErr: nil,
+		},
+		{
+			name:    "Should not get the breed of another species",
+			call:    func() error { _, err := s.GetOne(ctx, validation.Dogs, savannah.ID); return err },
+			wantErr: ErrNotFound,
+		},
+		{
+			name: "Should not update a breed to an alias of another one",
+			call: func() error {
+				return s.Update(ctx, validation.Cats, savannah.ID, &models.Breed{Name: "Savannah", Aliases: []string{"DSH"}})
+			},
+			wantErr: ErrConflict,
+		},
+		{
+			name: "Should update a breed keeping its own names",
+			call: func() error {
+				return s.Update(ctx, validation.Cats, savannah.ID, &models.Breed{Name: "Savannah", Aliases: []string{"Savanna", "Savvy"}, MaxWeight: 30})
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "Should delete a breed",
+			call:    func() error { return s.Delete(ctx, validation.Cats, savannah.ID) },
+			wantErr: nil,
+		},
+		{
+			name:    "Should not delete a missing breed",
+			call:    func() error { return s.Delete(ctx, validation.Cats, savannah.ID) },
+			wantErr: ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_normalize(t *testing.T) {
+	ctx := context.Background()
+	lenient := validation.Current()
+	strict := *lenient
+	strict.StrictBreeds = true
+	t.Cleanup(func() { validation.Use(lenient) })
+
+	tests := []struct {
+		name       string
+		rules      *validation.Rules
+		catalogue  []models.Breed
+		breed      string
+		weight     int
+		wantBreed  string
+		wantStatus string
+		wantErr    error
+	}{
+		{
+			name:       "Should store the canonical name",
+			rules:      &strict,
+			breed:      "maine coon",
+			weight:     15,
+			wantBreed:  "Maine Coon",
+			wantStatus: models.BreedKnown,
+		},
+		{
+			name:       "Should store the breed an alias names",
+			rules:      &strict,
+			breed:      "DSH",
+			weight:     10,
+			wantBreed:  "Domestic Shorthair",
+			wantStatus: models.BreedKnown,
+		},
+		{
+			name:       "Should store a mix of a known breed",
+			rules:      &strict,
+			breed:      "siamese mix",
+			weight:     30,
+			wantBreed:  "Siamese Mix",
+			wantStatus: models.BreedKnown,
+		},
+		{
+			name:       "Should keep the name of a mix too long to name after the canonical breed",
+			rules:      &strict,
+			catalogue:  []models.Breed{{ID: uuid.New(), Species: CatsResource, Name: "Exotic Shorthair Tabby", Aliases: []string{"Exotic Shorthair Tab"}}},
+			breed:      "Exotic Shorthair Tab Mix",
+			weight:     10,
+			wantBreed:  "Exotic Shorthair Tab Mix",
+			wantStatus: models.BreedKnown,
+		},
+		{
+			name:    "Should not accept a weight outside the range of the breed",
+			rules:   &strict,
+			breed:   "Siamese",
+			weight:  30,
+			wantErr: ErrInvalid,
+		},
+		{
+			name:    "Should reject an unknown breed in strict mode",
+			rules:   &strict,
+			breed:   "Unicorn",
+			weight:  10,
+			wantErr: ErrInvalid,
+		},
+		{
+			name:       "Should flag an unknown breed otherwise",
+			rules:      lenient,
+			breed:      "Unicorn",
+			weight:     10,
+			wantBreed:  "Unicorn",
+			wantStatus: models.BreedUnknown,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validation.Use(tt.rules)
+			repo := repositories.NewMemory()
+			for i := range tt.catalogue {
+				if err := repo.Breeds().Create(ctx, &tt.catalogue[i]); err != nil {
+					t.Fatalf("Breeds().Create() error = %v", err)
+				}
+			}
+			s := NewCatsService(repo)
+			cat := &models.Cat{ID: uuid.New(), Name: "Nacho", Breed: tt.breed, Color: "Orange", Birthdate: time.Now().AddDate(-3, 0, 0), Weight: tt.weight}
+
+			_, err := s.Add(ctx, cat)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Add() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			got, err := s.GetOne(ctx, cat.ID)
+			if err != nil {
+				t.Fatalf("GetOne() error = %v", err)
+			}
+			if got.Breed != tt.wantBreed || got.BreedStatus != tt.wantStatus {
+				t.Errorf("Add() stored %q (%s), want %q (%s)", got.Breed, got.BreedStatus, tt.wantBreed, tt.wantStatus)
+			}
+		})
+	}
+}
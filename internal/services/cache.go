@@ -0,0 +1,318 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+
+	"github.com/one-byte-data/go-api-sample/internal/cache"
+	"github.com/one-byte-data/go-api-sample/internal/contexts"
+	"github.com/one-byte-data/go-api-sample/internal/models"
+
+	"github.com/google/uuid"
+)
+
+type cachedCatsService struct {
+	next  CatsService
+	cats  *cache.LRU[uuid.UUID, models.Cat]
+	lists *cache.LRU[string, []models.Cat]
+}
+
+// NewCachedCatsService wraps a CatsService with a read-through cache. Add,
+// Update, Transition and Delete invalidate the cached cat and every cached list. Reads
+// asking to read your writes bypass the cache, and cached cats and lists
+// are only returned to their tenant.
+func NewCachedCatsService(next CatsService, cats *cache.LRU[uuid.UUID, models.Cat], lists *cache.LRU[string, []models.Cat]) CatsService {
+	return &cachedCatsService{
+		next:  next,
+		cats:  cats,
+		lists: lists,
+	}
+}
+
+func (s *cachedCatsService) Add(ctx context.Context, cat *models.Cat) (*uuid.UUID, error) {
+	id, err := s.next.Add(ctx, cat)
+	s.lists.Purge()
+	return id, err
+}
+
+// Count is not cached.
+func (s *cachedCatsService) Count(ctx context.Context, filter interface{}) (int64, error) {
+	return s.next.Count(ctx, filter)
+}
+
+func (s *cachedCatsService) Delete(ctx context.Context, id uuid.UUID) error {
+	err := s.next.Delete(ctx, id)
+	s.cats.Delete(id)
+	s.lists.Purge()
+	return err
+}
+
+func (s *cachedCatsService) Get(ctx context.Context, filter interface{}) ([]models.Cat, error) {
+	if contexts.ReadYourWrites(ctx) {
+		return s.next.Get(ctx, filter)
+	}
+
+	key, err := cacheKey(ctx, filter)
+	if err != nil {
+		return s.next.Get(ctx, filter)
+	}
+
+	if cats, ok := s.lists.Get(key); ok {
+		return append([]models.Cat(nil), cats...), nil
+	}
+
+	cats, err := s.next.Get(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	s.lists.Set(key, append([]models.Cat(nil), cats...))
+	return cats, nil
+}
+
+func (s *cachedCatsService) GetOne(ctx context.Context, id uuid.UUID) (*models.Cat, error) {
+	if contexts.ReadYourWrites(ctx) {
+		return s.next.GetOne(ctx, id)
+	}
+
+	if cat, ok := s.cats.Get(id); ok && cat.TenantID == contexts.Tenant(ctx) {
+		return &cat, nil
+	}
+
+	cat, err := s.next.GetOne(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	s.cats.Set(id, *cat)
+	return cat, nil
+}
+
+func (s *cachedCatsService) Update(ctx context.Context, id uuid.UUID, cat *models.Cat) error {
+	err := s.next.Update(ctx, id, cat)
+	s.cats.Delete(id)
+	s.lists.Purge()
+	return err
+}
+
+func (s *cachedCatsService) Transition(ctx context.Context, id uuid.UUID, status string) (*models.Transition, error) {
+	transition, err := s.next.Transition(ctx, id, status)
+	s.cats.Delete(id)
+	s.lists.Purge()
+	return transition, err
+}
+
+// Transitions is not cached.
+func (s *cachedCatsService) Transitions(ctx context.Context, id uuid.UUID) ([]models.Transition, error) {
+	return s.next.Transitions(ctx, id)
+}
+
+type cachedDogsService struct {
+	next  DogsService
+	dogs  *cache.LRU[uuid.UUID, models.Dog]
+	lists *cache.LRU[string, []models.Dog]
+}
+
+// NewCachedDogsService wraps a DogsService with a read-through cache. Add,
+// Update, Transition and Delete invalidate the cached dog and every cached list. Reads
+// asking to read your writes bypass the cache, and cached dogs and lists
+// are only returned to their tenant.
+func NewCachedDogsService(next DogsService, dogs *cache.LRU[uuid.UUID, models.Dog], lists *cache.LRU[string, []models.Dog]) DogsService {
+	return &cachedDogsService{
+		next:  next,
+		dogs:  dogs,
+		lists: lists,
+	}
+}
+
+func (s *cachedDogsService) Add(ctx context.Context, dog *models.Dog) (*uuid.UUID, error) {
+	id, err := s.next.Add(ctx, dog)
+	s.lists.Purge()
+	return id, err
+}
+
+// Count is not cached.
+func (s *cachedDogsService) Count(ctx context.Context, filter interface{}) (int64, error) {
+	return s.next.Count(ctx, filter)
+}
+
+func (s *cachedDogsService) Delete(ctx context.Context, id uuid.UUID) error {
+	err := s.next.Delete(ctx, id)
+	s.dogs.Delete(id)
+	s.lists.Purge()
+	return err
+}
+
+func (s *cachedDogsService) Get(ctx context.Context, filter interface{}) ([]models.Dog, error) {
+	if contexts.ReadYourWrites(ctx) {
+		return s.next.Get(ctx, filter)
+	}
+
+	key, err := cacheKey(ctx, filter)
+	if err != nil {
+		return s.next.Get(ctx, filter)
+	}
+
+	if dogs, ok := s.lists.Get(key); ok {
+		return append([]models.Dog(nil), dogs...), nil
+	}
+
+	dogs, err := s.next.Get(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	s.lists.Set(key, append([]models.Dog(nil), dogs...))
+	return dogs, nil
+}
+
+func (s *cachedDogsService) GetOne(ctx context.Context, id uuid.UUID) (*models.Dog, error) {
+	if contexts.ReadYourWrites(ctx) {
+		return s.next.GetOne(ctx, id)
+	}
+
+	if dog, ok := s.dogs.Get(id); ok && dog.TenantID == contexts.Tenant(ctx) {
+		return &dog, nil
+	}
+
+	dog, err := s.next.GetOne(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	s.dogs.Set(id, *dog)
+	return dog, nil
+}
+
+func (s *cachedDogsService) Update(ctx context.Context, id uuid.UUID, dog *models.Dog) error {
+	err := s.next.Update(ctx, id, dog)
+	s.dogs.Delete(id)
+	s.lists.Purge()
+	return err
+}
+
+func (s *cachedDogsService) Transition(ctx context.Context, id uuid.UUID, status string) (*models.Transition, error) {
+	transition, err := s.next.Transition(ctx, id, status)
+	s.dogs.Delete(id)
+	s.lists.Purge()
+	return transition, err
+}
+
+// Transitions is not cached.
+func (s *cachedDogsService) Transitions(ctx context.Context, id uuid.UUID) ([]models.Transition, error) {
+	return s.next.Transitions(ctx, id)
+}
+
+type cachedApplicationsService struct {
+	next     ApplicationsService
+	cats     *cache.LRU[uuid.UUID, models.Cat]
+	catLists *cache.LRU[string, []models.Cat]
+	dogs     *cache.LRU[uuid.UUID, models.Dog]
+	dogLists *cache.LRU[string, []models.Dog]
+}
+
+// NewCachedApplicationsService wraps an ApplicationsService so approvals
+// invalidate the cached cat or dog they adopt and every cached list of its
+// species. Applications themselves are not cached.
+func NewCachedApplicationsService(next ApplicationsService, cats *cache.LRU[uuid.UUID, models.Cat], catLists *cache.LRU[string, []models.Cat], dogs *cache.LRU[uuid.UUID, models.Dog], dogLists *cache.LRU[string, []models.Dog]) ApplicationsService {
+	return &cachedApplicationsService{
+		next:     next,
+		cats:     cats,
+		catLists: catLists,
+		dogs:     dogs,
+		dogLists: dogLists,
+	}
+}
+
+func (s *cachedApplicationsService) Add(ctx context.Context, application *models.Application) error {
+	return s.next.Add(ctx, application)
+}
+
+func (s *cachedApplicationsService) Delete(ctx context.Context, id uuid.UUID) error {
+	return s.next.Delete(ctx, id)
+}
+
+func (s *cachedApplicationsService) Get(ctx context.Context, filter *ApplicationFilter) ([]models.Application, error) {
+	return s.next.Get(ctx, filter)
+}
+
+func (s *cachedApplicationsService) GetOne(ctx context.Context, id uuid.UUID) (*models.Application, error) {
+	return s.next.GetOne(ctx, id)
+}
+
+func (s *cachedApplicationsService) Update(ctx context.Context, id uuid.UUID, application *models.Application) error {
+	return s.next.Update(ctx, id, application)
+}
+
+func (s *cachedApplicationsService) Review(ctx context.Context, id uuid.UUID, status string) (*models.Application, error) {
+	application, err := s.next.Review(ctx, id, status)
+	if err != nil || application.Status != models.ApplicationApproved {
+		return application, err
+	}
+	switch application.Species {
+	case CatsResource:
+		s.cats.Delete(application.AnimalID)
+		s.catLists.Purge()
+	case DogsResource:
+		s.dogs.Delete(application.AnimalID)
+		s.dogLists.Purge()
+	}
+	return application, nil
+}
+
+type cachedStatsService struct {
+	next  StatsService
+	stats *cache.LRU[string, models.AnimalStats]
+}
+
+// NewCachedStatsService wraps a StatsService with a read-through cache.
+// Writes do not invalidate it, so stats lag behind them by up to the TTL of
+// the cache. Reads asking to read your writes bypass the cache, and cached
+// stats are only returned to their tenant.
+func NewCachedStatsService(next StatsService, stats *cache.LRU[string, models.AnimalStats]) StatsService {
+	return &cachedStatsService{
+		next:  next,
+		stats: stats,
+	}
+}
+
+func (s *cachedStatsService) Get(ctx context.Context, species string, filter *AnimalFilter) (*models.AnimalStats, error) {
+	if contexts.ReadYourWrites(ctx) {
+		return s.next.Get(ctx, species, filter)
+	}
+
+	key, err := cacheKey(ctx, filter)
+	if err != nil {
+		return s.next.Get(ctx, species, filter)
+	}
+	key = species + "|" + key
+
+	if stats, ok := s.stats.Get(key); ok {
+		stats = copyStats(stats)
+		return &stats, nil
+	}
+
+	stats, err := s.next.Get(ctx, species, filter)
+	if err != nil {
+		return nil, err
+	}
+	s.stats.Set(key, copyStats(*stats))
+	return stats, nil
+}
+
+// copyStats copies the slices of stats so callers cannot change cached
+// stats.
+func copyStats(stats models.AnimalStats) models.AnimalStats {
+	stats.Breeds = slices.Clone(stats.Breeds)
+	stats.Colors = slices.Clone(stats.Colors)
+	stats.Ages = slices.Clone(stats.Ages)
+	stats.Weights = slices.Clone(stats.Weights)
+	return stats
+}
+
+// cacheKey identifies a list query by the tenant of ctx and the JSON
+// encoding of its filter.
+func cacheKey(ctx context.Context, filter interface{}) (string, error) {
+	data, err := json.Marshal(filter)
+	if err != nil {
+		return "", err
+	}
+	return contexts.Tenant(ctx) + "|" + string(data), nil
+}
@@ -0,0 +1,129 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/one-byte-data/go-api-sample/internal/cache"
+	"github.com/one-byte-data/go-api-sample/internal/contexts"
+	"github.com/one-byte-data/go-api-sample/internal/models"
+)
+
+type countingCatsService struct {
+	stubCatsService
+	gets    int
+	getOnes int
+}
+
+func (s *countingCatsService) Get(ctx context.Context, filter interface{}) ([]models.Cat, error) {
+	s.gets++
+	return []models.Cat{{Name: "Nacho"}}, nil
+}
+
+func (s *countingCatsService) GetOne(ctx context.Context, id uuid.UUID) (*models.Cat, error) {
+	s.getOnes++
+	return &models.Cat{ID: id, Name: "Nacho", TenantID: contexts.Tenant(ctx)}, nil
+}
+
+func Test_cachedCatsService(t *testing.T) {
+	config := cache.Config{Size: 10, TTL: time.Minute}
+	next := &countingCatsService{}
+	s := NewCachedCatsService(next, cache.New[uuid.UUID, models.Cat]("cats", config), cache.New[string, []models.Cat]("cat_lists", config))
+	ctx := context.Background()
+	id := uuid.New()
+
+	tests := []struct {
+		name        string
+		call        func()
+		wantGets    int
+		wantGetOnes int
+	}{
+		{
+			name:        "Should read through on a miss",
+			call:        func() { s.Get(ctx, nil); s.GetOne(ctx, id) },
+			wantGets:    1,
+			wantGetOnes: 1,
+		},
+		{
+			name:        "Should serve a hit from the cache",
+			call:        func() { s.Get(ctx, nil); s.GetOne(ctx, id) },
+			wantGets:    1,
+			wantGetOnes: 1,
+		},
+		{
+			name:        "Should cache lists per filter",
+			call:        func() { s.Get(ctx, map[string]string{"breed": "Tabby"}) },
+			wantGets:    2,
+			wantGetOnes: 1,
+		},
+		{
+			name:        "Should invalidate lists on Add",
+			call:        func() { s.Add(ctx, &models.Cat{}); s.Get(ctx, nil); s.GetOne(ctx, id) },
+			wantGets:    3,
+			wantGetOnes: 1,
+		},
+		{
+			name:        "Should invalidate the cat and lists on Update",
+			call:        func() { s.Update(ctx, id, &models.Cat{}); s.Get(ctx, nil); s.GetOne(ctx, id) },
+			wantGets:    4,
+			wantGetOnes: 2,
+		},
+		{
+			name:        "Should invalidate the cat and lists on Delete",
+			call:        func() { s.Delete(ctx, id); s.Get(ctx, nil); s.GetOne(ctx, id) },
+			wantGets:    5,
+			wantGetOnes: 3,
+		},
+		{
+			name: "Should bypass the cache to read your writes",
+			call: func() {
+				s.Get(ctx, nil)
+				s.GetOne(ctx, id)
+				ctx := contexts.WithReadYourWrites(ctx)
+				s.Get(ctx, nil)
+				s.GetOne(ctx, id)
+			},
+			wantGets:    6,
+			wantGetOnes: 4,
+		},
+		{
+			name: "Should not share cached cats and lists between tenants",
+			call: func() {
+				ctx := contexts.WithTenant(ctx, "shelter-b")
+				s.Get(ctx, nil)
+				s.GetOne(ctx, id)
+			},
+			wantGets:    7,
+			wantGetOnes: 5,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.call()
+			if next.gets != tt.wantGets || next.getOnes != tt.wantGetOnes {
+				t.Errorf("cachedCatsService reads = %v/%v, want %v/%v", next.gets, next.getOnes, tt.wantGets, tt.wantGetOnes)
+			}
+		})
+	}
+}
+
+func Test_cachedCatsService_copies(t *testing.T) {
+	config := cache.Config{Size: 10, TTL: time.Minute}
+	s := NewCachedCatsService(&countingCatsService{}, cache.New[uuid.UUID, models.Cat]("cats", config), cache.New[string, []models.Cat]("cat_lists", config))
+	ctx := context.Background()
+	id := uuid.New()
+
+	cat, _ := s.GetOne(ctx, id)
+	cat.Name = "Changed"
+	cats, _ := s.Get(ctx, nil)
+	cats[0].Name = "Changed"
+
+	if cat, _ := s.GetOne(ctx, id); cat.Name != "Nacho" {
+		t.Errorf("cachedCatsService.GetOne() = %v, callers must not change cached cats", cat.Name)
+	}
+	if cats, _ := s.Get(ctx, nil); cats[0].Name != "Nacho" {
+		t.Errorf("cachedCatsService.Get() = %v, callers must not change cached lists", cats[0].Name)
+	}
+}
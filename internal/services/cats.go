@@ -2,78 +2,151 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/one-byte-data/go-api-sample/internal/models"
+	"github.com/one-byte-data/go-api-sample/internal/repositories"
+	"github.com/one-byte-data/go-api-sample/internal/validation"
 
 	"github.com/google/uuid"
-	"gorm.io/gorm"
 )
 
+// CatsResource is the resource name cats are recorded under in the audit log.
+const CatsResource = "cats"
+
 type CatsService interface {
 	Add(ctx context.Context, cat *models.Cat) (*uuid.UUID, error)
+	Count(ctx context.Context, filter interface{}) (int64, error)
 	Delete(ctx context.Context, id uuid.UUID) error
 	Get(ctx context.Context, filter interface{}) ([]models.Cat, error)
 	GetOne(ctx context.Context, id uuid.UUID) (*models.Cat, error)
+	// Update stores the changes and fills cat with the updated row. The
+	// status is left as is.
 	Update(ctx context.Context, id uuid.UUID, cat *models.Cat) error
+	// Transition moves the cat to the status and returns the recorded
+	// transition, see CanTransition.
+	Transition(ctx context.Context, id uuid.UUID, status string) (*models.Transition, error)
+	// Transitions returns the status transitions of the cat, oldest first.
+	Transitions(ctx context.Context, id uuid.UUID) ([]models.Transition, error)
 }
 
 type catsService struct {
-	db *gorm.DB
+	repo repositories.Repository
 }
 
-func NewCatsService(db *gorm.DB) CatsService {
+func NewCatsService(repo repositories.Repository) CatsService {
 	return &catsService{
-		db: db,
+		repo: repo,
 	}
 }
 
 func (s *catsService) Add(ctx context.Context, cat *models.Cat) (*uuid.UUID, error) {
-	if err := s.db.Create(cat).Error; err != nil {
+	if err := normalize(ctx, s.repo, validation.Cats, &cat.Breed, &cat.BreedStatus, cat); err != nil {
+		return nil, err
+	}
+	cat.Status = models.StatusIntake
+
+	err := s.repo.Transaction(ctx, func(tx repositories.Repository) error {
+		if err := tx.Cats().Create(ctx, cat); err != nil {
+			return err
+		}
+		return recordAudit(ctx, tx.Audit(), CatsResource, cat.ID, models.AuditActionCreate, nil, cat)
+	})
+	if err != nil {
 		return nil, err
 	}
 	return &cat.ID, nil
 }
 
+func (s *catsService) Count(ctx context.Context, filter interface{}) (int64, error) {
+	return s.repo.Cats().Count(ctx, animalFilter(filter))
+}
+
 func (s *catsService) Delete(ctx context.Context, id uuid.UUID) error {
-	db := s.db.Delete(&models.Cat{}, id)
-	if err := db.Error; err != nil {
-		return err
-	}
-	if db.RowsAffected < 1 {
-		return fmt.Errorf("row with id=%v cannot be deleted because it doesn't exist", id)
-	}
-	return nil
+	return s.repo.Transaction(ctx, func(tx repositories.Repository) error {
+		before, err := tx.Cats().Get(ctx, id)
+		if err != nil {
+			if errors.Is(err, ErrNotFound) {
+				return fmt.Errorf("row with id=%v cannot be deleted because it doesn't exist: %w", id, ErrNotFound)
+			}
+			return err
+		}
+
+		if err := tx.Cats().Delete(ctx, id); err != nil {
+			if errors.Is(err, ErrNotFound) {
+				return fmt.Errorf("row with id=%v cannot be deleted because it doesn't exist: %w", id, ErrNotFound)
+			}
+			return err
+		}
+
+		return recordAudit(ctx, tx.Audit(), CatsResource, id, models.AuditActionDelete, before, nil)
+	})
 }
 
 func (s *catsService) Get(ctx context.Context, filter interface{}) ([]models.Cat, error) {
-	cats := make([]models.Cat, 0)
-	if err := s.db.Find(&cats).Error; err != nil {
-		return nil, err
-	}
-	return cats, nil
+	return s.repo.Cats().Find(ctx, animalFilter(filter))
 }
 
 func (s *catsService) GetOne(ctx context.Context, id uuid.UUID) (*models.Cat, error) {
-	cat := new(models.Cat)
-	if err := s.db.First(cat, id).Error; err != nil {
-		return nil, err
-	}
-	return cat, nil
+	return s.repo.Cats().Get(ctx, id)
 }
 
 func (s *catsService) Update(ctx context.Context, id uuid.UUID, cat *models.Cat) error {
-	db := s.db.Model(&models.Cat{ID: id}).Updates(models.Cat{
-		Name:      cat.Name,
-		Breed:     cat.Breed,
-		Color:     cat.Color,
-		Birthdate: cat.Birthdate,
-		Weight:    cat.Weight,
+	if err := normalize(ctx, s.repo, validation.Cats, &cat.Breed, &cat.BreedStatus, cat); err != nil {
+		return err
+	}
+
+	return s.repo.Transaction(ctx, func(tx repositories.Repository) error {
+		before, err := tx.Cats().Get(ctx, id)
+		if err != nil {
+			if errors.Is(err, ErrNotFound) {
+				return fmt.Errorf("row with id=%v cannot be updated because it doesn't exist: %w", id, ErrNotFound)
+			}
+			return err
+		}
+
+		err = tx.Cats().Update(ctx, id, &models.Cat{
+			Name:        cat.Name,
+			Breed:       cat.Breed,
+			Color:       cat.Color,
+			Birthdate:   cat.Birthdate,
+			Weight:      cat.Weight,
+			BreedStatus: cat.BreedStatus,
+		})
+		if err != nil {
+			if errors.Is(err, ErrNotFound) {
+				return fmt.Errorf("row with id=%v cannot be updated because it doesn't exist: %w", id, ErrNotFound)
+			}
+			return err
+		}
+
+		after, err := tx.Cats().Get(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := recordAudit(ctx, tx.Audit(), CatsResource, id, models.AuditActionUpdate, before, after); err != nil {
+			return err
+		}
+		*cat = *after
+		return nil
 	})
+}
 
-	if db.RowsAffected < 1 {
-		return fmt.Errorf("row with id=%v cannot be updated because it doesn't exist", id)
+func (s *catsService) Transition(ctx context.Context, id uuid.UUID, status string) (*models.Transition, error) {
+	var transition *models.Transition
+	err := s.repo.Transaction(ctx, func(tx repositories.Repository) error {
+		var err error
+		transition, err = changeStatus(ctx, tx, tx.Cats(), CatsResource, id, func(cat models.Cat) string { return cat.Status }, status)
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
+	return transition, nil
+}
 
-	return db.Error
+func (s *catsService) Transitions(ctx context.Context, id uuid.UUID) ([]models.Transition, error) {
+	return s.repo.Transitions().Find(ctx, CatsResource, id)
 }
@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"math/rand"
 	"reflect"
@@ -11,8 +12,11 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/google/uuid"
+	"github.com/jackc/pgconn"
 	"github.com/one-byte-data/go-api-sample/cmd/tests"
+	"github.com/one-byte-data/go-api-sample/internal/contexts"
 	"github.com/one-byte-data/go-api-sample/internal/models"
+	"github.com/one-byte-data/go-api-sample/internal/repositories"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
@@ -21,7 +25,7 @@ func BenchmarkCatInserts(b *testing.B) {
 	teardownTests := tests.SetupTests(b, postgres.Open(tests.ConnectionString))
 	defer teardownTests(b)
 
-	catsService := NewCatsService(tests.DB)
+	catsService := NewCatsService(repositories.NewGorm(tests.DB))
 
 	for i := 0; i < b.N; i++ {
 		cat := &models.Cat{
@@ -58,12 +62,12 @@ func TestIntegration_NewCatsService(t *testing.T) {
 		{
 			name: "Should get valid interface back",
 			args: args{db: tests.DB},
-			want: &catsService{db: tests.DB},
+			want: &catsService{repo: repositories.NewGorm(tests.DB)},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := NewCatsService(tt.args.db); !reflect.DeepEqual(got, tt.want) {
+			if got := NewCatsService(repositories.NewGorm(tt.args.db)); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("NewCatsService() = %v, want %v", got, tt.want)
 			}
 		})
@@ -114,27 +118,50 @@ func Test_catsService_Add(t *testing.T) {
 			want:    &zeroID,
 			wantErr: false,
 		},
+		{
+			name:   "Should not add a cat too heavy for its breed",
+			fields: fields{db: gdb},
+			args: args{ctx: context.Background(), cat: &models.Cat{
+				Name:      "Nacho",
+				Breed:     "siamese",
+				Color:     "Seal",
+				Birthdate: birth,
+				Weight:    40,
+			}},
+			want:    nil,
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mock.ExpectBegin()
-			mock.ExpectExec(`INSERT INTO "cats"`).WithArgs(
-				sqlmock.AnyArg(),
-				sqlmock.AnyArg(),
-				sqlmock.AnyArg(),
-				sqlmock.AnyArg(),
-				sqlmock.AnyArg(),
-				sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(1, 1))
-			mock.ExpectCommit()
+			expectBreeds(mock, models.Breed{Name: "Tabby", MinWeight: 4, MaxWeight: 25}, models.Breed{Name: "Siamese", MinWeight: 5, MaxWeight: 15})
+			if !tt.wantErr {
+				mock.ExpectBegin()
+				mock.ExpectExec(`INSERT INTO "cats"`).WithArgs(
+					sqlmock.AnyArg(),
+					sqlmock.AnyArg(),
+					sqlmock.AnyArg(),
+					sqlmock.AnyArg(),
+					sqlmock.AnyArg(),
+					sqlmock.AnyArg(),
+					models.BreedKnown,
+					models.StatusIntake,
+					contexts.DefaultTenant).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(`INSERT INTO "audit_entries"`).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			}
 
 			s := &catsService{
-				db: tt.fields.db,
+				repo: repositories.NewGorm(tt.fields.db),
 			}
 			got, err := s.Add(tt.args.ctx, tt.args.cat)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("catsService.Add() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
+			if tt.wantErr && !errors.Is(err, ErrInvalid) {
+				t.Errorf("catsService.Add() error = %v, want %v", err, ErrInvalid)
+			}
 			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("catsService.Add() = %v, want %v", got, tt.want)
 			}
@@ -142,6 +169,40 @@ func Test_catsService_Add(t *testing.T) {
 	}
 }
 
+func Test_catsService_Add_retries(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	gdb, err := gorm.Open(postgres.Dialector{
+		Config: &postgres.Config{Conn: db},
+	})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	// CockroachDB aborts the first attempt to resolve contention.
+	expectBreeds(mock, models.Breed{Name: "Tabby"})
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO "cats"`).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(`INSERT INTO "audit_entries"`).WillReturnError(&pgconn.PgError{Code: "40001", Message: "restart transaction"})
+	mock.ExpectRollback()
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO "cats"`).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(`INSERT INTO "audit_entries"`).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	s := NewCatsService(repositories.NewGorm(gdb))
+	if _, err := s.Add(context.Background(), &models.Cat{Name: "Nacho", Breed: "Tabby", Color: "Orange", Birthdate: time.Now(), Weight: 17}); err != nil {
+		t.Errorf("catsService.Add() error = %v, want the transaction to be retried", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
 func TestIntegration_catsService_Add(t *testing.T) {
 	if m := flag.Lookup("test.run").Value.String(); m == "" || !regexp.MustCompile(m).MatchString(t.Name()) {
 		t.Skip("skipping as execution was not requested explicitly using go test -run")
@@ -199,7 +260,7 @@ func TestIntegration_catsService_Add(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &catsService{
-				db: tt.fields.db,
+				repo: repositories.NewGorm(tt.fields.db),
 			}
 			got, err := s.Add(tt.args.ctx, tt.args.cat)
 			if (err != nil) != tt.wantErr {
@@ -250,10 +311,12 @@ func Test_catsService_Delete(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mock.ExpectBegin()
-			mock.ExpectExec(`DELETE FROM "cats" WHERE`).WithArgs(tt.args.id).WillReturnResult(sqlmock.NewResult(1, 1))
+			mock.ExpectQuery(`SELECT \* FROM "cats" WHERE`).WithArgs(tt.args.id, contexts.DefaultTenant).WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(tt.args.id, "Nacho"))
+			mock.ExpectExec(`DELETE FROM "cats" WHERE`).WithArgs(tt.args.id, contexts.DefaultTenant).WillReturnResult(sqlmock.NewResult(1, 1))
+			mock.ExpectExec(`INSERT INTO "audit_entries"`).WillReturnResult(sqlmock.NewResult(1, 1))
 			mock.ExpectCommit()
 			s := &catsService{
-				db: tt.fields.db,
+				repo: repositories.NewGorm(tt.fields.db),
 			}
 			if err := s.Delete(tt.args.ctx, tt.args.id); (err != nil) != tt.wantErr {
 				t.Errorf("catsService.Delete() error = %v, wantErr %v", err, tt.wantErr)
@@ -299,7 +362,7 @@ func TestIntegration_catsService_Delete(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &catsService{
-				db: tt.fields.db,
+				repo: repositories.NewGorm(tt.fields.db),
 			}
 			if err := s.Delete(tt.args.ctx, tt.args.id); (err != nil) != tt.wantErr {
 				t.Errorf("catsService.Delete() error = %v, wantErr %v", err, tt.wantErr)
@@ -348,7 +411,7 @@ func Test_catsService_Get(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mock.ExpectQuery(`SELECT`).WillReturnRows(sqlmock.NewRows([]string{""}))
 			s := &catsService{
-				db: tt.fields.db,
+				repo: repositories.NewGorm(tt.fields.db),
 			}
 			got, err := s.Get(tt.args.ctx, tt.args.filter)
 			if (err != nil) != tt.wantErr {
@@ -362,6 +425,39 @@ func Test_catsService_Get(t *testing.T) {
 	}
 }
 
+func Test_catsService_GetFiltered(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	gdb, err := gorm.Open(postgres.Dialector{
+		Config: &postgres.Config{Conn: db},
+	})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	after := uuid.New()
+	mock.ExpectQuery(`SELECT \* FROM "cats" WHERE LOWER\(name\) LIKE \$1 AND weight >= \$2 AND id > \$3 AND "cats"."tenant_id" = \$4 ORDER BY id LIMIT 3`).
+		WithArgs("%nacho%", 2, after, contexts.DefaultTenant).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(uuid.New(), "Nacho"))
+
+	s := &catsService{repo: repositories.NewGorm(gdb)}
+	got, err := s.Get(context.Background(), &AnimalFilter{Name: "Nacho", MinWeight: 2, After: &after, Limit: 3})
+	if err != nil {
+		t.Errorf("catsService.Get() error = %v", err)
+		return
+	}
+	if len(got) != 1 || got[0].Name != "Nacho" {
+		t.Errorf("catsService.Get() = %v, want Nacho", got)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
 func TestIntegration_catsService_Get(t *testing.T) {
 	if m := flag.Lookup("test.run").Value.String(); m == "" || !regexp.MustCompile(m).MatchString(t.Name()) {
 		t.Skip("skipping as execution was not requested explicitly using go test -run")
@@ -395,7 +491,7 @@ func TestIntegration_catsService_Get(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &catsService{
-				db: tt.fields.db,
+				repo: repositories.NewGorm(tt.fields.db),
 			}
 			got, err := s.Get(tt.args.ctx, tt.args.filter)
 			if (err != nil) != tt.wantErr {
@@ -449,7 +545,7 @@ func TestIntegration_catsService_GetOne(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &catsService{
-				db: tt.fields.db,
+				repo: repositories.NewGorm(tt.fields.db),
 			}
 			got, err := s.GetOne(tt.args.ctx, tt.args.id)
 			if (err != nil) != tt.wantErr {
@@ -516,7 +612,7 @@ func TestIntegration_catsService_Update(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &catsService{
-				db: tt.fields.db,
+				repo: repositories.NewGorm(tt.fields.db),
 			}
 			if err := s.Update(tt.args.ctx, tt.args.id, tt.args.cat); (err != nil) != tt.wantErr {
 				t.Errorf("catsService.Update() error = %v, wantErr %v", err, tt.wantErr)
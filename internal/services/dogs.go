@@ -2,78 +2,151 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/one-byte-data/go-api-sample/internal/models"
+	"github.com/one-byte-data/go-api-sample/internal/repositories"
+	"github.com/one-byte-data/go-api-sample/internal/validation"
 
 	"github.com/google/uuid"
-	"gorm.io/gorm"
 )
 
+// DogsResource is the resource name dogs are recorded under in the audit log.
+const DogsResource = "dogs"
+
 type DogsService interface {
 	Add(ctx context.Context, dog *models.Dog) (*uuid.UUID, error)
+	Count(ctx context.Context, filter interface{}) (int64, error)
 	Delete(ctx context.Context, id uuid.UUID) error
 	Get(ctx context.Context, filter interface{}) ([]models.Dog, error)
 	GetOne(ctx context.Context, id uuid.UUID) (*models.Dog, error)
+	// Update stores the changes and fills dog with the updated row. The
+	// status is left as is.
 	Update(ctx context.Context, id uuid.UUID, dog *models.Dog) error
+	// Transition moves the dog to the status and returns the recorded
+	// transition, see CanTransition.
+	Transition(ctx context.Context, id uuid.UUID, status string) (*models.Transition, error)
+	// Transitions returns the status transitions of the dog, oldest first.
+	Transitions(ctx context.Context, id uuid.UUID) ([]models.Transition, error)
 }
 
 type dogsService struct {
-	db *gorm.DB
+	repo repositories.Repository
 }
 
-func NewDogsService(db *gorm.DB) DogsService {
+func NewDogsService(repo repositories.Repository) DogsService {
 	return &dogsService{
-		db: db,
+		repo: repo,
 	}
 }
 
 func (s *dogsService) Add(ctx context.Context, dog *models.Dog) (*uuid.UUID, error) {
-	if err := s.db.Create(dog).Error; err != nil {
+	if err := normalize(ctx, s.repo, validation.Dogs, &dog.Breed, &dog.BreedStatus, dog); err != nil {
+		return nil, err
+	}
+	dog.Status = models.StatusIntake
+
+	err := s.repo.Transaction(ctx, func(tx repositories.Repository) error {
+		if err := tx.Dogs().Create(ctx, dog); err != nil {
+			return err
+		}
+		return recordAudit(ctx, tx.Audit(), DogsResource, dog.ID, models.AuditActionCreate, nil, dog)
+	})
+	if err != nil {
 		return nil, err
 	}
 	return &dog.ID, nil
 }
 
+func (s *dogsService) Count(ctx context.Context, filter interface{}) (int64, error) {
+	return s.repo.Dogs().Count(ctx, animalFilter(filter))
+}
+
 func (s *dogsService) Delete(ctx context.Context, id uuid.UUID) error {
-	db := s.db.Delete(&models.Dog{}, id)
-	if err := db.Error; err != nil {
-		return err
-	}
-	if db.RowsAffected < 1 {
-		return fmt.Errorf("row with id=%v cannot be deleted because it doesn't exist", id)
-	}
-	return nil
+	return s.repo.Transaction(ctx, func(tx repositories.Repository) error {
+		before, err := tx.Dogs().Get(ctx, id)
+		if err != nil {
+			if errors.Is(err, ErrNotFound) {
+				return fmt.Errorf("row with id=%v cannot be deleted because it doesn't exist: %w", id, ErrNotFound)
+			}
+			return err
+		}
+
+		if err := tx.Dogs().Delete(ctx, id); err != nil {
+			if errors.Is(err, ErrNotFound) {
+				return fmt.Errorf("row with id=%v cannot be deleted because it doesn't exist: %w", id, ErrNotFound)
+			}
+			return err
+		}
+
+		return recordAudit(ctx, tx.Audit(), DogsResource, id, models.AuditActionDelete, before, nil)
+	})
 }
 
 func (s *dogsService) Get(ctx context.Context, filter interface{}) ([]models.Dog, error) {
-	dogs := make([]models.Dog, 0)
-	if err := s.db.Find(&dogs).Error; err != nil {
-		return nil, err
-	}
-	return dogs, nil
+	return s.repo.Dogs().Find(ctx, animalFilter(filter))
 }
 
 func (s *dogsService) GetOne(ctx context.Context, id uuid.UUID) (*models.Dog, error) {
-	dog := new(models.Dog)
-	if err := s.db.First(dog, id).Error; err != nil {
-		return nil, err
-	}
-	return dog, nil
+	return s.repo.Dogs().Get(ctx, id)
 }
 
 func (s *dogsService) Update(ctx context.Context, id uuid.UUID, dog *models.Dog) error {
-	db := s.db.Model(&models.Dog{ID: id}).Updates(models.Dog{
-		Name:      dog.Name,
-		Breed:     dog.Breed,
-		Color:     dog.Color,
-		Birthdate: dog.Birthdate,
-		Weight:    dog.Weight,
+	if err := normalize(ctx, s.repo, validation.Dogs, &dog.Breed, &dog.BreedStatus, dog); err != nil {
+		return err
+	}
+
+	return s.repo.Transaction(ctx, func(tx repositories.Repository) error {
+		before, err := tx.Dogs().Get(ctx, id)
+		if err != nil {
+			if errors.Is(err, ErrNotFound) {
+				return fmt.Errorf("row with id=%v cannot be updated because it doesn't exist: %w", id, ErrNotFound)
+			}
+			return err
+		}
+
+		err = tx.Dogs().Update(ctx, id, &models.Dog{
+			Name:        dog.Name,
+			Breed:       dog.Breed,
+			Color:       dog.Color,
+			Birthdate:   dog.Birthdate,
+			Weight:      dog.Weight,
+			BreedStatus: dog.BreedStatus,
+		})
+		if err != nil {
+			if errors.Is(err, ErrNotFound) {
+				return fmt.Errorf("row with id=%v cannot be updated because it doesn't exist: %w", id, ErrNotFound)
+			}
+			return err
+		}
+
+		after, err := tx.Dogs().Get(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := recordAudit(ctx, tx.Audit(), DogsResource, id, models.AuditActionUpdate, before, after); err != nil {
+			return err
+		}
+		*dog = *after
+		return nil
 	})
+}
 
-	if db.RowsAffected < 1 {
-		return fmt.Errorf("row with id=%v cannot be updated because it doesn't exist", id)
+func (s *dogsService) Transition(ctx context.Context, id uuid.UUID, status string) (*models.Transition, error) {
+	var transition *models.Transition
+	err := s.repo.Transaction(ctx, func(tx repositories.Repository) error {
+		var err error
+		transition, err = changeStatus(ctx, tx, tx.Dogs(), DogsResource, id, func(dog models.Dog) string { return dog.Status }, status)
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
+	return transition, nil
+}
 
-	return db.Error
+func (s *dogsService) Transitions(ctx context.Context, id uuid.UUID) ([]models.Transition, error) {
+	return s.repo.Transitions().Find(ctx, DogsResource, id)
 }
@@ -11,6 +11,7 @@ import (
 
 	"github.com/one-byte-data/go-api-sample/cmd/tests"
 	"github.com/one-byte-data/go-api-sample/internal/models"
+	"github.com/one-byte-data/go-api-sample/internal/repositories"
 
 	"github.com/google/uuid"
 	"gorm.io/driver/postgres"
@@ -21,7 +22,7 @@ func BenchmarkDogInserts(b *testing.B) {
 	teardownTests := tests.SetupTests(b, postgres.Open(tests.ConnectionString))
 	defer teardownTests(b)
 
-	dogsService := NewDogsService(tests.DB)
+	dogsService := NewDogsService(repositories.NewGorm(tests.DB))
 
 	for i := 0; i < b.N; i++ {
 		dog := &models.Dog{
@@ -58,12 +59,12 @@ func TestIntegration_NewDogsService(t *testing.T) {
 		{
 			name: "Should get valid interface back",
 			args: args{db: tests.DB},
-			want: &dogsService{db: tests.DB},
+			want: &dogsService{repo: repositories.NewGorm(tests.DB)},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := NewDogsService(tt.args.db); !reflect.DeepEqual(got, tt.want) {
+			if got := NewDogsService(repositories.NewGorm(tt.args.db)); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("NewDogsService() = %v, want %v", got, tt.want)
 			}
 		})
@@ -90,7 +91,7 @@ func Test_dogsService_Add(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &dogsService{
-				db: tt.fields.db,
+				repo: repositories.NewGorm(tt.fields.db),
 			}
 			got, err := s.Add(tt.args.ctx, tt.args.dog)
 			if (err != nil) != tt.wantErr {
@@ -136,7 +137,7 @@ func TestIntegration_dogsService_Add(t *testing.T) {
 				dog: &models.Dog{
 					ID:        dogID,
 					Name:      "Snowball",
-					Breed:     "Sniba Inu",
+					Breed:     "Shiba Inu",
 					Color:     "Cream",
 					Birthdate: time.Now(),
 					Weight:    22,
@@ -161,7 +162,7 @@ func TestIntegration_dogsService_Add(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &dogsService{
-				db: tt.fields.db,
+				repo: repositories.NewGorm(tt.fields.db),
 			}
 			got, err := s.Add(tt.args.ctx, tt.args.dog)
 			if (err != nil) != tt.wantErr {
@@ -212,7 +213,7 @@ func TestIntegration_dogsService_Delete(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &dogsService{
-				db: tt.fields.db,
+				repo: repositories.NewGorm(tt.fields.db),
 			}
 			if err := s.Delete(tt.args.ctx, tt.args.id); (err != nil) != tt.wantErr {
 				t.Errorf("dogsService.Delete() error = %v, wantErr %v", err, tt.wantErr)
@@ -254,7 +255,7 @@ func TestIntegration_dogsService_Get(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &dogsService{
-				db: tt.fields.db,
+				repo: repositories.NewGorm(tt.fields.db),
 			}
 			got, err := s.Get(tt.args.ctx, tt.args.filter)
 			if (err != nil) != tt.wantErr {
@@ -308,7 +309,7 @@ func TestIntegration_dogsService_GetOne(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &dogsService{
-				db: tt.fields.db,
+				repo: repositories.NewGorm(tt.fields.db),
 			}
 			got, err := s.GetOne(tt.args.ctx, tt.args.id)
 			if (err != nil) != tt.wantErr {
@@ -375,7 +376,7 @@ func TestIntegration_dogsService_Update(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &dogsService{
-				db: tt.fields.db,
+				repo: repositories.NewGorm(tt.fields.db),
 			}
 			if err := s.Update(tt.args.ctx, tt.args.id, tt.args.dog); (err != nil) != tt.wantErr {
 				t.Errorf("dogsService.Update() error = %v, wantErr %v", err, tt.wantErr)
@@ -0,0 +1,43 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/one-byte-data/go-api-sample/internal/repositories"
+	"github.com/one-byte-data/go-api-sample/internal/validation"
+)
+
+// ErrNotFound is returned, possibly wrapped, when the requested row does
+// not exist.
+var ErrNotFound = repositories.ErrNotFound
+
+// ErrUnavailable is returned, possibly wrapped, while the database cannot
+// be reached.
+var ErrUnavailable = repositories.ErrUnavailable
+
+// ErrConflict is returned, possibly wrapped, when a change conflicts with
+// the rows stored, e.g. a tenant that already exists.
+var ErrConflict = repositories.ErrConflict
+
+// ErrInvalidTransition is returned, possibly wrapped, when the status of a
+// cat or dog cannot change to the requested one, see CanTransition. It
+// wraps ErrConflict.
+var ErrInvalidTransition = fmt.Errorf("invalid transition: %w", ErrConflict)
+
+// ErrInvalidTenant is returned, possibly wrapped, for a tenant ID that is
+// malformed or names no tenant.
+var ErrInvalidTenant = errors.New("invalid tenant")
+
+// ErrInvalid is returned, wrapping the validator.ValidationErrors, for a
+// cat or dog that breaks the validation rules, see the validation package.
+var ErrInvalid = errors.New("invalid")
+
+// validate checks item against its binding tags and the validation rules.
+func validate(ctx context.Context, item interface{}) error {
+	if err := validation.Struct(ctx, item); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalid, err)
+	}
+	return nil
+}
@@ -0,0 +1,16 @@
+package services
+
+import "github.com/one-byte-data/go-api-sample/internal/repositories"
+
+// AnimalFilter narrows the cats or dogs returned by Get and counted by Count
+// when passed as the filter.
+type AnimalFilter = repositories.AnimalFilter
+
+// animalFilter returns the filter passed to Get or Count, or nil when it is
+// not an *AnimalFilter.
+func animalFilter(filter interface{}) *AnimalFilter {
+	if f, ok := filter.(*AnimalFilter); ok {
+		return f
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/one-byte-data/go-api-sample/internal/models"
+	"github.com/one-byte-data/go-api-sample/internal/repositories"
+)
+
+// StatsService summarizes the cats or dogs of a tenant. The aggregation is
+// done by the repository, in SQL for the database backends.
+type StatsService interface {
+	// Get summarizes the cats or dogs, see CatsResource and DogsResource,
+	// matching the filter, which may be nil. Ages are as of now. It returns
+	// ErrNotFound for other species.
+	Get(ctx context.Context, species string, filter *AnimalFilter) (*models.AnimalStats, error)
+}
+
+type statsService struct {
+	repo repositories.Repository
+}
+
+func NewStatsService(repo repositories.Repository) StatsService {
+	return &statsService{
+		repo: repo,
+	}
+}
+
+func (s *statsService) Get(ctx context.Context, species string, filter *AnimalFilter) (*models.AnimalStats, error) {
+	now := time.Now().UTC()
+	switch species {
+	case CatsResource:
+		return s.repo.Cats().Stats(ctx, filter, now)
+	case DogsResource:
+		return s.repo.Dogs().Stats(ctx, filter, now)
+	}
+	return nil, fmt.Errorf("there are no stats for %q: %w", species, ErrNotFound)
+}
@@ -0,0 +1,140 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/one-byte-data/go-api-sample/internal/cache"
+	"github.com/one-byte-data/go-api-sample/internal/contexts"
+	"github.com/one-byte-data/go-api-sample/internal/models"
+	"github.com/one-byte-data/go-api-sample/internal/repositories"
+)
+
+func Test_statsService_Get(t *testing.T) {
+	ctx := context.Background()
+	repo := repositories.NewMemory()
+	cat := &models.Cat{ID: uuid.New(), Name: "Nacho", Breed: "Tabby", Color: "Orange", Birthdate: time.Now().AddDate(-2, 0, 0), Weight: 10}
+	if _, err := NewCatsService(repo).Add(ctx, cat); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	s := NewStatsService(repo)
+
+	tests := []struct {
+		name      string
+		species   string
+		filter    *AnimalFilter
+		wantTotal int64
+		wantErr   error
+	}{
+		{
+			name:      "Should summarize the cats",
+			species:   CatsResource,
+			wantTotal: 1,
+		},
+		{
+			name:      "Should filter the cats",
+			species:   CatsResource,
+			filter:    &AnimalFilter{Status: models.StatusAvailable},
+			wantTotal: 0,
+		},
+		{
+			name:      "Should summarize the dogs",
+			species:   DogsResource,
+			wantTotal: 0,
+		},
+		{
+			name:    "Should not summarize other species",
+			species: "birds",
+			wantErr: ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.Get(ctx, tt.species, tt.filter)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Get() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && got.Total != tt.wantTotal {
+				t.Errorf("Get() total = %v, want %v", got.Total, tt.wantTotal)
+			}
+		})
+	}
+
+	got, err := s.Get(ctx, CatsResource, nil)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if len(got.Ages) != 5 || got.Ages[1].Label != "1-3" || got.Ages[1].Count != 1 {
+		t.Errorf("Get() ages = %+v, want the cat in the 1-3 bucket", got.Ages)
+	}
+}
+
+type countingStatsService struct {
+	gets int
+}
+
+func (s *countingStatsService) Get(ctx context.Context, species string, filter *AnimalFilter) (*models.AnimalStats, error) {
+	s.gets++
+	return &models.AnimalStats{Total: 1, Breeds: []models.GroupCount{{Value: "Tabby", Count: 1}}}, nil
+}
+
+func Test_cachedStatsService(t *testing.T) {
+	config := cache.Config{Size: 10, TTL: time.Minute}
+	next := &countingStatsService{}
+	s := NewCachedStatsService(next, cache.New[string, models.AnimalStats]("stats", config))
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		call     func()
+		wantGets int
+	}{
+		{
+			name:     "Should read through on a miss",
+			call:     func() { s.Get(ctx, CatsResource, nil) },
+			wantGets: 1,
+		},
+		{
+			name:     "Should serve a hit from the cache",
+			call:     func() { s.Get(ctx, CatsResource, nil) },
+			wantGets: 1,
+		},
+		{
+			name:     "Should cache stats per species",
+			call:     func() { s.Get(ctx, DogsResource, nil) },
+			wantGets: 2,
+		},
+		{
+			name:     "Should cache stats per filter",
+			call:     func() { s.Get(ctx, CatsResource, &AnimalFilter{Breed: "Tabby"}) },
+			wantGets: 3,
+		},
+		{
+			name:     "Should cache stats per tenant",
+			call:     func() { s.Get(contexts.WithTenant(ctx, "shelter-b"), CatsResource, nil) },
+			wantGets: 4,
+		},
+		{
+			name:     "Should bypass the cache to read your writes",
+			call:     func() { s.Get(contexts.WithReadYourWrites(ctx), CatsResource, nil) },
+			wantGets: 5,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.call()
+			if next.gets != tt.wantGets {
+				t.Errorf("cachedStatsService reads = %v, want %v", next.gets, tt.wantGets)
+			}
+		})
+	}
+
+	stats, _ := s.Get(ctx, CatsResource, nil)
+	stats.Breeds[0].Value = "Changed"
+	if stats, _ := s.Get(ctx, CatsResource, nil); stats.Breeds[0].Value != "Tabby" {
+		t.Errorf("cachedStatsService.Get() = %v, callers must not change cached stats", stats.Breeds[0].Value)
+	}
+}
@@ -0,0 +1,92 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/one-byte-data/go-api-sample/internal/contexts"
+	"github.com/one-byte-data/go-api-sample/internal/models"
+	"github.com/one-byte-data/go-api-sample/internal/repositories"
+
+	"github.com/google/uuid"
+)
+
+// transitions is the shelter state machine: the statuses a cat or dog can
+// move to from each status.
+var transitions = map[string][]string{
+	models.StatusIntake:    {models.StatusAvailable, models.StatusOnHold},
+	models.StatusAvailable: {models.StatusOnHold, models.StatusAdopted},
+	models.StatusOnHold:    {models.StatusAvailable, models.StatusAdopted},
+	models.StatusAdopted:   {models.StatusReturned},
+	models.StatusReturned:  {models.StatusIntake, models.StatusAvailable, models.StatusOnHold},
+}
+
+// KnownStatus reports whether status is one of the shelter statuses.
+func KnownStatus(status string) bool {
+	_, ok := transitions[status]
+	return ok
+}
+
+// CanTransition reports whether a cat or dog can move from one status to
+// another.
+func CanTransition(from, to string) bool {
+	for _, next := range transitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// changeStatus moves the cat or dog with the ID to the status, then records
+// the transition and an audit entry. It must be called with a transaction.
+// It returns ErrInvalidTransition when the state machine does not allow the
+// change and ErrConflict when another transaction changed the status first.
+func changeStatus[T any](ctx context.Context, tx repositories.Repository, animals repositories.AnimalRepository[T], resource string, id uuid.UUID, status func(T) string, to string) (*models.Transition, error) {
+	before, err := animals.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, fmt.Errorf("row with id=%v cannot change status because it doesn't exist: %w", id, ErrNotFound)
+		}
+		return nil, err
+	}
+
+	from := status(*before)
+	if !CanTransition(from, to) {
+		return nil, fmt.Errorf("row with id=%v cannot change status from %s to %s: %w", id, from, to, ErrInvalidTransition)
+	}
+	if err := animals.SetStatus(ctx, id, from, to); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, fmt.Errorf("row with id=%v changed status concurrently: %w", id, ErrConflict)
+		}
+		return nil, err
+	}
+
+	after, err := animals.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := recordAudit(ctx, tx.Audit(), resource, id, models.AuditActionUpdate, before, after); err != nil {
+		return nil, err
+	}
+
+	transition := &models.Transition{
+		ID:         uuid.New(),
+		Resource:   resource,
+		ResourceID: id,
+		From:       from,
+		To:         to,
+		Actor:      contexts.Actor(ctx),
+		RequestID:  contexts.RequestID(ctx),
+		Timestamp:  time.Now().UTC(),
+	}
+	if err := tx.Transitions().Create(ctx, transition); err != nil {
+		return nil, err
+	}
+
+	slog.DebugContext(ctx, "changed status", "resource", resource, "resource_id", id, "from", from, "to", to, "actor", transition.Actor)
+	return transition, nil
+}
@@ -0,0 +1,122 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/one-byte-data/go-api-sample/internal/contexts"
+	"github.com/one-byte-data/go-api-sample/internal/models"
+	"github.com/one-byte-data/go-api-sample/internal/repositories"
+)
+
+func TestCanTransition(t *testing.T) {
+	tests := []struct {
+		from string
+		to   string
+		want bool
+	}{
+		{from: models.StatusIntake, to: models.StatusAvailable, want: true},
+		{from: models.StatusIntake, to: models.StatusAdopted, want: false},
+		{from: models.StatusAvailable, to: models.StatusOnHold, want: true},
+		{from: models.StatusOnHold, to: models.StatusAdopted, want: true},
+		{from: models.StatusAdopted, to: models.StatusAvailable, want: false},
+		{from: models.StatusAdopted, to: models.StatusReturned, want: true},
+		{from: models.StatusReturned, to: models.StatusAvailable, want: true},
+		{from: models.StatusAvailable, to: models.StatusAvailable, want: false},
+		{from: "lost", to: models.StatusAvailable, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.from+" to "+tt.to, func(t *testing.T) {
+			if got := CanTransition(tt.from, tt.to); got != tt.want {
+				t.Errorf("CanTransition() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_catsService_Transition(t *testing.T) {
+	ctx := contexts.WithActor(context.Background(), "alice")
+	repo := repositories.NewMemory()
+	s := NewCatsService(repo)
+	cat := &models.Cat{ID: uuid.New(), Name: "Nacho", Breed: "Tabby", Color: "Orange", Birthdate: time.Now().AddDate(-3, 0, 0), Weight: 10, Status: models.StatusAdopted}
+	if _, err := s.Add(ctx, cat); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if cat.Status != models.StatusIntake {
+		t.Fatalf("Add() status = %q, want new cats to start at %q", cat.Status, models.StatusIntake)
+	}
+
+	tests := []struct {
+		name       string
+		id         uuid.UUID
+		status     string
+		wantErr    error
+		wantStatus string
+	}{
+		{
+			name:       "Should move along the state machine",
+			id:         cat.ID,
+			status:     models.StatusAvailable,
+			wantStatus: models.StatusAvailable,
+		},
+		{
+			name:       "Should reject a transition the state machine does not allow",
+			id:         cat.ID,
+			status:     models.StatusReturned,
+			wantErr:    ErrInvalidTransition,
+			wantStatus: models.StatusAvailable,
+		},
+		{
+			name:       "Should reject an unknown status",
+			id:         cat.ID,
+			status:     "lost",
+			wantErr:    ErrInvalidTransition,
+			wantStatus: models.StatusAvailable,
+		},
+		{
+			name:    "Should not change a cat that does not exist",
+			id:      uuid.New(),
+			status:  models.StatusAvailable,
+			wantErr: ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transition, err := s.Transition(ctx, tt.id, tt.status)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Transition() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && (transition.To != tt.status || transition.Actor != "alice" || transition.Timestamp.IsZero()) {
+				t.Errorf("Transition() = %+v, want a transition to %s by alice", transition, tt.status)
+			}
+			if tt.wantStatus == "" {
+				return
+			}
+			got, err := s.GetOne(ctx, tt.id)
+			if err != nil {
+				t.Fatalf("GetOne() error = %v", err)
+			}
+			if got.Status != tt.wantStatus {
+				t.Errorf("GetOne() status = %q, want %q", got.Status, tt.wantStatus)
+			}
+		})
+	}
+
+	transitions, err := s.Transitions(ctx, cat.ID)
+	if err != nil {
+		t.Fatalf("Transitions() error = %v", err)
+	}
+	if len(transitions) != 1 || transitions[0].From != models.StatusIntake || transitions[0].To != models.StatusAvailable {
+		t.Errorf("Transitions() = %+v, want the move from intake to available", transitions)
+	}
+	history, err := NewAuditService(repo).History(ctx, CatsResource, cat.ID)
+	if err != nil {
+		t.Fatalf("History() error = %v", err)
+	}
+	if len(history) != 2 || history[1].Action != models.AuditActionUpdate {
+		t.Errorf("History() = %+v, want the create and the status update", history)
+	}
+}
@@ -0,0 +1,105 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/one-byte-data/go-api-sample/internal/contexts"
+	"github.com/one-byte-data/go-api-sample/internal/models"
+	"github.com/one-byte-data/go-api-sample/internal/repositories"
+)
+
+type TenantsService interface {
+	// Add returns ErrInvalidTenant when the ID is malformed and ErrConflict
+	// when the tenant already exists.
+	Add(ctx context.Context, tenant *models.Tenant) error
+	// Delete returns ErrConflict for the default tenant and for tenants
+	// that still have cats or dogs. Their audit log is kept.
+	Delete(ctx context.Context, id string) error
+	Get(ctx context.Context) ([]models.Tenant, error)
+	GetOne(ctx context.Context, id string) (*models.Tenant, error)
+}
+
+type tenantsService struct {
+	repo repositories.Repository
+}
+
+func NewTenantsService(repo repositories.Repository) TenantsService {
+	return &tenantsService{
+		repo: repo,
+	}
+}
+
+func (s *tenantsService) Add(ctx context.Context, tenant *models.Tenant) error {
+	if !models.ValidTenantID(tenant.ID) {
+		return fmt.Errorf("%q is not a valid tenant ID: %w", tenant.ID, ErrInvalidTenant)
+	}
+	tenant.CreatedAt = time.Now().UTC()
+
+	return s.repo.Transaction(ctx, func(tx repositories.Repository) error {
+		_, err := tx.Tenants().Get(ctx, tenant.ID)
+		switch {
+		case err == nil:
+			return fmt.Errorf("tenant %q already exists: %w", tenant.ID, ErrConflict)
+		case !errors.Is(err, ErrNotFound):
+			return err
+		}
+		return tx.Tenants().Create(ctx, tenant)
+	})
+}
+
+func (s *tenantsService) Delete(ctx context.Context, id string) error {
+	if id == contexts.DefaultTenant {
+		return fmt.Errorf("the default tenant cannot be deleted: %w", ErrConflict)
+	}
+
+	return s.repo.Transaction(ctx, func(tx repositories.Repository) error {
+		if _, err := tx.Tenants().Get(ctx, id); err != nil {
+			return err
+		}
+
+		tenantCtx := contexts.WithTenant(ctx, id)
+		cats, err := tx.Cats().Count(tenantCtx, nil)
+		if err != nil {
+			return err
+		}
+		dogs, err := tx.Dogs().Count(tenantCtx, nil)
+		if err != nil {
+			return err
+		}
+		if cats+dogs > 0 {
+			return fmt.Errorf("tenant %q still has %d cats and %d dogs: %w", id, cats, dogs, ErrConflict)
+		}
+
+		return tx.Tenants().Delete(ctx, id)
+	})
+}
+
+func (s *tenantsService) Get(ctx context.Context) ([]models.Tenant, error) {
+	return s.repo.Tenants().Find(ctx)
+}
+
+func (s *tenantsService) GetOne(ctx context.Context, id string) (*models.Tenant, error) {
+	return s.repo.Tenants().Get(ctx, id)
+}
+
+// WithTenant scopes ctx to the tenant with the ID after checking it exists.
+// An empty ID leaves ctx to the default tenant. It returns ErrInvalidTenant
+// when the ID is malformed or there is no such tenant.
+func WithTenant(ctx context.Context, tenants TenantsService, id string) (context.Context, error) {
+	if id == "" || id == contexts.DefaultTenant {
+		return ctx, nil
+	}
+	if !models.ValidTenantID(id) {
+		return nil, fmt.Errorf("%q is not a valid tenant ID: %w", id, ErrInvalidTenant)
+	}
+	if _, err := tenants.GetOne(ctx, id); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, fmt.Errorf("tenant %q does not exist: %w", id, ErrInvalidTenant)
+		}
+		return nil, err
+	}
+	return contexts.WithTenant(ctx, id), nil
+}
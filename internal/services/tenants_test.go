@@ -0,0 +1,106 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/one-byte-data/go-api-sample/internal/contexts"
+	"github.com/one-byte-data/go-api-sample/internal/models"
+	"github.com/one-byte-data/go-api-sample/internal/repositories"
+)
+
+func Test_tenantsService(t *testing.T) {
+	ctx := context.Background()
+	repo := repositories.NewMemory()
+	s := NewTenantsService(repo)
+
+	if err := s.Add(ctx, &models.Tenant{ID: "shelter-a", Name: "Shelter A"}); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if err := s.Add(ctx, &models.Tenant{ID: "shelter-b", Name: "Shelter B"}); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	cat := &models.Cat{ID: uuid.New(), Name: "Nacho", Breed: "Tabby", Color: "Orange", Birthdate: time.Now(), Weight: 5}
+	if err := repo.Cats().Create(contexts.WithTenant(ctx, "shelter-b"), cat); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		call    func() error
+		wantErr error
+	}{
+		{
+			name:    "Should not add a tenant with an invalid ID",
+			call:    func() error { return s.Add(ctx, &models.Tenant{ID: "Shelter C", Name: "Shelter C"}) },
+			wantErr: ErrInvalidTenant,
+		},
+		{
+			name:    "Should not add a tenant twice",
+			call:    func() error { return s.Add(ctx, &models.Tenant{ID: "shelter-a", Name: "Shelter A"}) },
+			wantErr: ErrConflict,
+		},
+		{
+			name:    "Should not delete the default tenant",
+			call:    func() error { return s.Delete(ctx, contexts.DefaultTenant) },
+			wantErr: ErrConflict,
+		},
+		{
+			name:    "Should not delete a tenant with cats",
+			call:    func() error { return s.Delete(ctx, "shelter-b") },
+			wantErr: ErrConflict,
+		},
+		{
+			name:    "Should delete an empty tenant",
+			call:    func() error { return s.Delete(ctx, "shelter-a") },
+			wantErr: nil,
+		},
+		{
+			name:    "Should not delete a missing tenant",
+			call:    func() error { return s.Delete(ctx, "shelter-a") },
+			wantErr: ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWithTenant(t *testing.T) {
+	ctx := context.Background()
+	tenants := NewTenantsService(repositories.NewMemory())
+	if err := tenants.Add(ctx, &models.Tenant{ID: "shelter-a", Name: "Shelter A"}); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		id      string
+		want    string
+		wantErr error
+	}{
+		{name: "Should use the default tenant without an ID", id: "", want: contexts.DefaultTenant},
+		{name: "Should use the default tenant", id: contexts.DefaultTenant, want: contexts.DefaultTenant},
+		{name: "Should use an existing tenant", id: "shelter-a", want: "shelter-a"},
+		{name: "Should not use a missing tenant", id: "shelter-b", wantErr: ErrInvalidTenant},
+		{name: "Should not use an invalid ID", id: "-shelter", wantErr: ErrInvalidTenant},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := WithTenant(ctx, tenants, tt.id)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("WithTenant() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && contexts.Tenant(got) != tt.want {
+				t.Errorf("WithTenant() tenant = %v, want %v", contexts.Tenant(got), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,367 @@
+package services
+
+import (
+	"context"
+
+	"github.com/one-byte-data/go-api-sample/internal/models"
+	"github.com/one-byte-data/go-api-sample/internal/tracing"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type tracedCatsService struct {
+	next CatsService
+}
+
+// NewTracedCatsService wraps a CatsService with a span per method call.
+func NewTracedCatsService(next CatsService) CatsService {
+	return &tracedCatsService{
+		next: next,
+	}
+}
+
+func (s *tracedCatsService) Add(ctx context.Context, cat *models.Cat) (*uuid.UUID, error) {
+	ctx, span := startSpan(ctx, "CatsService.Add")
+	defer span.End()
+
+	id, err := s.next.Add(ctx, cat)
+	return id, endSpan(span, err)
+}
+
+func (s *tracedCatsService) Count(ctx context.Context, filter interface{}) (int64, error) {
+	ctx, span := startSpan(ctx, "CatsService.Count")
+	defer span.End()
+
+	count, err := s.next.Count(ctx, filter)
+	return count, endSpan(span, err)
+}
+
+func (s *tracedCatsService) Delete(ctx context.Context, id uuid.UUID) error {
+	ctx, span := startSpan(ctx, "CatsService.Delete", attribute.String("cat.id", id.String()))
+	defer span.End()
+
+	return endSpan(span, s.next.Delete(ctx, id))
+}
+
+func (s *tracedCatsService) Get(ctx context.Context, filter interface{}) ([]models.Cat, error) {
+	ctx, span := startSpan(ctx, "CatsService.Get")
+	defer span.End()
+
+	cats, err := s.next.Get(ctx, filter)
+	span.SetAttributes(attribute.Int("cats.count", len(cats)))
+	return cats, endSpan(span, err)
+}
+
+func (s *tracedCatsService) GetOne(ctx context.Context, id uuid.UUID) (*models.Cat, error) {
+	ctx, span := startSpan(ctx, "CatsService.GetOne", attribute.String("cat.id", id.String()))
+	defer span.End()
+
+	cat, err := s.next.GetOne(ctx, id)
+	return cat, endSpan(span, err)
+}
+
+func (s *tracedCatsService) Update(ctx context.Context, id uuid.UUID, cat *models.Cat) error {
+	ctx, span := startSpan(ctx, "CatsService.Update", attribute.String("cat.id", id.String()))
+	defer span.End()
+
+	return endSpan(span, s.next.Update(ctx, id, cat))
+}
+
+func (s *tracedCatsService) Transition(ctx context.Context, id uuid.UUID, status string) (*models.Transition, error) {
+	ctx, span := startSpan(ctx, "CatsService.Transition", attribute.String("cat.id", id.String()), attribute.String("cat.status", status))
+	defer span.End()
+
+	transition, err := s.next.Transition(ctx, id, status)
+	return transition, endSpan(span, err)
+}
+
+func (s *tracedCatsService) Transitions(ctx context.Context, id uuid.UUID) ([]models.Transition, error) {
+	ctx, span := startSpan(ctx, "CatsService.Transitions", attribute.String("cat.id", id.String()))
+	defer span.End()
+
+	transitions, err := s.next.Transitions(ctx, id)
+	return transitions, endSpan(span, err)
+}
+
+type tracedDogsService struct {
+	next DogsService
+}
+
+// NewTracedDogsService wraps a DogsService with a span per method call.
+func NewTracedDogsService(next DogsService) DogsService {
+	return &tracedDogsService{
+		next: next,
+	}
+}
+
+func (s *tracedDogsService) Add(ctx context.Context, dog *models.Dog) (*uuid.UUID, error) {
+	ctx, span := startSpan(ctx, "DogsService.Add")
+	defer span.End()
+
+	id, err := s.next.Add(ctx, dog)
+	return id, endSpan(span, err)
+}
+
+func (s *tracedDogsService) Count(ctx context.Context, filter interface{}) (int64, error) {
+	ctx, span := startSpan(ctx, "DogsService.Count")
+	defer span.End()
+
+	count, err := s.next.Count(ctx, filter)
+	return count, endSpan(span, err)
+}
+
+func (s *tracedDogsService) Delete(ctx context.Context, id uuid.UUID) error {
+	ctx, span := startSpan(ctx, "DogsService.Delete", attribute.String("dog.id", id.String()))
+	defer span.End()
+
+	return endSpan(span, s.next.Delete(ctx, id))
+}
+
+func (s *tracedDogsService) Get(ctx context.Context, filter interface{}) ([]models.Dog, error) {
+	ctx, span := startSpan(ctx, "DogsService.Get")
+	defer span.End()
+
+	dogs, err := s.next.Get(ctx, filter)
+	span.SetAttributes(attribute.Int("dogs.count", len(dogs)))
+	return dogs, endSpan(span, err)
+}
+
+func (s *tracedDogsService) GetOne(ctx context.Context, id uuid.UUID) (*models.Dog, error) {
+	ctx, span := startSpan(ctx, "DogsService.GetOne", attribute.String("dog.id", id.String()))
+	defer span.End()
+
+	dog, err := s.next.GetOne(ctx, id)
+	return dog, endSpan(span, err)
+}
+
+func (s *tracedDogsService) Update(ctx context.Context, id uuid.UUID, dog *models.Dog) error {
+	ctx, span := startSpan(ctx, "DogsService.Update", attribute.String("dog.id", id.String()))
+	defer span.End()
+
+	return endSpan(span, s.next.Update(ctx, id, dog))
+}
+
+func (s *tracedDogsService) Transition(ctx context.Context, id uuid.UUID, status string) (*models.Transition, error) {
+	ctx, span := startSpan(ctx, "DogsService.Transition", attribute.String("dog.id", id.String()), attribute.String("dog.status", status))
+	defer span.End()
+
+	transition, err := s.next.Transition(ctx, id, status)
+	return transition, endSpan(span, err)
+}
+
+func (s *tracedDogsService) Transitions(ctx context.Context, id uuid.UUID) ([]models.Transition, error) {
+	ctx, span := startSpan(ctx, "DogsService.Transitions", attribute.String("dog.id", id.String()))
+	defer span.End()
+
+	transitions, err := s.next.Transitions(ctx, id)
+	return transitions, endSpan(span, err)
+}
+
+type tracedAuditService struct {
+	next AuditService
+}
+
+// NewTracedAuditService wraps an AuditService with a span per method call.
+func NewTracedAuditService(next AuditService) AuditService {
+	return &tracedAuditService{
+		next: next,
+	}
+}
+
+func (s *tracedAuditService) Get(ctx context.Context, filter *AuditFilter) ([]models.AuditEntry, error) {
+	ctx, span := startSpan(ctx, "AuditService.Get")
+	defer span.End()
+
+	entries, err := s.next.Get(ctx, filter)
+	return entries, endSpan(span, err)
+}
+
+func (s *tracedAuditService) History(ctx context.Context, resource string, id uuid.UUID) ([]models.AuditEntry, error) {
+	ctx, span := startSpan(ctx, "AuditService.History", attribute.String("audit.resource", resource), attribute.String("audit.resource_id", id.String()))
+	defer span.End()
+
+	entries, err := s.next.History(ctx, resource, id)
+	return entries, endSpan(span, err)
+}
+
+type tracedTenantsService struct {
+	next TenantsService
+}
+
+// NewTracedTenantsService wraps a TenantsService with a span per method call.
+func NewTracedTenantsService(next TenantsService) TenantsService {
+	return &tracedTenantsService{
+		next: next,
+	}
+}
+
+func (s *tracedTenantsService) Add(ctx context.Context, tenant *models.Tenant) error {
+	ctx, span := startSpan(ctx, "TenantsService.Add", attribute.String("tenant.id", tenant.ID))
+	defer span.End()
+
+	return endSpan(span, s.next.Add(ctx, tenant))
+}
+
+func (s *tracedTenantsService) Delete(ctx context.Context, id string) error {
+	ctx, span := startSpan(ctx, "TenantsService.Delete", attribute.String("tenant.id", id))
+	defer span.End()
+
+	return endSpan(span, s.next.Delete(ctx, id))
+}
+
+func (s *tracedTenantsService) Get(ctx context.Context) ([]models.Tenant, error) {
+	ctx, span := startSpan(ctx, "TenantsService.Get")
+	defer span.End()
+
+	tenants, err := s.next.Get(ctx)
+	return tenants, endSpan(span, err)
+}
+
+func (s *tracedTenantsService) GetOne(ctx context.Context, id string) (*models.Tenant, error) {
+	ctx, span := startSpan(ctx, "TenantsService.GetOne", attribute.String("tenant.id", id))
+	defer span.End()
+
+	tenant, err := s.next.GetOne(ctx, id)
+	return tenant, endSpan(span, err)
+}
+
+type tracedBreedsService struct {
+	next BreedsService
+}
+
+// NewTracedBreedsService wraps a BreedsService with a span per method call.
+func NewTracedBreedsService(next BreedsService) BreedsService {
+	return &tracedBreedsService{
+		next: next,
+	}
+}
+
+func (s *tracedBreedsService) Add(ctx context.Context, breed *models.Breed) error {
+	ctx, span := startSpan(ctx, "BreedsService.Add", attribute.String("breed.species", breed.Species))
+	defer span.End()
+
+	return endSpan(span, s.next.Add(ctx, breed))
+}
+
+func (s *tracedBreedsService) Delete(ctx context.Context, species string, id uuid.UUID) error {
+	ctx, span := startSpan(ctx, "BreedsService.Delete", attribute.String("breed.species", species), attribute.String("breed.id", id.String()))
+	defer span.End()
+
+	return endSpan(span, s.next.Delete(ctx, species, id))
+}
+
+func (s *tracedBreedsService) Get(ctx context.Context, species string) ([]models.Breed, error) {
+	ctx, span := startSpan(ctx, "BreedsService.Get", attribute.String("breed.species", species))
+	defer span.End()
+
+	breeds, err := s.next.Get(ctx, species)
+	return breeds, endSpan(span, err)
+}
+
+func (s *tracedBreedsService) GetOne(ctx context.Context, species string, id uuid.UUID) (*models.Breed, error) {
+	ctx, span := startSpan(ctx, "BreedsService.GetOne", attribute.String("breed.species", species), attribute.String("breed.id", id.String()))
+	defer span.End()
+
+	breed, err := s.next.GetOne(ctx, species, id)
+	return breed, endSpan(span, err)
+}
+
+func (s *tracedBreedsService) Update(ctx context.Context, species string, id uuid.UUID, breed *models.Breed) error {
+	ctx, span := startSpan(ctx, "BreedsService.Update", attribute.String("breed.species", species), attribute.String("breed.id", id.String()))
+	defer span.End()
+
+	return endSpan(span, s.next.Update(ctx, species, id, breed))
+}
+
+type tracedApplicationsService struct {
+	next ApplicationsService
+}
+
+// NewTracedApplicationsService wraps an ApplicationsService with a span per
+// method call.
+func NewTracedApplicationsService(next ApplicationsService) ApplicationsService {
+	return &tracedApplicationsService{
+		next: next,
+	}
+}
+
+func (s *tracedApplicationsService) Add(ctx context.Context, application *models.Application) error {
+	ctx, span := startSpan(ctx, "ApplicationsService.Add", attribute.String("application.species", application.Species), attribute.String("application.animal_id", application.AnimalID.String()))
+	defer span.End()
+
+	return endSpan(span, s.next.Add(ctx, application))
+}
+
+func (s *tracedApplicationsService) Delete(ctx context.Context, id uuid.UUID) error {
+	ctx, span := startSpan(ctx, "ApplicationsService.Delete", attribute.String("application.id", id.String()))
+	defer span.End()
+
+	return endSpan(span, s.next.Delete(ctx, id))
+}
+
+func (s *tracedApplicationsService) Get(ctx context.Context, filter *ApplicationFilter) ([]models.Application, error) {
+	ctx, span := startSpan(ctx, "ApplicationsService.Get")
+	defer span.End()
+
+	applications, err := s.next.Get(ctx, filter)
+	span.SetAttributes(attribute.Int("applications.count", len(applications)))
+	return applications, endSpan(span, err)
+}
+
+func (s *tracedApplicationsService) GetOne(ctx context.Context, id uuid.UUID) (*models.Application, error) {
+	ctx, span := startSpan(ctx, "ApplicationsService.GetOne", attribute.String("application.id", id.String()))
+	defer span.End()
+
+	application, err := s.next.GetOne(ctx, id)
+	return application, endSpan(span, err)
+}
+
+func (s *tracedApplicationsService) Update(ctx context.Context, id uuid.UUID, application *models.Application) error {
+	ctx, span := startSpan(ctx, "ApplicationsService.Update", attribute.String("application.id", id.String()))
+	defer span.End()
+
+	return endSpan(span, s.next.Update(ctx, id, application))
+}
+
+func (s *tracedApplicationsService) Review(ctx context.Context, id uuid.UUID, status string) (*models.Application, error) {
+	ctx, span := startSpan(ctx, "ApplicationsService.Review", attribute.String("application.id", id.String()), attribute.String("application.status", status))
+	defer span.End()
+
+	application, err := s.next.Review(ctx, id, status)
+	return application, endSpan(span, err)
+}
+
+type tracedStatsService struct {
+	next StatsService
+}
+
+// NewTracedStatsService wraps a StatsService with a span per method call.
+func NewTracedStatsService(next StatsService) StatsService {
+	return &tracedStatsService{
+		next: next,
+	}
+}
+
+func (s *tracedStatsService) Get(ctx context.Context, species string, filter *AnimalFilter) (*models.AnimalStats, error) {
+	ctx, span := startSpan(ctx, "StatsService.Get", attribute.String("stats.species", species))
+	defer span.End()
+
+	stats, err := s.next.Get(ctx, species, filter)
+	return stats, endSpan(span, err)
+}
+
+func startSpan(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return tracing.Tracer().Start(ctx, name, trace.WithAttributes(attributes...))
+}
+
+// endSpan marks the span as failed when err is set and passes err through.
+func endSpan(span trace.Span, err error) error {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
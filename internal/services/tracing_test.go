@@ -0,0 +1,109 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/one-byte-data/go-api-sample/internal/models"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type stubCatsService struct {
+	err error
+}
+
+func (s *stubCatsService) Add(ctx context.Context, cat *models.Cat) (*uuid.UUID, error) {
+	return &cat.ID, s.err
+}
+
+func (s *stubCatsService) Count(ctx context.Context, filter interface{}) (int64, error) {
+	return 0, s.err
+}
+
+func (s *stubCatsService) Delete(ctx context.Context, id uuid.UUID) error {
+	return s.err
+}
+
+func (s *stubCatsService) Get(ctx context.Context, filter interface{}) ([]models.Cat, error) {
+	return []models.Cat{}, s.err
+}
+
+func (s *stubCatsService) GetOne(ctx context.Context, id uuid.UUID) (*models.Cat, error) {
+	return &models.Cat{ID: id}, s.err
+}
+
+func (s *stubCatsService) Update(ctx context.Context, id uuid.UUID, cat *models.Cat) error {
+	return s.err
+}
+
+func (s *stubCatsService) Transition(ctx context.Context, id uuid.UUID, status string) (*models.Transition, error) {
+	return &models.Transition{ResourceID: id, To: status}, s.err
+}
+
+func (s *stubCatsService) Transitions(ctx context.Context, id uuid.UUID) ([]models.Transition, error) {
+	return []models.Transition{}, s.err
+}
+
+func Test_tracedCatsService(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	tests := []struct {
+		name       string
+		err        error
+		call       func(s CatsService) error
+		wantName   string
+		wantStatus codes.Code
+	}{
+		{
+			name: "Should trace Get",
+			call: func(s CatsService) error {
+				_, err := s.Get(context.Background(), nil)
+				return err
+			},
+			wantName:   "CatsService.Get",
+			wantStatus: codes.Unset,
+		},
+		{
+			name: "Should trace a failing Update",
+			err:  errors.New("row cannot be updated"),
+			call: func(s CatsService) error {
+				return s.Update(context.Background(), uuid.New(), &models.Cat{})
+			},
+			wantName:   "CatsService.Update",
+			wantStatus: codes.Error,
+		},
+		{
+			name: "Should trace an invalid Transition",
+			err:  ErrInvalidTransition,
+			call: func(s CatsService) error {
+				_, err := s.Transition(context.Background(), uuid.New(), models.StatusReturned)
+				return err
+			},
+			wantName:   "CatsService.Transition",
+			wantStatus: codes.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewTracedCatsService(&stubCatsService{err: tt.err})
+			if err := tt.call(s); !errors.Is(err, tt.err) {
+				t.Errorf("tracedCatsService error = %v, want %v", err, tt.err)
+			}
+
+			spans := recorder.Ended()
+			span := spans[len(spans)-1]
+			if span.Name() != tt.wantName {
+				t.Errorf("tracedCatsService span name = %v, want %v", span.Name(), tt.wantName)
+			}
+			if span.Status().Code != tt.wantStatus {
+				t.Errorf("tracedCatsService span status = %v, want %v", span.Status().Code, tt.wantStatus)
+			}
+		})
+	}
+}
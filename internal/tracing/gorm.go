@@ -0,0 +1,85 @@
+package tracing
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const spanKey = "tracing:span"
+
+// GormPlugin starts a span for every statement GORM runs. The spans are
+// children of the span in the statement context, so services must pass
+// their context with db.WithContext.
+type GormPlugin struct{}
+
+func (p *GormPlugin) Name() string {
+	return "tracing"
+}
+
+func (p *GormPlugin) Initialize(db *gorm.DB) error {
+	callbacks := []struct {
+		operation string
+		before    registerer
+		after     registerer
+	}{
+		{"create", db.Callback().Create().Before("gorm:create"), db.Callback().Create().After("gorm:create")},
+		{"query", db.Callback().Query().Before("gorm:query"), db.Callback().Query().After("gorm:query")},
+		{"update", db.Callback().Update().Before("gorm:update"), db.Callback().Update().After("gorm:update")},
+		{"delete", db.Callback().Delete().Before("gorm:delete"), db.Callback().Delete().After("gorm:delete")},
+		{"row", db.Callback().Row().Before("gorm:row"), db.Callback().Row().After("gorm:row")},
+		{"raw", db.Callback().Raw().Before("gorm:raw"), db.Callback().Raw().After("gorm:raw")},
+	}
+
+	for _, callback := range callbacks {
+		if err := callback.before.Register("tracing:before_"+callback.operation, before(callback.operation)); err != nil {
+			return err
+		}
+		if err := callback.after.Register("tracing:after_"+callback.operation, after); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type registerer interface {
+	Register(name string, fn func(*gorm.DB)) error
+}
+
+func before(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		ctx, span := Tracer().Start(db.Statement.Context, "gorm."+operation, trace.WithSpanKind(trace.SpanKindClient))
+		span.SetAttributes(
+			semconv.DBSystemPostgreSQL,
+			semconv.DBOperationKey.String(operation),
+			semconv.DBSQLTableKey.String(db.Statement.Table),
+		)
+		db.Statement.Context = ctx
+		db.InstanceSet(spanKey, span)
+	}
+}
+
+func after(db *gorm.DB) {
+	value, ok := db.InstanceGet(spanKey)
+	if !ok {
+		return
+	}
+	span, ok := value.(trace.Span)
+	if !ok {
+		return
+	}
+	defer span.End()
+
+	span.SetAttributes(
+		semconv.DBStatementKey.String(db.Statement.SQL.String()),
+		attribute.Int64("db.rows_affected", db.Statement.RowsAffected),
+	)
+	if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+		span.RecordError(db.Error)
+		span.SetStatus(codes.Error, db.Error.Error())
+	}
+}
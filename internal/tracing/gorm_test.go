@@ -0,0 +1,90 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type widget struct {
+	ID   int
+	Name string
+}
+
+func TestGormPlugin(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	gdb, err := gorm.Open(postgres.Dialector{
+		Config: &postgres.Config{Conn: db},
+	})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	if err := gdb.Use(&GormPlugin{}); err != nil {
+		t.Fatalf("Use() error = %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		queryErr   error
+		wantStatus codes.Code
+	}{
+		{
+			name:       "Should trace a successful query as a child span",
+			queryErr:   nil,
+			wantStatus: codes.Unset,
+		},
+		{
+			name:       "Should mark a failed query as an error",
+			queryErr:   errors.New("connection reset"),
+			wantStatus: codes.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := mock.ExpectQuery(`SELECT \* FROM "widgets"`)
+			if tt.queryErr != nil {
+				query.WillReturnError(tt.queryErr)
+			} else {
+				query.WillReturnRows(sqlmock.NewRows([]string{"id", "name"}))
+			}
+
+			ctx, parent := Tracer().Start(context.Background(), "parent")
+			widgets := make([]widget, 0)
+			gdb.WithContext(ctx).Find(&widgets)
+			parent.End()
+
+			spans := recorder.Ended()
+			if len(spans) < 2 {
+				t.Fatalf("GormPlugin recorded %v spans, want 2", len(spans))
+			}
+			span := spans[len(spans)-2]
+
+			if span.Name() != "gorm.query" {
+				t.Errorf("GormPlugin span name = %v, want gorm.query", span.Name())
+			}
+			if span.Parent().SpanID() != parent.SpanContext().SpanID() {
+				t.Errorf("GormPlugin span is not a child of the statement context")
+			}
+			if span.Status().Code != tt.wantStatus {
+				t.Errorf("GormPlugin span status = %v, want %v", span.Status().Code, tt.wantStatus)
+			}
+		})
+	}
+}
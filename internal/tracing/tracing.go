@@ -0,0 +1,131 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	ServiceName     = "go-api-sample"
+	instrumentation = "github.com/one-byte-data/go-api-sample"
+)
+
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterFile   = "file"
+	ExporterOTLP   = "otlp"
+)
+
+// Config selects where spans are exported and which fraction of new traces
+// is sampled. Traces started upstream keep the sampling decision carried by
+// their traceparent header. The OTLP exporter is configured through the
+// standard OTEL_EXPORTER_OTLP_* environment variables.
+type Config struct {
+	Exporter       string
+	File           string
+	SampleRatio    float64
+	ServiceVersion string
+}
+
+func ConfigFromEnv() (Config, error) {
+	config := Config{
+		Exporter:    os.Getenv("TRACING_EXPORTER"),
+		File:        os.Getenv("TRACING_FILE"),
+		SampleRatio: 1,
+	}
+	if config.Exporter == "" {
+		config.Exporter = ExporterNone
+	}
+
+	if value := os.Getenv("TRACING_SAMPLE_RATIO"); value != "" {
+		ratio, err := strconv.ParseFloat(value, 64)
+		if err != nil || ratio < 0 || ratio > 1 {
+			return config, fmt.Errorf("TRACING_SAMPLE_RATIO must be a number between 0 and 1, got %q", value)
+		}
+		config.SampleRatio = ratio
+	}
+
+	return config, nil
+}
+
+// Setup installs the global tracer provider and the W3C trace context
+// propagator. The returned function flushes and stops the exporter.
+func Setup(ctx context.Context, config Config) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	exporter, closer, err := newExporter(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	if exporter == nil {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.SampleRatio))),
+		sdktrace.WithResource(resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceNameKey.String(ServiceName),
+			semconv.ServiceVersionKey.String(config.ServiceVersion),
+		)),
+	)
+	otel.SetTracerProvider(provider)
+
+	return func(ctx context.Context) error {
+		err := provider.Shutdown(ctx)
+		if closer != nil {
+			if closeErr := closer.Close(); err == nil {
+				err = closeErr
+			}
+		}
+		return err
+	}, nil
+}
+
+// Tracer returns the tracer used for every span created by this server.
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentation)
+}
+
+func newExporter(ctx context.Context, config Config) (sdktrace.SpanExporter, io.Closer, error) {
+	switch config.Exporter {
+	case ExporterNone:
+		return nil, nil, nil
+	case ExporterStdout:
+		exporter, err := stdouttrace.New()
+		return exporter, nil, err
+	case ExporterFile:
+		if config.File == "" {
+			return nil, nil, fmt.Errorf("TRACING_FILE is required for the %s exporter", ExporterFile)
+		}
+		file, err := os.OpenFile(config.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return nil, nil, err
+		}
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(file))
+		if err != nil {
+			file.Close()
+			return nil, nil, err
+		}
+		return exporter, file, nil
+	case ExporterOTLP:
+		exporter, err := otlptracehttp.New(ctx)
+		return exporter, nil, err
+	default:
+		return nil, nil, fmt.Errorf("unknown tracing exporter %q", config.Exporter)
+	}
+}
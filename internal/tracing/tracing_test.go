@@ -0,0 +1,111 @@
+package tracing
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestConfigFromEnv(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		want    Config
+		wantErr bool
+	}{
+		{
+			name: "Should default to no exporter and sample everything",
+			env:  map[string]string{"TRACING_EXPORTER": "", "TRACING_FILE": "", "TRACING_SAMPLE_RATIO": ""},
+			want: Config{Exporter: ExporterNone, SampleRatio: 1},
+		},
+		{
+			name: "Should read the exporter and sample ratio",
+			env:  map[string]string{"TRACING_EXPORTER": "file", "TRACING_FILE": "/tmp/traces.json", "TRACING_SAMPLE_RATIO": "0.25"},
+			want: Config{Exporter: ExporterFile, File: "/tmp/traces.json", SampleRatio: 0.25},
+		},
+		{
+			name:    "Should not accept a sample ratio above 1",
+			env:     map[string]string{"TRACING_EXPORTER": "", "TRACING_FILE": "", "TRACING_SAMPLE_RATIO": "2"},
+			wantErr: true,
+		},
+		{
+			name:    "Should not accept a sample ratio that is not a number",
+			env:     map[string]string{"TRACING_EXPORTER": "", "TRACING_FILE": "", "TRACING_SAMPLE_RATIO": "half"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			got, err := ConfigFromEnv()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ConfigFromEnv() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("ConfigFromEnv() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetup(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "traces.json")
+
+	tests := []struct {
+		name     string
+		config   Config
+		wantFile bool
+		wantErr  bool
+	}{
+		{
+			name:   "Should set up tracing without an exporter",
+			config: Config{Exporter: ExporterNone, SampleRatio: 1},
+		},
+		{
+			name:     "Should export spans to a file",
+			config:   Config{Exporter: ExporterFile, File: file, SampleRatio: 1},
+			wantFile: true,
+		},
+		{
+			name:    "Should require a file for the file exporter",
+			config:  Config{Exporter: ExporterFile, SampleRatio: 1},
+			wantErr: true,
+		},
+		{
+			name:    "Should not accept an unknown exporter",
+			config:  Config{Exporter: "carrier-pigeon", SampleRatio: 1},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shutdown, err := Setup(context.Background(), tt.config)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Setup() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+
+			_, span := Tracer().Start(context.Background(), "test")
+			span.End()
+
+			if err := shutdown(context.Background()); err != nil {
+				t.Errorf("Setup() shutdown error = %v", err)
+			}
+
+			if tt.wantFile {
+				data, err := os.ReadFile(tt.config.File)
+				if err != nil || len(data) == 0 {
+					t.Errorf("Setup() did not export to %v: %v", tt.config.File, err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,244 @@
+// Package validation holds the domain rules for cats and dogs that go
+// beyond the binding tags of the models: birthdates in the past, plausible
+// ages, known colors, and breeds from the catalogue with weights that fit
+// them.
+//
+// The rules are registered with gin's validator, so binding a request
+// checks them, and Struct applies the same checks in the service layer.
+// The breed is only checked when the context carries the breed the services
+// looked up in the catalogue, see WithBreed.
+package validation
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/one-byte-data/go-api-sample/internal/models"
+)
+
+// The species the rules are kept for. Each has a <species>.json data file,
+// and the breed catalogue files breeds under the same names.
+const (
+	Cats = "cats"
+	Dogs = "dogs"
+)
+
+type breedKey struct{}
+
+//go:embed data/*.json
+var data embed.FS
+
+// now is replaced by the tests.
+var now = time.Now
+
+var rules atomic.Pointer[Rules]
+
+// Species holds the rules of one species. Colors are matched without
+// regard to case.
+type Species struct {
+	MaxAgeYears    int      `json:"max_age_years"`
+	AdultAgeMonths int      `json:"adult_age_months"`
+	Colors         []string `json:"colors"`
+
+	colors map[string]bool
+}
+
+// Rules holds the rules of every species. StrictBreeds rejects breeds the
+// catalogue does not know; otherwise, the default, the services flag them.
+// StrictColors rejects colors the species does not know; otherwise, the
+// default, they are accepted as given.
+type Rules struct {
+	Species      map[string]*Species
+	StrictBreeds bool
+	StrictColors bool
+}
+
+// Default returns the rules bundled with the server.
+func Default() *Rules {
+	sub, err := fs.Sub(data, "data")
+	if err != nil {
+		panic(err)
+	}
+	r, err := Load(sub)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// Load reads the cats.json and dogs.json data files from fsys.
+func Load(fsys fs.FS) (*Rules, error) {
+	r := &Rules{Species: make(map[string]*Species)}
+	for _, name := range []string{Cats, Dogs} {
+		content, err := fs.ReadFile(fsys, name+".json")
+		if err != nil {
+			return nil, err
+		}
+
+		species := new(Species)
+		if err := json.Unmarshal(content, species); err != nil {
+			return nil, fmt.Errorf("%s.json: %w", name, err)
+		}
+		if err := species.index(); err != nil {
+			return nil, fmt.Errorf("%s.json: %w", name, err)
+		}
+		r.Species[name] = species
+	}
+	return r, nil
+}
+
+// RulesFromEnv loads the data files from the VALIDATION_DATA_DIR directory,
+// or the bundled ones when it is not set, and reads BREEDS_STRICT and
+// COLORS_STRICT as booleans (default false).
+func RulesFromEnv() (*Rules, error) {
+	r := Default()
+	if dir := os.Getenv("VALIDATION_DATA_DIR"); dir != "" {
+		var err error
+		if r, err = Load(os.DirFS(dir)); err != nil {
+			return nil, fmt.Errorf("VALIDATION_DATA_DIR must hold the cats.json and dogs.json data files, got %q: %w", dir, err)
+		}
+	}
+
+	for _, setting := range []struct {
+		name   string
+		strict *bool
+	}{
+		{"BREEDS_STRICT", &r.StrictBreeds},
+		{"COLORS_STRICT", &r.StrictColors},
+	} {
+		if value := os.Getenv(setting.name); value != "" {
+			strict, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, fmt.Errorf("%s must be true or false, got %q", setting.name, value)
+			}
+			*setting.strict = strict
+		}
+	}
+	return r, nil
+}
+
+func (s *Species) index() error {
+	if s.MaxAgeYears < 1 {
+		return fmt.Errorf("max_age_years must be positive, got %d", s.MaxAgeYears)
+	}
+
+	s.colors = make(map[string]bool, len(s.Colors))
+	for _, color := range s.Colors {
+		s.colors[strings.ToLower(color)] = true
+	}
+	return nil
+}
+
+// KnownColor reports whether color is known. Colors may be combined with a
+// slash, e.g. White/Brindle, when each part is known.
+func (s *Species) KnownColor(color string) bool {
+	for _, part := range strings.Split(color, "/") {
+		if !s.colors[strings.ToLower(strings.TrimSpace(part))] {
+			return false
+		}
+	}
+	return true
+}
+
+// Use replaces the rules checked from now on.
+func Use(r *Rules) {
+	rules.Store(r)
+}
+
+// Current returns the rules in use.
+func Current() *Rules {
+	return rules.Load()
+}
+
+// WithBreed returns a context telling Struct the breed of the cat or dog
+// looked up in the catalogue, or nil when the catalogue does not know it.
+func WithBreed(ctx context.Context, breed *models.Breed) context.Context {
+	return context.WithValue(ctx, breedKey{}, breed)
+}
+
+// breedFrom returns the breed set by WithBreed, and false when there is
+// none.
+func breedFrom(ctx context.Context) (*models.Breed, bool) {
+	breed, ok := ctx.Value(breedKey{}).(*models.Breed)
+	return breed, ok
+}
+
+// Struct validates obj with its binding tags and, for cats and dogs, the
+// domain rules, the same way binding a request does.
+func Struct(ctx context.Context, obj interface{}) error {
+	if validate, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		return validate.StructCtx(ctx, obj)
+	}
+	return binding.Validator.ValidateStruct(obj)
+}
+
+func init() {
+	Use(Default())
+	if validate, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		validate.RegisterStructValidationCtx(validateAnimal, models.Cat{}, models.Dog{})
+	}
+}
+
+func validateAnimal(ctx context.Context, sl validator.StructLevel) {
+	switch animal := sl.Current().Interface().(type) {
+	case models.Cat:
+		check(ctx, sl, Current(), Cats, animal.Breed, animal.Color, animal.Birthdate, animal.Weight)
+	case models.Dog:
+		check(ctx, sl, Current(), Dogs, animal.Breed, animal.Color, animal.Birthdate, animal.Weight)
+	}
+}
+
+// check reports the fields that break the rules of the species. Empty
+// fields are left to the required tag.
+func check(ctx context.Context, sl validator.StructLevel, r *Rules, name, breedName, color string, birthdate time.Time, weight int) {
+	species := r.Species[name]
+	if species == nil {
+		return
+	}
+	today := now()
+
+	adult := true
+	if !birthdate.IsZero() {
+		switch {
+		case birthdate.After(today):
+			sl.ReportError(birthdate, "birthdate", "Birthdate", "notfuture", "")
+		case birthdate.Before(today.AddDate(-species.MaxAgeYears, 0, 0)):
+			sl.ReportError(birthdate, "birthdate", "Birthdate", "maxage", strconv.Itoa(species.MaxAgeYears))
+		default:
+			adult = !birthdate.After(today.AddDate(0, -species.AdultAgeMonths, 0))
+		}
+	}
+
+	if r.StrictColors && color != "" && !species.KnownColor(color) {
+		sl.ReportError(color, "color", "Color", "color", "")
+	}
+
+	breed, ok := breedFrom(ctx)
+	if !ok {
+		return
+	}
+	if breed == nil {
+		if r.StrictBreeds && breedName != "" {
+			sl.ReportError(breedName, "breed", "Breed", "breed", "")
+		}
+		return
+	}
+	if weight < 1 {
+		return
+	}
+	tooLight := adult && breed.MinWeight > 0 && weight < breed.MinWeight
+	tooHeavy := breed.MaxWeight > 0 && weight > breed.MaxWeight
+	if tooLight || tooHeavy {
+		sl.ReportError(weight, "weight", "Weight", "breedweight", fmt.Sprintf("%d-%d", breed.MinWeight, breed.MaxWeight))
+	}
+}
@@ -0,0 +1,235 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/one-byte-data/go-api-sample/internal/models"
+)
+
+func TestRulesFromEnv(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"cats.json": `{"max_age_years": 20, "adult_age_months": 12, "colors": ["Lavender"]}`,
+		"dogs.json": `{"max_age_years": 20, "adult_age_months": 12, "colors": ["Black"]}`,
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	invalid := t.TempDir()
+	if err := os.WriteFile(filepath.Join(invalid, "cats.json"), []byte(`{"max_age_years": 0}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		dir        string
+		strict     string
+		colors     string
+		wantColor  string
+		wantStrict bool
+		wantColors bool
+		wantErr    bool
+	}{
+		{
+			name:      "Should use the bundled rules",
+			wantColor: "Calico",
+		},
+		{
+			name:      "Should read the data files of the directory",
+			dir:       dir,
+			wantColor: "Lavender",
+		},
+		{
+			name:       "Should reject unknown breeds in strict mode",
+			strict:     "true",
+			wantColor:  "Calico",
+			wantStrict: true,
+		},
+		{
+			name:       "Should reject unknown colors in strict mode",
+			colors:     "true",
+			wantColor:  "Calico",
+			wantColors: true,
+		},
+		{
+			name:    "Should not accept a directory without the data files",
+			dir:     t.TempDir(),
+			wantErr: true,
+		},
+		{
+			name:    "Should not accept invalid rules",
+			dir:     invalid,
+			wantErr: true,
+		},
+		{
+			name:    "Should not accept a strict mode that is not a boolean",
+			strict:  "sometimes",
+			wantErr: true,
+		},
+		{
+			name:    "Should not accept a strict colors mode that is not a boolean",
+			colors:  "sometimes",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("VALIDATION_DATA_DIR", tt.dir)
+			t.Setenv("BREEDS_STRICT", tt.strict)
+			t.Setenv("COLORS_STRICT", tt.colors)
+
+			got, err := RulesFromEnv()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RulesFromEnv() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if !got.Species[Cats].KnownColor(tt.wantColor) {
+				t.Errorf("RulesFromEnv() does not know the color %s", tt.wantColor)
+			}
+			if got.StrictBreeds != tt.wantStrict {
+				t.Errorf("RulesFromEnv() StrictBreeds = %v, want %v", got.StrictBreeds, tt.wantStrict)
+			}
+			if got.StrictColors != tt.wantColors {
+				t.Errorf("RulesFromEnv() StrictColors = %v, want %v", got.StrictColors, tt.wantColors)
+			}
+		})
+	}
+}
+
+func TestStruct(t *testing.T) {
+	today := time.Date(2022, 2, 10, 0, 0, 0, 0, time.UTC)
+	now = func() time.Time { return today }
+	t.Cleanup(func() { now = time.Now })
+
+	lenient := Current()
+	strict := *lenient
+	strict.StrictBreeds = true
+	strict.StrictColors = true
+	Use(&strict)
+	t.Cleanup(func() { Use(lenient) })
+
+	siamese := &models.Breed{Name: "Siamese", MinWeight: 5, MaxWeight: 15}
+	samoyed := &models.Breed{Name: "Samoyed", MinWeight: 35, MaxWeight: 75}
+	known := func(breed *models.Breed) context.Context {
+		return WithBreed(context.Background(), breed)
+	}
+	cat := func(change func(*models.Cat)) *models.Cat {
+		c := &models.Cat{Name: "Nacho", Breed: "Siamese", Color: "Seal/White", Birthdate: today.AddDate(-3, 0, 0), Weight: 9}
+		change(c)
+		return c
+	}
+	dog := func(change func(*models.Dog)) *models.Dog {
+		d := &models.Dog{Name: "Spot", Breed: "Samoyed", Color: "White", Birthdate: today.AddDate(-3, 0, 0), Weight: 50}
+		change(d)
+		return d
+	}
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		obj      interface{}
+		wantRule string
+	}{
+		{
+			name: "Should accept a valid cat",
+			ctx:  known(siamese),
+			obj:  cat(func(c *models.Cat) {}),
+		},
+		{
+			name: "Should match colors regardless of case",
+			ctx:  known(siamese),
+			obj:  cat(func(c *models.Cat) { c.Color = "seal / white" }),
+		},
+		{
+			name:     "Should not accept a birthdate in the future",
+			ctx:      known(siamese),
+			obj:      cat(func(c *models.Cat) { c.Birthdate = today.AddDate(0, 0, 1) }),
+			wantRule: "notfuture",
+		},
+		{
+			name:     "Should not accept an implausible age",
+			ctx:      known(siamese),
+			obj:      cat(func(c *models.Cat) { c.Birthdate = today.AddDate(-31, 0, 0) }),
+			wantRule: "maxage",
+		},
+		{
+			name:     "Should not accept an unknown color",
+			ctx:      known(siamese),
+			obj:      cat(func(c *models.Cat) { c.Color = "blak" }),
+			wantRule: "color",
+		},
+		{
+			name:     "Should not accept a combination with an unknown color",
+			ctx:      known(siamese),
+			obj:      cat(func(c *models.Cat) { c.Color = "White/blak" }),
+			wantRule: "color",
+		},
+		{
+			name:     "Should not accept a breed the catalogue does not know",
+			ctx:      known(nil),
+			obj:      cat(func(c *models.Cat) { c.Breed = "Siamse" }),
+			wantRule: "breed",
+		},
+		{
+			name: "Should not check the breed without a catalogue lookup",
+			ctx:  context.Background(),
+			obj:  cat(func(c *models.Cat) { c.Breed, c.Weight = "Siamse", 40 }),
+		},
+		{
+			name:     "Should not accept a weight above the range of the breed",
+			ctx:      known(siamese),
+			obj:      cat(func(c *models.Cat) { c.Weight = 40 }),
+			wantRule: "breedweight",
+		},
+		{
+			name:     "Should not accept an adult below the range of the breed",
+			ctx:      known(samoyed),
+			obj:      dog(func(d *models.Dog) { d.Weight = 5 }),
+			wantRule: "breedweight",
+		},
+		{
+			name: "Should accept a puppy below the range of the breed",
+			ctx:  known(samoyed),
+			obj:  dog(func(d *models.Dog) { d.Birthdate, d.Weight = today.AddDate(0, -2, 0), 5 }),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Struct(tt.ctx, tt.obj)
+			if tt.wantRule == "" {
+				if err != nil {
+					t.Errorf("Struct() error = %v", err)
+				}
+				return
+			}
+
+			var validationErrors validator.ValidationErrors
+			if !errors.As(err, &validationErrors) || len(validationErrors) != 1 {
+				t.Errorf("Struct() error = %v, want one %s error", err, tt.wantRule)
+				return
+			}
+			if got := validationErrors[0].Tag(); got != tt.wantRule {
+				t.Errorf("Struct() rule = %v, want %v", got, tt.wantRule)
+			}
+		})
+	}
+
+	t.Run("Should accept unknown breeds and colors when strict mode is off", func(t *testing.T) {
+		Use(lenient)
+		t.Cleanup(func() { Use(&strict) })
+
+		if err := Struct(known(nil), cat(func(c *models.Cat) { c.Breed, c.Color = "Siamse", "blak" })); err != nil {
+			t.Errorf("Struct() error = %v", err)
+		}
+	})
+}
@@ -0,0 +1,330 @@
+// Package client is a typed Go client for the go-api-sample REST API.
+//
+//	c, err := client.New("http://localhost:8080", client.WithAPIKey(key))
+//	cat, err := c.Cats.Create(ctx, &client.Cat{Name: "Nacho", ...})
+//	if client.IsNotFound(err) { ... }
+//
+// Failed requests are retried with exponential backoff when that is safe:
+// reads, updates and deletes always, creates because they carry an
+// Idempotency-Key.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	apiKeyHeader         = "X-Api-Key"
+	actorHeader          = "X-Actor"
+	tenantHeader         = "X-Tenant-ID"
+	idempotencyKeyHeader = "Idempotency-Key"
+)
+
+// RetryPolicy retries a request up to MaxAttempts times in total. The wait
+// before retry n is random between zero and MinBackoff*2^n, capped at
+// MaxBackoff, unless the server sent Retry-After. A Retry-After longer than
+// MaxBackoff ends the retries.
+type RetryPolicy struct {
+	MaxAttempts int
+	MinBackoff  time.Duration
+	MaxBackoff  time.Duration
+}
+
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		MinBackoff:  100 * time.Millisecond,
+		MaxBackoff:  5 * time.Second,
+	}
+}
+
+type Client struct {
+	baseURL    *url.URL
+	httpClient *http.Client
+	apiKey     string
+	actor      string
+	tenant     string
+	userAgent  string
+	retry      RetryPolicy
+
+	Cats *Resource[Cat]
+	Dogs *Resource[Dog]
+
+	CatBreeds *Breeds
+	DogBreeds *Breeds
+
+	Applications *Applications
+}
+
+type Option func(*Client)
+
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithAPIKey authenticates requests with the X-Api-Key header.
+func WithAPIKey(key string) Option {
+	return func(c *Client) {
+		c.apiKey = key
+	}
+}
+
+// WithActor names who makes changes in the server's audit log.
+func WithActor(actor string) Option {
+	return func(c *Client) {
+		c.actor = actor
+	}
+}
+
+// WithTenant makes every request for a tenant, the default one otherwise.
+func WithTenant(tenant string) Option {
+	return func(c *Client) {
+		c.tenant = tenant
+	}
+}
+
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = policy
+	}
+}
+
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// New returns a client for the API at baseURL, e.g. http://localhost:8080.
+func New(baseURL string, options ...Option) (*Client, error) {
+	u, err := url.Parse(strings.TrimSuffix(baseURL, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid base URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid base URL %q: the scheme must be http or https", baseURL)
+	}
+
+	c := &Client{
+		baseURL:    u,
+		httpClient: http.DefaultClient,
+		userAgent:  "go-api-sample-client",
+		retry:      DefaultRetryPolicy(),
+	}
+	for _, option := range options {
+		option(c)
+	}
+	c.Cats = &Resource[Cat]{client: c, path: "/cats"}
+	c.Dogs = &Resource[Dog]{client: c, path: "/dogs"}
+	c.CatBreeds = &Breeds{client: c, path: "/breeds/cats"}
+	c.DogBreeds = &Breeds{client: c, path: "/breeds/dogs"}
+	c.Applications = &Applications{client: c}
+	return c, nil
+}
+
+// CallOption changes a single request.
+type CallOption func(*http.Header)
+
+// WithIdempotencyKey replaces the key Create generates, so a create can be
+// retried safely across restarts of the caller.
+func WithIdempotencyKey(key string) CallOption {
+	return func(header *http.Header) {
+		header.Set(idempotencyKeyHeader, key)
+	}
+}
+
+func WithHeader(name string, value string) CallOption {
+	return func(header *http.Header) {
+		header.Set(name, value)
+	}
+}
+
+// Health returns nil when the server is up.
+func (c *Client) Health(ctx context.Context) error {
+	return c.do(ctx, http.MethodGet, "/health", nil, nil, &message{})
+}
+
+// Audit returns the audit entries matching filter.
+func (c *Client) Audit(ctx context.Context, filter AuditFilter) ([]AuditEntry, error) {
+	query := url.Values{}
+	if filter.Resource != "" {
+		query.Set("resource", filter.Resource)
+	}
+	if filter.ResourceID != uuid.Nil {
+		query.Set("resource_id", filter.ResourceID.String())
+	}
+	if filter.Actor != "" {
+		query.Set("actor", filter.Actor)
+	}
+	if !filter.Since.IsZero() {
+		query.Set("since", filter.Since.Format(time.RFC3339))
+	}
+	if !filter.Until.IsZero() {
+		query.Set("until", filter.Until.Format(time.RFC3339))
+	}
+
+	path := "/audit"
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+	entries := make([]AuditEntry, 0)
+	return entries, c.do(ctx, http.MethodGet, path, nil, nil, &entries)
+}
+
+func (c *Client) CacheStats(ctx context.Context) ([]CacheStats, error) {
+	stats := make([]CacheStats, 0)
+	return stats, c.do(ctx, http.MethodGet, "/cache/stats", nil, nil, &stats)
+}
+
+// OpenAPI returns the server's OpenAPI document.
+func (c *Client) OpenAPI(ctx context.Context) (json.RawMessage, error) {
+	var doc json.RawMessage
+	return doc, c.do(ctx, http.MethodGet, "/openapi.json", nil, nil, &doc)
+}
+
+// Metrics returns the server's metrics in the Prometheus text format.
+func (c *Client) Metrics(ctx context.Context) (string, error) {
+	var metrics string
+	return metrics, c.do(ctx, http.MethodGet, "/metrics", nil, nil, &metrics)
+}
+
+// do sends the request, retrying as the policy allows, and decodes the
+// response into out. A *string out receives the raw body.
+func (c *Client) do(ctx context.Context, method string, path string, body interface{}, header http.Header, out interface{}) error {
+	var data []byte
+	if body != nil {
+		var err error
+		if data, err = json.Marshal(body); err != nil {
+			return fmt.Errorf("encoding the request: %w", err)
+		}
+	}
+
+	retryable := method != http.MethodPost || header.Get(idempotencyKeyHeader) != ""
+	attempts := c.retry.MaxAttempts
+	if attempts < 1 || !retryable {
+		attempts = 1
+	}
+
+	var err error
+	for attempt := 0; attempt < attempts; attempt++ {
+		var wait time.Duration
+		wait, err = c.send(ctx, method, path, data, header, out)
+		if wait < 0 || attempt == attempts-1 {
+			break
+		}
+		if wait == 0 {
+			wait = c.backoff(attempt)
+		}
+		if wait > c.retry.MaxBackoff {
+			break
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return errors.Join(err, ctx.Err())
+		case <-timer.C:
+		}
+	}
+	return err
+}
+
+// send makes one attempt. It returns a negative wait when the request must
+// not be retried and the server's Retry-After, if any, otherwise.
+func (c *Client) send(ctx context.Context, method string, path string, data []byte, header http.Header, out interface{}) (time.Duration, error) {
+	var body io.Reader
+	if data != nil {
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL.String()+path, body)
+	if err != nil {
+		return -1, err
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	if data != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", c.userAgent)
+	if c.apiKey != "" {
+		req.Header.Set(apiKeyHeader, c.apiKey)
+	}
+	if c.actor != "" {
+		req.Header.Set(actorHeader, c.actor)
+	}
+	if c.tenant != "" {
+		req.Header.Set(tenantHeader, c.tenant)
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return -1, err
+		}
+		return 0, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= http.StatusBadRequest {
+		err := errorFromResponse(res)
+		switch {
+		case res.StatusCode == http.StatusTooManyRequests,
+			res.StatusCode == http.StatusBadGateway,
+			res.StatusCode == http.StatusServiceUnavailable,
+			res.StatusCode == http.StatusGatewayTimeout,
+			HasCode(err, CodeIdempotencyKeyInUse):
+			return retryAfter(res), err
+		}
+		return -1, err
+	}
+
+	if out == nil || res.StatusCode == http.StatusNoContent {
+		return -1, nil
+	}
+	if raw, ok := out.(*string); ok {
+		data, err := io.ReadAll(res.Body)
+		*raw = string(data)
+		return -1, err
+	}
+	if err := json.NewDecoder(res.Body).Decode(out); err != nil {
+		return -1, fmt.Errorf("decoding the response: %w", err)
+	}
+	return -1, nil
+}
+
+func (c *Client) backoff(attempt int) time.Duration {
+	limit := c.retry.MinBackoff << attempt
+	if limit <= 0 || limit > c.retry.MaxBackoff {
+		limit = c.retry.MaxBackoff
+	}
+	if limit <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(limit)))
+}
+
+func retryAfter(res *http.Response) time.Duration {
+	seconds, err := strconv.Atoi(res.Header.Get("Retry-After"))
+	if err != nil || seconds < 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
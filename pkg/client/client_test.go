@@ -0,0 +1,400 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/one-byte-data/go-api-sample/internal/controllers"
+	"github.com/one-byte-data/go-api-sample/internal/repositories"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func setupServer(t *testing.T) (*Client, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	gdb, err := gorm.Open(postgres.Dialector{
+		Config: &postgres.Config{Conn: db},
+	})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	router, err := controllers.SetupRouter(repositories.NewGorm(gdb))
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+
+	c, err := New(server.URL, WithAPIKey("secret"), WithActor("tests"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c, mock
+}
+
+// setupMemoryServer serves the API from an in-memory repository, for the
+// flows that span several requests.
+func setupMemoryServer(t *testing.T, options ...Option) *Client {
+	router, err := controllers.SetupRouter(repositories.NewMemory())
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+
+	c, err := New(server.URL, append([]Option{WithActor("tests")}, options...)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestClient(t *testing.T) {
+	c, mock := setupServer(t)
+	ctx := context.Background()
+	id := uuid.New()
+	birthdate := time.Date(2020, 2, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		expect   func()
+		call     func() error
+		wantCode ErrorCode
+	}{
+		{
+			name: "Should check health",
+			call: func() error { return c.Health(ctx) },
+		},
+		{
+			name: "Should list cats",
+			expect: func() {
+				mock.ExpectQuery(`SELECT \* FROM "cats"`).WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(id, "Nacho"))
+			},
+			call: func() error {
+				cats, err := c.Cats.List(ctx)
+				if err == nil && (len(cats) != 1 || cats[0].ID != id || cats[0].Name != "Nacho") {
+					return errors.New("unexpected cats")
+				}
+				return err
+			},
+		},
+		{
+			name: "Should count dogs",
+			expect: func() {
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "dogs"`).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+			},
+			call: func() error {
+				count, err := c.Dogs.Count(ctx)
+				if err == nil && count != 3 {
+					return errors.New("unexpected count")
+				}
+				return err
+			},
+		},
+		{
+			name: "Should create a cat",
+			expect: func() {
+				mock.ExpectQuery(`SELECT \* FROM "breeds"`).WillReturnRows(sqlmock.NewRows([]string{"id", "species", "name"}).AddRow(uuid.New(), "cats", "Tabby"))
+				mock.ExpectBegin()
+				mock.ExpectExec(`INSERT INTO "cats"`).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(`INSERT INTO "audit_entries"`).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			call: func() error {
+				cat, err := c.Cats.Create(ctx, &Cat{Name: "Nacho", Breed: "Tabby", Color: "Orange", Birthdate: birthdate, Weight: 17})
+				if err == nil && (cat.ID == uuid.Nil || cat.Name != "Nacho") {
+					return errors.New("unexpected cat")
+				}
+				return err
+			},
+		},
+		{
+			name: "Should return the invalid fields",
+			call: func() error {
+				_, err := c.Dogs.Create(ctx, &Dog{Name: "Spike", Breed: "Boxer", Color: "Brown", Birthdate: birthdate})
+				var apiErr *Error
+				if errors.As(err, &apiErr) && (len(apiErr.Errors) != 1 || apiErr.Errors[0].Field != "weight") {
+					return errors.New("unexpected field errors")
+				}
+				return err
+			},
+			wantCode: CodeInvalidBody,
+		},
+		{
+			name: "Should return not found",
+			expect: func() {
+				mock.ExpectQuery(`SELECT \* FROM "dogs" WHERE`).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			},
+			call: func() error {
+				_, err := c.Dogs.Get(ctx, id)
+				return err
+			},
+			wantCode: CodeNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.expect != nil {
+				tt.expect()
+			}
+
+			err := tt.call()
+			if tt.wantCode == "" && err != nil {
+				t.Errorf("Client error = %v", err)
+			}
+			if tt.wantCode != "" && !HasCode(err, tt.wantCode) {
+				t.Errorf("Client error = %v, wantCode %v", err, tt.wantCode)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestResource_Transition(t *testing.T) {
+	c := setupMemoryServer(t)
+	ctx := context.Background()
+
+	cat, err := c.Cats.Create(ctx, &Cat{Name: "Nacho", Breed: "Tabby", Color: "Orange", Birthdate: time.Date(2020, 2, 10, 0, 0, 0, 0, time.UTC), Weight: 5})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if cat.Status != StatusIntake {
+		t.Errorf("Create() status = %v, want %v", cat.Status, StatusIntake)
+	}
+
+	transition, err := c.Cats.Transition(ctx, cat.ID, StatusAvailable)
+	if err != nil {
+		t.Fatalf("Transition() error = %v", err)
+	}
+	if transition.From != StatusIntake || transition.To != StatusAvailable || transition.Actor != "tests" {
+		t.Errorf("Transition() = %+v, want intake to available by tests", transition)
+	}
+
+	if _, err := c.Cats.Transition(ctx, cat.ID, StatusIntake); !HasCode(err, CodeInvalidTransition) {
+		t.Errorf("Transition() error = %v, wantCode %v", err, CodeInvalidTransition)
+	}
+
+	transitions, err := c.Cats.Transitions(ctx, cat.ID)
+	if err != nil || len(transitions) != 1 {
+		t.Errorf("Transitions() = %v, %v, want one transition", transitions, err)
+	}
+	if got, err := c.Cats.Get(ctx, cat.ID); err != nil || got.Status != StatusAvailable {
+		t.Errorf("Get() = %+v, %v, want status %v", got, err, StatusAvailable)
+	}
+}
+
+func TestBreeds(t *testing.T) {
+	t.Setenv("ADMIN_API_KEY", "secret")
+	c := setupMemoryServer(t, WithAPIKey("secret"))
+	ctx := context.Background()
+
+	breed, err := c.CatBreeds.Create(ctx, &Breed{Name: "Savannah", Aliases: []string{"Savanna"}, MinWeight: 12, MaxWeight: 25})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if breed.ID == uuid.Nil || breed.Species != "cats" {
+		t.Errorf("Create() = %+v, want a cats breed with an ID", breed)
+	}
+
+	breed.MaxWeight = 30
+	if updated, err := c.CatBreeds.Update(ctx, breed.ID, breed); err != nil || updated.MaxWeight != 30 {
+		t.Errorf("Update() = %+v, %v, want max weight 30", updated, err)
+	}
+	if _, err := c.CatBreeds.Create(ctx, &Breed{Name: "Savannah"}); !HasCode(err, CodeConflict) {
+		t.Errorf("Create() error = %v, wantCode %v", err, CodeConflict)
+	}
+
+	cat, err := c.Cats.Create(ctx, &Cat{Name: "Nacho", Breed: "savanna", Color: "Brown", Birthdate: time.Date(2020, 2, 10, 0, 0, 0, 0, time.UTC), Weight: 20})
+	if err != nil {
+		t.Fatalf("Cats.Create() error = %v", err)
+	}
+	if cat.Breed != "Savannah" || cat.BreedStatus != BreedKnown {
+		t.Errorf("Cats.Create() breed = %v %v, want Savannah known", cat.Breed, cat.BreedStatus)
+	}
+
+	breeds, err := c.DogBreeds.List(ctx)
+	if err != nil || len(breeds) == 0 || breeds[0].Species != "dogs" {
+		t.Errorf("List() = %v, %v, want the dog breeds", breeds, err)
+	}
+
+	if err := c.CatBreeds.Delete(ctx, breed.ID); err != nil {
+		t.Errorf("Delete() error = %v", err)
+	}
+	if _, err := c.CatBreeds.Get(ctx, breed.ID); !IsNotFound(err) {
+		t.Errorf("Get() error = %v, want not found", err)
+	}
+}
+
+func TestApplications(t *testing.T) {
+	c := setupMemoryServer(t)
+	ctx := context.Background()
+
+	dog, err := c.Dogs.Create(ctx, &Dog{Name: "Rex", Breed: "Boxer", Color: "Brown", Birthdate: time.Date(2020, 2, 10, 0, 0, 0, 0, time.UTC), Weight: 60})
+	if err != nil {
+		t.Fatalf("Dogs.Create() error = %v", err)
+	}
+	if _, err := c.Dogs.Transition(ctx, dog.ID, StatusAvailable); err != nil {
+		t.Fatalf("Dogs.Transition() error = %v", err)
+	}
+
+	apply := func(name string) *Application {
+		application, err := c.Applications.Create(ctx, &Application{Species: "dogs", AnimalID: dog.ID, ApplicantName: name, ApplicantEmail: "applicant@example.com"})
+		if err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		return application
+	}
+	first := apply("Jane Doe")
+	second := apply("John Doe")
+	if first.Status != ApplicationSubmitted {
+		t.Errorf("Create() status = %v, want %v", first.Status, ApplicationSubmitted)
+	}
+
+	if _, err := c.Applications.Review(ctx, first.ID, ApplicationUnderReview); err != nil {
+		t.Fatalf("Review() error = %v", err)
+	}
+	approved, err := c.Applications.Approve(ctx, first.ID)
+	if err != nil || approved.Status != ApplicationApproved || approved.Reviewer != "tests" {
+		t.Fatalf("Approve() = %+v, %v, want approved by tests", approved, err)
+	}
+	if _, err := c.Applications.Reject(ctx, first.ID); !HasCode(err, CodeInvalidTransition) {
+		t.Errorf("Reject() error = %v, wantCode %v", err, CodeInvalidTransition)
+	}
+
+	rejected, err := c.Applications.List(ctx, ApplicationFilter{AnimalID: dog.ID, Status: ApplicationRejected})
+	if err != nil || len(rejected) != 1 || rejected[0].ID != second.ID {
+		t.Errorf("List() = %v, %v, want the other application rejected", rejected, err)
+	}
+	if got, err := c.Dogs.Get(ctx, dog.ID); err != nil || got.Status != StatusAdopted {
+		t.Errorf("Dogs.Get() = %+v, %v, want status %v", got, err, StatusAdopted)
+	}
+}
+
+func TestResource_Stats(t *testing.T) {
+	c := setupMemoryServer(t)
+	ctx := context.Background()
+
+	for _, weight := range []int{6, 8, 10} {
+		if _, err := c.Cats.Create(ctx, &Cat{Name: "Nacho", Breed: "Siamese", Color: "Grey", Birthdate: time.Date(2020, 2, 10, 0, 0, 0, 0, time.UTC), Weight: weight}); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	stats, err := c.Cats.Stats(ctx, StatsFilter{Breed: "siamese", MinWeight: 7})
+	if err != nil {
+		t.Fatalf("Stats() error = %v", err)
+	}
+	if stats.Total != 2 || len(stats.Weights) != 1 || stats.Weights[0].Median != 9 {
+		t.Errorf("Stats() = %+v, want 2 Siamese with a median weight of 9", stats)
+	}
+
+	if _, err := c.Cats.Stats(ctx, StatsFilter{Status: "lost"}); !HasCode(err, CodeInvalidQuery) {
+		t.Errorf("Stats() error = %v, wantCode %v", err, CodeInvalidQuery)
+	}
+}
+
+func TestClient_retry(t *testing.T) {
+	tests := []struct {
+		name         string
+		failures     int32
+		status       int
+		retryAfter   string
+		call         func(c *Client) error
+		wantRequests int32
+		wantErr      bool
+	}{
+		{
+			name:         "Should retry a read until it succeeds",
+			failures:     2,
+			status:       http.StatusServiceUnavailable,
+			call:         func(c *Client) error { return c.Health(context.Background()) },
+			wantRequests: 3,
+		},
+		{
+			name:         "Should give up after the last attempt",
+			failures:     5,
+			status:       http.StatusBadGateway,
+			call:         func(c *Client) error { return c.Health(context.Background()) },
+			wantRequests: 3,
+			wantErr:      true,
+		},
+		{
+			name:     "Should retry a create because it carries an idempotency key",
+			failures: 1,
+			status:   http.StatusTooManyRequests,
+			call: func(c *Client) error {
+				_, err := c.Cats.Create(context.Background(), &Cat{Name: "Nacho"})
+				return err
+			},
+			wantRequests: 2,
+		},
+		{
+			name:         "Should not retry a client error",
+			failures:     1,
+			status:       http.StatusBadRequest,
+			call:         func(c *Client) error { return c.Health(context.Background()) },
+			wantRequests: 1,
+			wantErr:      true,
+		},
+		{
+			name:         "Should not wait longer than the maximum backoff",
+			failures:     1,
+			status:       http.StatusTooManyRequests,
+			retryAfter:   "60",
+			call:         func(c *Client) error { return c.Health(context.Background()) },
+			wantRequests: 1,
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int32
+			keys := make(map[string]bool)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				keys[r.Header.Get(idempotencyKeyHeader)] = true
+				if atomic.AddInt32(&requests, 1) <= tt.failures {
+					if tt.retryAfter != "" {
+						w.Header().Set("Retry-After", tt.retryAfter)
+					}
+					w.WriteHeader(tt.status)
+					return
+				}
+				w.Write([]byte(`{}`))
+			}))
+			defer server.Close()
+
+			c, _ := New(server.URL, WithRetryPolicy(RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: 10 * time.Millisecond}))
+			err := tt.call(c)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Client error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if requests != tt.wantRequests {
+				t.Errorf("Client requests = %v, want %v", requests, tt.wantRequests)
+			}
+			if len(keys) != 1 {
+				t.Errorf("Client sent %v idempotency keys, want the same key on every attempt", len(keys))
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	if _, err := New("localhost:8080"); err == nil {
+		t.Errorf("New() error = nil, want an error for a URL without a scheme")
+	}
+}
@@ -0,0 +1,104 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// ErrorCode mirrors the code of the server's problem responses.
+type ErrorCode string
+
+const (
+	CodeInvalidID             ErrorCode = "invalid_id"
+	CodeInvalidBody           ErrorCode = "invalid_body"
+	CodeInvalidQuery          ErrorCode = "invalid_query"
+	CodeNotFound              ErrorCode = "not_found"
+	CodeNotAllowed            ErrorCode = "not_allowed"
+	CodeOriginNotAllowed      ErrorCode = "origin_not_allowed"
+	CodeRateLimited           ErrorCode = "rate_limited"
+	CodeInvalidIdempotencyKey ErrorCode = "invalid_idempotency_key"
+	CodeIdempotencyKeyInUse   ErrorCode = "idempotency_key_in_use"
+	CodeIdempotencyKeyReused  ErrorCode = "idempotency_key_reused"
+	CodeInvalidTenant         ErrorCode = "invalid_tenant"
+	CodeConflict              ErrorCode = "conflict"
+	CodeInvalidTransition     ErrorCode = "invalid_transition"
+	CodeInternal              ErrorCode = "internal_error"
+	CodeUnavailable           ErrorCode = "unavailable"
+)
+
+// FieldError describes a field the server rejected.
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// Error is returned for every response with a 4xx or 5xx status. Code is
+// empty when the response was not a problem document, e.g. when it came
+// from a proxy.
+type Error struct {
+	Type      string       `json:"type"`
+	Title     string       `json:"title"`
+	Status    int          `json:"status"`
+	Detail    string       `json:"detail"`
+	Code      ErrorCode    `json:"code"`
+	Instance  string       `json:"instance"`
+	RequestID string       `json:"request_id"`
+	Errors    []FieldError `json:"errors"`
+}
+
+func (e *Error) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d %s", e.Status, http.StatusText(e.Status))
+	if e.Code != "" {
+		fmt.Fprintf(&b, " (%s)", e.Code)
+	}
+	if e.Detail != "" {
+		fmt.Fprintf(&b, ": %s", e.Detail)
+	}
+	for _, field := range e.Errors {
+		fmt.Fprintf(&b, "; %s %s", field.Field, field.Message)
+	}
+	if e.RequestID != "" {
+		fmt.Fprintf(&b, " [request %s]", e.RequestID)
+	}
+	return b.String()
+}
+
+// HasCode reports whether err is an *Error with the given code.
+func HasCode(err error, code ErrorCode) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) && apiErr.Code == code
+}
+
+func IsNotFound(err error) bool {
+	return HasCode(err, CodeNotFound)
+}
+
+// maxErrorBody limits how much of a response that is not a problem
+// document ends up in Error.Detail.
+const maxErrorBody = 512
+
+func errorFromResponse(res *http.Response) error {
+	body, err := io.ReadAll(io.LimitReader(res.Body, 1<<20))
+	if err != nil {
+		return fmt.Errorf("reading the error response: %w", err)
+	}
+
+	apiErr := &Error{}
+	if json.Unmarshal(body, apiErr) != nil || apiErr.Status == 0 {
+		apiErr = &Error{Detail: strings.TrimSpace(string(body))}
+		if len(apiErr.Detail) > maxErrorBody {
+			apiErr.Detail = apiErr.Detail[:maxErrorBody]
+		}
+	}
+	apiErr.Status = res.StatusCode
+	if apiErr.RequestID == "" {
+		apiErr.RequestID = res.Header.Get("X-Request-ID")
+	}
+	return apiErr
+}
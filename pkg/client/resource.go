@@ -0,0 +1,264 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Resource is the collection of cats or dogs.
+type Resource[T any] struct {
+	client *Client
+	path   string
+}
+
+func (r *Resource[T]) List(ctx context.Context) ([]T, error) {
+	items := make([]T, 0)
+	return items, r.client.do(ctx, http.MethodGet, r.path, nil, nil, &items)
+}
+
+func (r *Resource[T]) Get(ctx context.Context, id uuid.UUID) (*T, error) {
+	item := new(T)
+	if err := r.client.do(ctx, http.MethodGet, r.itemPath(id), nil, nil, item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+func (r *Resource[T]) Count(ctx context.Context) (int64, error) {
+	result := new(count)
+	return result.Count, r.client.do(ctx, http.MethodPost, r.path+"/count", nil, nil, result)
+}
+
+// Create adds item and returns it as stored. Every call carries a fresh
+// Idempotency-Key unless one is given, so retries never create duplicates.
+func (r *Resource[T]) Create(ctx context.Context, item *T, options ...CallOption) (*T, error) {
+	header := http.Header{}
+	header.Set(idempotencyKeyHeader, uuid.NewString())
+	for _, option := range options {
+		option(&header)
+	}
+
+	created := new(T)
+	if err := r.client.do(ctx, http.MethodPost, r.path, item, header, created); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// Update replaces the fields of the item with the given ID and returns it
+// as stored.
+func (r *Resource[T]) Update(ctx context.Context, id uuid.UUID, item *T, options ...CallOption) (*T, error) {
+	header := http.Header{}
+	for _, option := range options {
+		option(&header)
+	}
+
+	updated := new(T)
+	if err := r.client.do(ctx, http.MethodPut, r.itemPath(id), item, header, updated); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func (r *Resource[T]) Delete(ctx context.Context, id uuid.UUID, options ...CallOption) error {
+	header := http.Header{}
+	for _, option := range options {
+		option(&header)
+	}
+	return r.client.do(ctx, http.MethodDelete, r.itemPath(id), nil, header, nil)
+}
+
+// History returns the audit entries of the item with the given ID.
+func (r *Resource[T]) History(ctx context.Context, id uuid.UUID) ([]AuditEntry, error) {
+	entries := make([]AuditEntry, 0)
+	return entries, r.client.do(ctx, http.MethodGet, r.itemPath(id)+"/history", nil, nil, &entries)
+}
+
+// Transition moves the item with the given ID to another shelter status.
+// Moves the server does not allow fail with CodeInvalidTransition.
+func (r *Resource[T]) Transition(ctx context.Context, id uuid.UUID, status string) (*Transition, error) {
+	transition := new(Transition)
+	if err := r.client.do(ctx, http.MethodPost, r.itemPath(id)+"/transitions", &transitionRequest{Status: status}, nil, transition); err != nil {
+		return nil, err
+	}
+	return transition, nil
+}
+
+// Transitions returns the status changes of the item with the given ID,
+// oldest first.
+func (r *Resource[T]) Transitions(ctx context.Context, id uuid.UUID) ([]Transition, error) {
+	transitions := make([]Transition, 0)
+	return transitions, r.client.do(ctx, http.MethodGet, r.itemPath(id)+"/transitions", nil, nil, &transitions)
+}
+
+// Stats summarizes the items matching filter. The server caches the result
+// for a while, so it can lag behind recent changes.
+func (r *Resource[T]) Stats(ctx context.Context, filter StatsFilter) (*AnimalStats, error) {
+	query := url.Values{}
+	if filter.Status != "" {
+		query.Set("status", filter.Status)
+	}
+	if filter.Breed != "" {
+		query.Set("breed", filter.Breed)
+	}
+	if filter.Color != "" {
+		query.Set("color", filter.Color)
+	}
+	if filter.MinWeight > 0 {
+		query.Set("min_weight", strconv.Itoa(filter.MinWeight))
+	}
+	if filter.MaxWeight > 0 {
+		query.Set("max_weight", strconv.Itoa(filter.MaxWeight))
+	}
+	if !filter.BornAfter.IsZero() {
+		query.Set("born_after", filter.BornAfter.Format(time.RFC3339))
+	}
+	if !filter.BornBefore.IsZero() {
+		query.Set("born_before", filter.BornBefore.Format(time.RFC3339))
+	}
+
+	path := "/stats" + r.path
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+	stats := new(AnimalStats)
+	if err := r.client.do(ctx, http.MethodGet, path, nil, nil, stats); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+func (r *Resource[T]) itemPath(id uuid.UUID) string {
+	return r.path + "/" + id.String()
+}
+
+// Breeds is the breed catalogue of cats or dogs. Changes require the admin
+// API key.
+type Breeds struct {
+	client *Client
+	path   string
+}
+
+func (b *Breeds) List(ctx context.Context) ([]Breed, error) {
+	breeds := make([]Breed, 0)
+	return breeds, b.client.do(ctx, http.MethodGet, b.path, nil, nil, &breeds)
+}
+
+func (b *Breeds) Get(ctx context.Context, id uuid.UUID) (*Breed, error) {
+	breed := new(Breed)
+	if err := b.client.do(ctx, http.MethodGet, b.itemPath(id), nil, nil, breed); err != nil {
+		return nil, err
+	}
+	return breed, nil
+}
+
+// Create adds breed. It is not retried, as the breed endpoints do not take
+// an Idempotency-Key.
+func (b *Breeds) Create(ctx context.Context, breed *Breed) (*Breed, error) {
+	created := new(Breed)
+	if err := b.client.do(ctx, http.MethodPost, b.path, breed, nil, created); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (b *Breeds) Update(ctx context.Context, id uuid.UUID, breed *Breed) (*Breed, error) {
+	updated := new(Breed)
+	if err := b.client.do(ctx, http.MethodPut, b.itemPath(id), breed, nil, updated); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func (b *Breeds) Delete(ctx context.Context, id uuid.UUID) error {
+	return b.client.do(ctx, http.MethodDelete, b.itemPath(id), nil, nil, nil)
+}
+
+func (b *Breeds) itemPath(id uuid.UUID) string {
+	return b.path + "/" + id.String()
+}
+
+// Applications are the adoption applications of the tenant.
+type Applications struct {
+	client *Client
+}
+
+func (a *Applications) List(ctx context.Context, filter ApplicationFilter) ([]Application, error) {
+	query := url.Values{}
+	if filter.Species != "" {
+		query.Set("species", filter.Species)
+	}
+	if filter.AnimalID != uuid.Nil {
+		query.Set("animal_id", filter.AnimalID.String())
+	}
+	if filter.Status != "" {
+		query.Set("status", filter.Status)
+	}
+
+	path := "/applications"
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+	applications := make([]Application, 0)
+	return applications, a.client.do(ctx, http.MethodGet, path, nil, nil, &applications)
+}
+
+func (a *Applications) Get(ctx context.Context, id uuid.UUID) (*Application, error) {
+	application := new(Application)
+	if err := a.client.do(ctx, http.MethodGet, a.itemPath(id), nil, nil, application); err != nil {
+		return nil, err
+	}
+	return application, nil
+}
+
+// Create submits application. It is not retried, as the applications
+// endpoint does not take an Idempotency-Key.
+func (a *Applications) Create(ctx context.Context, application *Application) (*Application, error) {
+	created := new(Application)
+	if err := a.client.do(ctx, http.MethodPost, "/applications", application, nil, created); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (a *Applications) Update(ctx context.Context, id uuid.UUID, application *Application) (*Application, error) {
+	updated := new(Application)
+	if err := a.client.do(ctx, http.MethodPut, a.itemPath(id), application, nil, updated); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func (a *Applications) Delete(ctx context.Context, id uuid.UUID) error {
+	return a.client.do(ctx, http.MethodDelete, a.itemPath(id), nil, nil, nil)
+}
+
+// Review moves the application to ApplicationUnderReview,
+// ApplicationApproved or ApplicationRejected. Moves the server does not
+// allow fail with CodeInvalidTransition.
+func (a *Applications) Review(ctx context.Context, id uuid.UUID, status string) (*Application, error) {
+	reviewed := new(Application)
+	if err := a.client.do(ctx, http.MethodPost, a.itemPath(id)+"/review", &reviewRequest{Status: status}, nil, reviewed); err != nil {
+		return nil, err
+	}
+	return reviewed, nil
+}
+
+// Approve approves the application, which marks the animal adopted and
+// rejects the other open applications for it.
+func (a *Applications) Approve(ctx context.Context, id uuid.UUID) (*Application, error) {
+	return a.Review(ctx, id, ApplicationApproved)
+}
+
+func (a *Applications) Reject(ctx context.Context, id uuid.UUID) (*Application, error) {
+	return a.Review(ctx, id, ApplicationRejected)
+}
+
+func (a *Applications) itemPath(id uuid.UUID) string {
+	return "/applications/" + id.String()
+}
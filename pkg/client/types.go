@@ -0,0 +1,209 @@
+package client
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Cat and Dog mirror the JSON the server sends and accepts. Leave ID empty
+// on create to have the server generate one.
+type Cat struct {
+	ID        uuid.UUID `json:"id,omitempty"`
+	Name      string    `json:"name"`
+	Breed     string    `json:"breed"`
+	Color     string    `json:"color"`
+	Birthdate time.Time `json:"birthdate"`
+	Weight    int       `json:"weight"`
+	// BreedStatus tells whether the breed is in the breed catalogue, see
+	// BreedKnown. It is set by the server.
+	BreedStatus string `json:"breed_status,omitempty"`
+	// Status is the shelter status, set by the server and changed with
+	// Resource.Transition.
+	Status string `json:"status,omitempty"`
+}
+
+type Dog struct {
+	ID        uuid.UUID `json:"id,omitempty"`
+	Name      string    `json:"name"`
+	Breed     string    `json:"breed"`
+	Color     string    `json:"color"`
+	Birthdate time.Time `json:"birthdate"`
+	Weight    int       `json:"weight"`
+	// BreedStatus tells whether the breed is in the breed catalogue, see
+	// BreedKnown. It is set by the server.
+	BreedStatus string `json:"breed_status,omitempty"`
+	// Status is the shelter status, set by the server and changed with
+	// Resource.Transition.
+	Status string `json:"status,omitempty"`
+}
+
+// The breed statuses of a cat or dog.
+const (
+	// BreedKnown marks a breed found in the catalogue, stored by its
+	// canonical name.
+	BreedKnown = "known"
+	// BreedUnknown flags a breed missing from the catalogue, accepted
+	// because the server is not in strict mode.
+	BreedUnknown = "unknown"
+)
+
+// Breed is an entry of the breed catalogue of cats or dogs. Adults must
+// weigh between MinWeight and MaxWeight, where zero is no bound.
+type Breed struct {
+	ID        uuid.UUID `json:"id,omitempty"`
+	Species   string    `json:"species,omitempty"`
+	Name      string    `json:"name"`
+	Aliases   []string  `json:"aliases"`
+	MinWeight int       `json:"min_weight"`
+	MaxWeight int       `json:"max_weight"`
+}
+
+// The shelter statuses of a cat or dog.
+const (
+	StatusIntake    = "intake"
+	StatusAvailable = "available"
+	StatusOnHold    = "on_hold"
+	StatusAdopted   = "adopted"
+	StatusReturned  = "returned"
+)
+
+// Transition records a change of the status of a cat or dog.
+type Transition struct {
+	ID         uuid.UUID `json:"id"`
+	Resource   string    `json:"resource"`
+	ResourceID uuid.UUID `json:"resource_id"`
+	From       string    `json:"from"`
+	To         string    `json:"to"`
+	Actor      string    `json:"actor"`
+	RequestID  string    `json:"request_id"`
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+type transitionRequest struct {
+	Status string `json:"status"`
+}
+
+// The review statuses of an adoption application.
+const (
+	ApplicationSubmitted   = "submitted"
+	ApplicationUnderReview = "under_review"
+	ApplicationApproved    = "approved"
+	ApplicationRejected    = "rejected"
+)
+
+// Application is a request to adopt the cat or dog with AnimalID, where
+// Species is "cats" or "dogs". Status, Reviewer and the timestamps are set
+// by the server.
+type Application struct {
+	ID             uuid.UUID `json:"id,omitempty"`
+	Species        string    `json:"species"`
+	AnimalID       uuid.UUID `json:"animal_id"`
+	ApplicantName  string    `json:"applicant_name"`
+	ApplicantEmail string    `json:"applicant_email"`
+	ApplicantPhone string    `json:"applicant_phone,omitempty"`
+	Notes          string    `json:"notes,omitempty"`
+	Status         string    `json:"status,omitempty"`
+	Reviewer       string    `json:"reviewer,omitempty"`
+	CreatedAt      time.Time `json:"created_at,omitempty"`
+	UpdatedAt      time.Time `json:"updated_at,omitempty"`
+}
+
+// ApplicationFilter narrows Applications.List. Zero fields are not
+// filtered on.
+type ApplicationFilter struct {
+	Species  string
+	AnimalID uuid.UUID
+	Status   string
+}
+
+type reviewRequest struct {
+	Status string `json:"status"`
+}
+
+// AnimalStats summarizes the cats or dogs matching a StatsFilter.
+type AnimalStats struct {
+	Total int64 `json:"total"`
+	// Breeds and Colors are ordered by count, largest first.
+	Breeds []GroupCount `json:"breeds"`
+	Colors []GroupCount `json:"colors"`
+	// Ages lists every age bucket, youngest first.
+	Ages []AgeBucket `json:"ages"`
+	// Weights is ordered by breed.
+	Weights []BreedWeight `json:"weights"`
+}
+
+type GroupCount struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+// AgeBucket counts the animals at least MinYears and less than MaxYears
+// old. The oldest bucket has no MaxYears.
+type AgeBucket struct {
+	Label    string `json:"label"`
+	MinYears int    `json:"min_years"`
+	MaxYears int    `json:"max_years,omitempty"`
+	Count    int64  `json:"count"`
+}
+
+// BreedWeight summarizes the weights of a breed. The percentiles are
+// nearest-rank.
+type BreedWeight struct {
+	Breed  string  `json:"breed"`
+	Count  int64   `json:"count"`
+	Mean   float64 `json:"mean"`
+	Median float64 `json:"median"`
+	P25    float64 `json:"p25"`
+	P75    float64 `json:"p75"`
+	P90    float64 `json:"p90"`
+}
+
+// StatsFilter narrows Resource.Stats. Zero fields are not filtered on.
+type StatsFilter struct {
+	Status     string
+	Breed      string
+	Color      string
+	MinWeight  int
+	MaxWeight  int
+	BornAfter  time.Time
+	BornBefore time.Time
+}
+
+type AuditEntry struct {
+	ID         uuid.UUID       `json:"id"`
+	Resource   string          `json:"resource"`
+	ResourceID uuid.UUID       `json:"resource_id"`
+	Action     string          `json:"action"`
+	Actor      string          `json:"actor"`
+	RequestID  string          `json:"request_id"`
+	Timestamp  time.Time       `json:"timestamp"`
+	Changes    json.RawMessage `json:"changes"`
+}
+
+// AuditFilter narrows Client.Audit. Zero fields are not filtered on.
+type AuditFilter struct {
+	Resource   string
+	ResourceID uuid.UUID
+	Actor      string
+	Since      time.Time
+	Until      time.Time
+}
+
+type CacheStats struct {
+	Name      string `json:"name"`
+	Hits      uint64 `json:"hits"`
+	Misses    uint64 `json:"misses"`
+	Evictions uint64 `json:"evictions"`
+	Size      int    `json:"size"`
+	Capacity  int    `json:"capacity"`
+}
+
+type count struct {
+	Count int64 `json:"count"`
+}
+
+type message struct {
+	Message string `json:"message"`
+}